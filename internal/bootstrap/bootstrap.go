@@ -22,6 +22,7 @@ import (
 	"github.com/ActiveMemory/ctx/internal/cli/agent"
 	"github.com/ActiveMemory/ctx/internal/cli/compact"
 	"github.com/ActiveMemory/ctx/internal/cli/complete"
+	"github.com/ActiveMemory/ctx/internal/cli/decision"
 	"github.com/ActiveMemory/ctx/internal/cli/drift"
 	"github.com/ActiveMemory/ctx/internal/cli/hook"
 	"github.com/ActiveMemory/ctx/internal/cli/initialize"
@@ -29,10 +30,12 @@ import (
 	"github.com/ActiveMemory/ctx/internal/cli/loop"
 	"github.com/ActiveMemory/ctx/internal/cli/recall"
 	"github.com/ActiveMemory/ctx/internal/cli/session"
+	"github.com/ActiveMemory/ctx/internal/cli/show"
 	"github.com/ActiveMemory/ctx/internal/cli/status"
 	"github.com/ActiveMemory/ctx/internal/cli/sync"
 	"github.com/ActiveMemory/ctx/internal/cli/task"
 	"github.com/ActiveMemory/ctx/internal/cli/watch"
+	"github.com/ActiveMemory/ctx/internal/config"
 )
 
 // version is set at build time via ldflags
@@ -43,10 +46,15 @@ const version = "dev"
 // The root command provides the entry point for all ctx subcommands and
 // displays help information when invoked without arguments.
 //
+// Global Flags:
+//   - --context-dir: Context directory, overriding CTX_DIR and .contextrc
+//
 // Returns:
 //   - *cobra.Command: The configured root command with usage and version info
 func RootCmd() *cobra.Command {
-	return &cobra.Command{
+	var contextDir string
+
+	cmd := &cobra.Command{
 		Use:   "ctx",
 		Short: "Context - persistent context for AI coding assistants",
 		Long: `Context (ctx) maintains persistent context files that help
@@ -56,7 +64,20 @@ func RootCmd() *cobra.Command {
   Use 'ctx init' to create a .context/ directory in your project,
   then use 'ctx status', 'ctx load', and 'ctx agent' to work with context.`,
 		Version: version,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			if contextDir != "" {
+				config.OverrideContextDir(contextDir)
+			}
+		},
 	}
+
+	cmd.PersistentFlags().StringVar(
+		&contextDir,
+		"context-dir", "",
+		"Context directory (overrides CTX_DIR and .contextrc)",
+	)
+
+	return cmd
 }
 
 // Initialize registers all ctx subcommands with the root command.
@@ -76,6 +97,8 @@ func Initialize(cmd *cobra.Command) *cobra.Command {
 	cmd.AddCommand(load.Cmd())
 	cmd.AddCommand(add.Cmd())
 	cmd.AddCommand(complete.Cmd())
+	cmd.AddCommand(show.Cmd())
+	cmd.AddCommand(decision.Cmd())
 	cmd.AddCommand(agent.Cmd())
 	cmd.AddCommand(drift.Cmd())
 	cmd.AddCommand(sync.Cmd())
@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/ActiveMemory/ctx/internal/cli/initialize"
+	"github.com/ActiveMemory/ctx/internal/context"
 )
 
 // TestAddCommand tests the add command.
@@ -359,6 +360,17 @@ func TestAppendEntry(t *testing.T) {
 		}
 	})
 
+	t.Run("decision skips the template example", func(t *testing.T) {
+		existing := []byte("# Decisions\n\n<!-- Format:\n\n## [YYYY-MM-DD] Title\n-->\n")
+		entry := "## [2026-01-01] First Decision\n\nContent\n"
+
+		result := string(AppendEntry(existing, entry, "decision", ""))
+
+		if !strings.HasSuffix(result, "-->\n"+entry) {
+			t.Errorf("decision should follow the comment, got: %s", result)
+		}
+	})
+
 	t.Run("learning on empty file", func(t *testing.T) {
 		existing := []byte("# Learnings\n\n<!-- Add gotchas here -->\n")
 		entry := "- **[2026-01-01]** First Learning\n"
@@ -371,6 +383,91 @@ func TestAppendEntry(t *testing.T) {
 	})
 }
 
+// TestAddAssignsIDs tests that added entries get sequential stable IDs.
+func TestAddAssignsIDs(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cli-add-id-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	// Archived IDs are never reused
+	archiveDir := filepath.Join(".context", "archive")
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		t.Fatalf("failed to create archive dir: %v", err)
+	}
+	if err := os.WriteFile(
+		filepath.Join(archiveDir, "tasks-2026-01-01.md"),
+		[]byte("# Archived Tasks - 2026-01-01\n\n- [x] Old task #id:T-007\n"),
+		0644,
+	); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+
+	adds := [][]string{
+		{"task", "First task"},
+		{"task", "Second task"},
+		{"decision", "Use IDs", "--context", "c", "--rationale", "r",
+			"--consequences", "c"},
+		{"learning", "IDs help", "--context", "c", "--lesson", "l",
+			"--application", "a"},
+	}
+	for _, args := range adds {
+		addCmd := Cmd()
+		addCmd.SetArgs(args)
+		if err := addCmd.Execute(); err != nil {
+			t.Fatalf("add %v failed: %v", args, err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		file string
+		want []string
+	}{
+		{"tasks", "TASKS.md", []string{
+			"First task #added:", "#id:T-008", "Second task #added:", "#id:T-009",
+		}},
+		{"decisions", "DECISIONS.md", []string{"**ID**: D-001"}},
+		{"learnings", "LEARNINGS.md", []string{"**ID**: L-001"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := os.ReadFile(filepath.Join(".context", tt.file))
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", tt.file, err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("%s missing %q:\n%s", tt.file, want, content)
+				}
+			}
+		})
+	}
+
+	idx, err := context.LoadIndex("")
+	if err != nil {
+		t.Fatalf("LoadIndex failed: %v", err)
+	}
+	for _, id := range []string{"T-008", "T-009", "D-001", "L-001"} {
+		if e, ok := idx.Lookup(id); !ok || e.Archived {
+			t.Errorf("Lookup(%s) = %+v, %v; want a live entry", id, e, ok)
+		}
+	}
+}
+
 // TestAddFromFile tests adding content from a file.
 func TestAddFromFile(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "cli-add-file-test-*")
@@ -72,8 +72,9 @@ func AppendEntry(
 // Used for DECISIONS.md to maintain reverse-chronological order.
 // Entries are inserted before any existing entries (identified by "## [").
 func prependAfterHeader(content, entry, header string) []byte {
-	// Find the first entry marker "## [" (timestamp-prefixed sections)
-	entryIdx := strings.Index(content, "## [")
+	// Find the first entry marker "## [" (timestamp-prefixed sections),
+	// ignoring the format example in the template comment
+	entryIdx := indexOutsideComments(content, "## [")
 	if entryIdx != -1 {
 		// Insert before the first entry, with separator after
 		return []byte(content[:entryIdx] + entry + "\n---\n\n" + content[entryIdx:])
@@ -120,14 +121,14 @@ func prependAfterHeader(content, entry, header string) []byte {
 // Entries are inserted before any existing entries (identified by "- **[").
 func prependAfterSeparator(content, entry string) []byte {
 	// Find the first entry marker "- **[" (timestamp-prefixed list items)
-	entryIdx := strings.Index(content, "- **[")
+	entryIdx := indexOutsideComments(content, "- **[")
 	if entryIdx != -1 {
 		// Insert before the first entry
 		return []byte(content[:entryIdx] + entry + "\n" + content[entryIdx:])
 	}
 
 	// Also check for section-style learnings "## ["
-	if entryIdx = strings.Index(content, "## ["); entryIdx != -1 {
+	if entryIdx = indexOutsideComments(content, "## ["); entryIdx != -1 {
 		return []byte(content[:entryIdx] + entry + "\n---\n\n" + content[entryIdx:])
 	}
 
@@ -167,3 +168,35 @@ func prependAfterSeparator(content, entry string) []byte {
 	}
 	return []byte(content + "\n" + entry)
 }
+
+// indexOutsideComments returns the index of the first occurrence of
+// marker that is not inside an HTML comment, or -1 if there is none.
+//
+// Parameters:
+//   - content: Text to search
+//   - marker: Substring to find
+//
+// Returns:
+//   - int: Byte index of the marker, or -1
+func indexOutsideComments(content, marker string) int {
+	offset := 0
+	for {
+		idx := strings.Index(content[offset:], marker)
+		if idx == -1 {
+			return -1
+		}
+		idx += offset
+
+		open := strings.LastIndex(content[:idx], "<!--")
+		if open == -1 || strings.LastIndex(content[:idx], "-->") > open {
+			return idx
+		}
+
+		// Inside a comment: resume the search after it closes
+		end := strings.Index(content[idx:], "-->")
+		if end == -1 {
+			return -1
+		}
+		offset = idx + end + 3
+	}
+}
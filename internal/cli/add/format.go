@@ -9,35 +9,46 @@ package add
 import (
 	"fmt"
 	"time"
+
+	"github.com/ActiveMemory/ctx/internal/context"
 )
 
 // FormatTask formats a task entry as a markdown checkbox item.
 //
-// The output includes a timestamp tag for session correlation and an optional
-// priority tag. Format: "- [ ] content #priority:level #added:YYYY-MM-DD-HHMMSS"
+// The output includes a timestamp tag for session correlation, an optional
+// priority tag, and the stable task ID.
+// Format: "- [ ] content #priority:level #added:YYYY-MM-DD-HHMMSS #id:T-001"
 //
 // Parameters:
 //   - content: Task description text
 //   - priority: Priority level (high, medium, low); empty string omits the tag
+//   - id: Stable task ID (e.g., "T-107"); empty string omits the tag
 //
 // Returns:
 //   - string: Formatted task line with trailing newline
-func FormatTask(content string, priority string) string {
+func FormatTask(content string, priority string, id string) string {
 	// Use YYYY-MM-DD-HHMMSS timestamp for session correlation
 	timestamp := time.Now().Format("2006-01-02-150405")
 	var priorityTag string
 	if priority != "" {
 		priorityTag = fmt.Sprintf(" #priority:%s", priority)
 	}
-	return fmt.Sprintf("- [ ] %s%s #added:%s\n", content, priorityTag, timestamp)
+	var idTag string
+	if id != "" {
+		idTag = fmt.Sprintf(" #id:%s", id)
+	}
+	return fmt.Sprintf(
+		"- [ ] %s%s #added:%s%s\n", content, priorityTag, timestamp, idTag,
+	)
 }
 
 // FormatLearning formats a learning entry as a structured markdown section.
 //
-// The output includes a timestamped heading and complete sections for context,
-// lesson, and application.
+// The output includes a timestamped heading, the stable ID, and complete
+// sections for context, lesson, and application.
 //
 // Parameters:
+//   - id: Stable learning ID (e.g., "L-032"); empty string omits the field
 //   - title: Learning title/summary text
 //   - context: What prompted this learning
 //   - lesson: The key insight
@@ -45,16 +56,16 @@ func FormatTask(content string, priority string) string {
 //
 // Returns:
 //   - string: Formatted learning section with all fields
-func FormatLearning(title, context, lesson, application string) string {
+func FormatLearning(id, title, context, lesson, application string) string {
 	timestamp := time.Now().Format("2006-01-02-150405")
 	return fmt.Sprintf(`## [%s] %s
-
+%s
 **Context**: %s
 
 **Lesson**: %s
 
 **Application**: %s
-`, timestamp, title, context, lesson, application)
+`, timestamp, title, formatID(id), context, lesson, application)
 }
 
 // FormatConvention formats a convention entry as a simple markdown list item.
@@ -72,10 +83,11 @@ func FormatConvention(content string) string {
 
 // FormatDecision formats a decision entry as a structured Markdown section.
 //
-// The output includes a timestamped heading, status, and complete ADR sections
-// for context, rationale, and consequences.
+// The output includes a timestamped heading, the stable ID, status, and
+// complete ADR sections for context, rationale, and consequences.
 //
 // Parameters:
+//   - id: Stable decision ID (e.g., "D-014"); empty string omits the field
 //   - title: Decision title/summary text
 //   - context: What prompted this decision
 //   - rationale: Why this choice over alternatives
@@ -83,10 +95,12 @@ func FormatConvention(content string) string {
 //
 // Returns:
 //   - string: Formatted decision section with all ADR fields
-func FormatDecision(title, context, rationale, consequences string) string {
+func FormatDecision(
+	id, title, context, rationale, consequences string,
+) string {
 	timestamp := time.Now().Format("2006-01-02-150405")
 	return fmt.Sprintf(`## [%s] %s
-
+%s
 **Status**: Accepted
 
 **Context**: %s
@@ -96,5 +110,20 @@ func FormatDecision(title, context, rationale, consequences string) string {
 **Rationale**: %s
 
 **Consequences**: %s
-`, timestamp, title, context, title, rationale, consequences)
+`, timestamp, title, formatID(id), context, title, rationale, consequences)
+}
+
+// formatID formats the ID field that opens a decision or learning body.
+//
+// Parameters:
+//   - id: Stable entry ID
+//
+// Returns:
+//   - string: "\n**ID**: id\n", placed between the heading and the blank
+//     line before the first field; empty string if id is empty
+func formatID(id string) string {
+	if id == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n**%s**: %s\n", context.FieldID, id)
 }
@@ -0,0 +1,39 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package add
+
+import (
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// EntryID returns the stable ID for a new entry of the given type.
+//
+// IDs are numbered per kind ("D-014", "L-032", "T-107"), continuing from
+// the highest ID found in the context directory and its archive, so
+// archived IDs are never reused.
+//
+// Parameters:
+//   - fType: Entry type (decision, task, learning, convention, or plurals)
+//
+// Returns:
+//   - string: Next ID for the type; empty for conventions, which have none
+//   - error: Non-nil if the context files cannot be read
+func EntryID(fType string) (string, error) {
+	var kind context.EntryKind
+	switch fType {
+	case config.UpdateTypeDecision, config.UpdateTypeDecisions:
+		kind = context.KindDecision
+	case config.UpdateTypeLearning, config.UpdateTypeLearnings:
+		kind = context.KindLearning
+	case config.UpdateTypeTask, config.UpdateTypeTasks:
+		kind = context.KindTask
+	default:
+		return "", nil
+	}
+	return context.NextID("", kind)
+}
@@ -10,7 +10,6 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/fatih/color"
@@ -151,7 +150,7 @@ Examples:
 		)
 	}
 
-	filePath := filepath.Join(config.DirContext, fName)
+	filePath := config.ContextPath(fName)
 
 	// Check if the file exists
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
@@ -166,15 +165,24 @@ Examples:
 		return fmt.Errorf("failed to read %s: %w", filePath, err)
 	}
 
+	id, err := EntryID(fType)
+	if err != nil {
+		return fmt.Errorf("failed to assign an ID: %w", err)
+	}
+
 	// Format the new entry based on type
 	var entry string
 	switch fType {
 	case config.UpdateTypeDecision, config.UpdateTypeDecisions:
-		entry = FormatDecision(content, flags.context, flags.rationale, flags.consequences)
+		entry = FormatDecision(
+			id, content, flags.context, flags.rationale, flags.consequences,
+		)
 	case config.UpdateTypeTask, config.UpdateTypeTasks:
-		entry = FormatTask(content, flags.priority)
+		entry = FormatTask(content, flags.priority, id)
 	case config.UpdateTypeLearning, config.UpdateTypeLearnings:
-		entry = FormatLearning(content, flags.context, flags.lesson, flags.application)
+		entry = FormatLearning(
+			id, content, flags.context, flags.lesson, flags.application,
+		)
 	case config.UpdateTypeConvention, config.UpdateTypeConventions:
 		entry = FormatConvention(content)
 	}
@@ -187,7 +195,11 @@ Examples:
 	}
 
 	green := color.New(color.FgGreen).SprintFunc()
-	cmd.Printf("%s Added to %s\n", green("✓"), fName)
+	if id != "" {
+		cmd.Printf("%s Added %s to %s\n", green("✓"), id, fName)
+	} else {
+		cmd.Printf("%s Added to %s\n", green("✓"), fName)
+	}
 
 	return nil
 }
@@ -16,7 +16,8 @@ import (
 //
 // The command reads context files from .context/ and outputs a concise packet
 // optimized for AI consumption, including constitution rules, active tasks,
-// conventions, and recent decisions.
+// conventions, recent decisions, and recent learnings, packed to fit the
+// token budget.
 //
 // Flags:
 //   - --budget: Token budget for the context packet (default 8000)
@@ -26,8 +27,9 @@ import (
 //   - *cobra.Command: Configured agent command with flags registered
 func Cmd() *cobra.Command {
 	var (
-		budget int
-		format string
+		budget      int
+		format      string
+		withHistory bool
 	)
 
 	cmd := &cobra.Command{
@@ -40,27 +42,39 @@ or piped to a system prompt. It includes:
   - Constitution rules (NEVER VIOLATE)
   - Current tasks
   - Key conventions
-  - Recent decisions
+  - Recent decisions (Accepted and not superseded)
+  - Recent learnings
 
 Use --budget to limit token output (default from .contextrc or 8000).
+Constitution rules are always included; the remaining sections are
+filled in priority order (tasks, conventions, decisions, learnings)
+and items that do not fit are truncated or omitted. The packet reports
+the tokens it actually uses and how many items were omitted.
 Use --format to choose between markdown (md) or JSON output.
 
+With --with-history, the packet also gets a Relevant History section:
+recent sessions run in this directory and the session chunks that best
+match the active tasks and recently changed files, as printed by
+'ctx recall --auto'. It has the lowest priority in the budget.
+
 Examples:
   ctx agent                    # Default token budget, markdown output
   ctx agent --budget 4000      # Smaller context packet for limited contexts
   ctx agent --format json      # JSON output for programmatic use
-  ctx agent --budget 2000 --format json`,
+  ctx agent --budget 2000 --format json
+  ctx agent --with-history     # Include relevant session history`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Use configured budget if flag not explicitly set
 			if !cmd.Flags().Changed("budget") {
 				budget = config.GetTokenBudget()
 			}
-			return runAgent(cmd, budget, format)
+			return runAgent(cmd, budget, format, withHistory)
 		},
 	}
 
 	cmd.Flags().IntVar(&budget, "budget", config.DefaultTokenBudget, "Token budget for context packet")
 	cmd.Flags().StringVar(&format, "format", "md", "Output format: md or json")
+	cmd.Flags().BoolVar(&withHistory, "with-history", false, "Include relevant session history")
 
 	return cmd
 }
@@ -7,10 +7,17 @@
 package agent
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ActiveMemory/ctx/internal/cli/initialize"
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
 )
 
 // TestAgentCommand tests the agent command.
@@ -71,3 +78,273 @@ func TestAgentJSONOutput(t *testing.T) {
 		t.Fatalf("agent --format json failed: %v", err)
 	}
 }
+
+// TestAgentBudgetPacking tests that the packet is packed to fit the budget.
+func TestAgentBudgetPacking(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cli-agent-budget-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	// Fill TASKS.md with far more tasks than a small budget can hold
+	var tasks strings.Builder
+	tasks.WriteString("# Tasks\n\n### Phase 1: Bulk\n\n")
+	for i := 0; i < 200; i++ {
+		tasks.WriteString(fmt.Sprintf(
+			"- [ ] Task %d with a reasonably long description to use tokens\n", i,
+		))
+	}
+	tasksPath := filepath.Join(config.DirContext, config.FilenameTask)
+	if err := os.WriteFile(tasksPath, []byte(tasks.String()), 0644); err != nil {
+		t.Fatalf("failed to write tasks: %v", err)
+	}
+
+	for _, format := range []string{"md", "json"} {
+		t.Run(format, func(t *testing.T) {
+			budget := 1500
+
+			agentCmd := Cmd()
+			var buf bytes.Buffer
+			agentCmd.SetOut(&buf)
+			agentCmd.SetArgs([]string{
+				"--budget", fmt.Sprint(budget), "--format", format,
+			})
+			if err := agentCmd.Execute(); err != nil {
+				t.Fatalf("agent failed: %v", err)
+			}
+
+			used := context.EstimateTokens(buf.Bytes())
+			if used > budget {
+				t.Errorf("packet uses %d tokens, budget is %d", used, budget)
+			}
+
+			if format == "md" {
+				out := buf.String()
+				if !strings.Contains(out, fmt.Sprintf("Used: %d\n", used)) {
+					t.Errorf("header does not report %d used tokens:\n%s", used, out)
+				}
+				if !strings.Contains(out, "## Omitted (over budget)") {
+					t.Error("expected omitted section in output")
+				}
+				return
+			}
+
+			var packet Packet
+			if err := json.Unmarshal(buf.Bytes(), &packet); err != nil {
+				t.Fatalf("failed to parse JSON output: %v", err)
+			}
+			if packet.TokensUsed != used {
+				t.Errorf("tokens_used = %d, want %d", packet.TokensUsed, used)
+			}
+			if len(packet.Constitution) == 0 {
+				t.Error("constitution rules must always be included")
+			}
+			if packet.Omitted[sectionTasks]+len(packet.Tasks) != 200 {
+				t.Errorf("tasks (%d) + omitted (%d) != 200",
+					len(packet.Tasks), packet.Omitted[sectionTasks])
+			}
+			if packet.Omitted[sectionTasks] == 0 {
+				t.Error("expected some tasks to be omitted")
+			}
+		})
+	}
+}
+
+// TestAgentBudgetKeepsConstitution tests that constitution rules are
+// emitted even when they alone exceed the budget.
+func TestAgentBudgetKeepsConstitution(t *testing.T) {
+	ctx := &context.Context{
+		Dir: config.DirContext,
+		Files: []context.FileInfo{
+			{
+				Name:    config.FilenameConstitution,
+				Content: []byte("- [ ] Rule one\n- [ ] Rule two\n"),
+			},
+			{
+				Name:    config.FilenameTask,
+				Content: []byte("- [ ] Some task\n"),
+			},
+		},
+	}
+
+	packet, err := buildPacket(ctx, 10, "md", nil)
+	if err != nil {
+		t.Fatalf("buildPacket failed: %v", err)
+	}
+	if len(packet.Constitution) != 2 {
+		t.Errorf("got %d constitution rules, want 2", len(packet.Constitution))
+	}
+	if len(packet.Tasks) != 0 || packet.Omitted[sectionTasks] != 1 {
+		t.Errorf("expected the task to be omitted, got tasks=%v omitted=%v",
+			packet.Tasks, packet.Omitted)
+	}
+}
+
+// TestExtractLearningTitles tests learning title extraction for both the
+// section and the list formats.
+func TestExtractLearningTitles(t *testing.T) {
+	content := `# Learnings
+
+## [2026-01-28-191951] Newest learning
+
+**Context**: ...
+
+- **[2026-01-28-072838]** Older list-style learning
+
+## [2026-01-27] Oldest learning
+`
+
+	got := extractLearningTitles(content, 2)
+	want := []string{"Newest learning", "Older list-style learning"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("title %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+// TestAgentBudgetPacksAfterMiss tests that a task too large for the budget
+// does not crowd out a short decision that still fits.
+func TestAgentBudgetPacksAfterMiss(t *testing.T) {
+	largeTask := "- [ ] " + strings.Repeat("Migrate every legacy handler ", 100)
+	ctx := &context.Context{
+		Dir: config.DirContext,
+		Files: []context.FileInfo{
+			{
+				Name:    config.FilenameTask,
+				Content: []byte(largeTask + "\n"),
+			},
+			{
+				Name:    config.FilenameDecision,
+				Content: []byte("# Decisions\n\n## [2026-01-05] Use SQLite\n"),
+			},
+		},
+	}
+
+	for _, format := range []string{"md", "json"} {
+		t.Run(format, func(t *testing.T) {
+			packet, err := buildPacket(ctx, 200, format, nil)
+			if err != nil {
+				t.Fatalf("buildPacket failed: %v", err)
+			}
+			if len(packet.Decisions) != 1 || packet.Decisions[0] != "Use SQLite" {
+				t.Errorf("expected the decision to be packed, got %v (omitted %v)",
+					packet.Decisions, packet.Omitted)
+			}
+			switch {
+			case len(packet.Tasks) == 0:
+				if packet.Omitted[sectionTasks] != 1 {
+					t.Errorf("omitted tasks = %d, want 1", packet.Omitted[sectionTasks])
+				}
+			case !strings.HasSuffix(packet.Tasks[0], truncationMarker):
+				t.Errorf("expected the large task to be dropped or truncated, got %q",
+					packet.Tasks[0])
+			}
+			if packet.TokensUsed > 200 {
+				t.Errorf("packet uses %d tokens, budget is 200", packet.TokensUsed)
+			}
+		})
+	}
+}
+
+// TestExtractDecisionTitlesActiveOnly tests that only decisions in force
+// reach the packet.
+func TestExtractDecisionTitlesActiveOnly(t *testing.T) {
+	content := `# Decisions
+
+## [2026-01-05] Use SQLite
+
+**Status**: Accepted
+
+**Supersedes**: [[D-001]]
+
+## [2026-01-04] Maybe GraphQL
+
+**Status**: Proposed
+
+## [2026-01-03] Use XML
+
+**Status**: Deprecated
+
+## [2026-01-02] Legacy decision without status
+
+## [2026-01-01] Use flat files
+
+**ID**: D-001
+
+**Status**: Superseded
+
+**Superseded By**: [[D-005]]
+`
+
+	got := extractDecisionTitles(content, 5)
+	want := []string{"Use SQLite", "Legacy decision without status"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("title %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+// TestAgentWithHistory tests folding session history into the packet.
+func TestAgentWithHistory(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	// A session run in this directory
+	session := fmt.Sprintf(`{"uuid":"u1","sessionId":"s1","slug":"calm-reading-turing","type":"user","timestamp":"2026-01-20T10:00:00Z","cwd":%q,"version":"2.1.0","message":{"role":"user","content":[{"type":"text","text":"Why do the retries never stop?"}]}}`+"\n", dir)
+	projects := filepath.Join(home, ".claude", "projects", "p")
+	if err := os.MkdirAll(projects, 0755); err != nil {
+		t.Fatalf("failed to create projects dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(projects, "s1.jsonl"), []byte(session), 0644); err != nil {
+		t.Fatalf("failed to write session: %v", err)
+	}
+
+	run := func(args ...string) string {
+		var out bytes.Buffer
+		cmd := Cmd()
+		cmd.SetOut(&out)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("agent %v failed: %v", args, err)
+		}
+		return out.String()
+	}
+
+	if out := run(); strings.Contains(out, "Relevant History") {
+		t.Errorf("history included without --with-history:\n%s", out)
+	}
+	out := run("--with-history")
+	if !strings.Contains(out, "## Relevant History\n- 2026-01-20 calm-reading-turing: Why do the retries never stop?") {
+		t.Errorf("history missing:\n%s", out)
+	}
+}
@@ -0,0 +1,326 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// Section keys, used as JSON keys in the omitted-items report.
+const (
+	sectionConstitution = "constitution"
+	sectionTasks        = "tasks"
+	sectionConventions  = "conventions"
+	sectionDecisions    = "decisions"
+	sectionLearnings    = "learnings"
+	sectionHistory      = "history"
+)
+
+const (
+	// maxDecisions is the number of recent decisions offered to the packer.
+	maxDecisions = 3
+	// maxLearnings is the number of recent learnings offered to the packer.
+	maxLearnings = 5
+	// minTruncateTokens is the smallest allowance worth spending on a
+	// truncated item; below it, the item is dropped instead.
+	minTruncateTokens = 8
+	// maxPackPasses bounds the number of re-packing attempts when the
+	// rendered packet overshoots the budget.
+	maxPackPasses = 5
+	// truncationMarker is appended to items cut short to fit the budget.
+	truncationMarker = "..."
+)
+
+// packetSection describes one budgeted list of items in a packet.
+//
+// Fields:
+//   - key: Section key used in the omitted-items report
+//   - title: Markdown heading text
+//   - prefix: Markdown line prefix for each item
+//   - items: Pointer to the packet field holding the items
+type packetSection struct {
+	key    string
+	title  string
+	prefix string
+	items  *[]string
+}
+
+// packetSections returns the budgeted sections of a packet in priority
+// order.
+//
+// The constitution always comes first and is never dropped. Tasks,
+// decisions, learnings, conventions, and session history follow in that
+// order and compete for what is left of the budget.
+//
+// Parameters:
+//   - p: Packet whose fields the sections point to
+//
+// Returns:
+//   - []packetSection: Sections in packing (and rendering) order
+func packetSections(p *Packet) []packetSection {
+	return []packetSection{
+		{sectionConstitution, "Constitution (NEVER VIOLATE)", "- ", &p.Constitution},
+		{sectionTasks, "Current Tasks", "", &p.Tasks},
+		{sectionDecisions, "Recent Decisions", "- ", &p.Decisions},
+		{sectionLearnings, "Recent Learnings", "- ", &p.Learnings},
+		{sectionConventions, "Key Conventions", "- ", &p.Conventions},
+		{sectionHistory, "Relevant History", "- ", &p.History},
+	}
+}
+
+// buildPacket assembles a context packet that fits within a token budget.
+//
+// All candidate items are extracted from the context, then packed in
+// priority order. The packet is rendered in the requested format and
+// measured; if rounding or the omitted-items report pushes it over the
+// budget, it is re-packed with a correspondingly smaller allowance.
+//
+// Parameters:
+//   - ctx: Loaded context containing the files
+//   - budget: Token budget for the rendered packet
+//   - format: Output format, "json" or "md"
+//   - history: Session history items, most relevant first; nil to leave
+//     the history out
+//
+// Returns:
+//   - *Packet: Packed packet with TokensUsed set to its rendered size
+//   - error: Non-nil if the packet cannot be rendered
+func buildPacket(
+	ctx *context.Context, budget int, format string, history []string,
+) (*Packet, error) {
+	candidates := &Packet{
+		Constitution: extractConstitutionRules(ctx),
+		Tasks:        extractActiveTasks(ctx),
+		Conventions:  extractConventions(ctx),
+		Decisions:    extractRecentDecisions(ctx, maxDecisions),
+		Learnings:    extractRecentLearnings(ctx, maxLearnings),
+		History:      history,
+	}
+	generated := time.Now().UTC().Format(time.RFC3339)
+	readOrder := getReadOrder(ctx)
+
+	var packet *Packet
+	limit := budget
+	for pass := 0; pass < maxPackPasses; pass++ {
+		packet = &Packet{
+			Generated: generated,
+			Budget:    budget,
+			ReadOrder: readOrder,
+		}
+		if err := packSections(packet, candidates, limit, format); err != nil {
+			return nil, err
+		}
+		used, err := measurePacket(packet, format)
+		if err != nil {
+			return nil, err
+		}
+		if used <= budget || !hasOptionalItems(packet) {
+			break
+		}
+		limit -= used - budget
+	}
+
+	return packet, nil
+}
+
+// packSections fills the sections of a packet from candidates within a
+// token allowance.
+//
+// The fixed part of the packet (header and read order) is charged first.
+// Constitution rules are always included. Other items are taken in
+// priority order whenever they fit, so a large item that is left out
+// does not crowd out smaller ones after it. The first item that did not
+// fit is then truncated into the room left over, if enough remains.
+// Items left out are counted in packet.Omitted.
+//
+// Parameters:
+//   - packet: Packet to fill; its section fields must be empty
+//   - candidates: Packet holding all candidate items
+//   - limit: Token allowance for the whole rendered packet
+//   - format: Output format, "json" or "md"
+//
+// Returns:
+//   - error: Non-nil if the fixed part of the packet cannot be rendered
+func packSections(
+	packet, candidates *Packet, limit int, format string,
+) error {
+	packet.TokensUsed = packet.Budget
+	base, err := renderPacket(packet, format)
+	if err != nil {
+		return err
+	}
+	remaining := limit - context.EstimateTokens(base)
+
+	// kept[i] maps the index of each kept item of section i to its text
+	src := packetSections(candidates)
+	kept := make([]map[int]string, len(src))
+	missSection, missItem := -1, -1
+	for i, s := range src {
+		kept[i] = make(map[int]string)
+		for j, item := range *s.items {
+			cost := itemCost(s, item, format)
+			if len(kept[i]) == 0 {
+				cost += headerCost(s, format)
+			}
+
+			if s.key == sectionConstitution || cost <= remaining {
+				kept[i][j] = item
+				remaining -= cost
+				continue
+			}
+			if missSection < 0 {
+				missSection, missItem = i, j
+			}
+		}
+	}
+
+	if missSection >= 0 {
+		s := src[missSection]
+		room := remaining
+		if len(kept[missSection]) == 0 {
+			room -= headerCost(s, format)
+		}
+		item := (*s.items)[missItem]
+		if short, ok := truncateItem(s, item, room, format); ok {
+			kept[missSection][missItem] = short
+		}
+	}
+
+	for i, dst := range packetSections(packet) {
+		items := *src[i].items
+		for j := range items {
+			if item, ok := kept[i][j]; ok {
+				*dst.items = append(*dst.items, item)
+			}
+		}
+
+		if skipped := len(items) - len(kept[i]); skipped > 0 {
+			if packet.Omitted == nil {
+				packet.Omitted = make(map[string]int)
+			}
+			packet.Omitted[dst.key] = skipped
+		}
+	}
+
+	return nil
+}
+
+// hasOptionalItems reports whether a packet holds anything besides
+// constitution rules, i.e., whether re-packing could make it smaller.
+//
+// Parameters:
+//   - packet: Packet to inspect
+//
+// Returns:
+//   - bool: True if any droppable section has items
+func hasOptionalItems(packet *Packet) bool {
+	for _, s := range packetSections(packet) {
+		if s.key != sectionConstitution && len(*s.items) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// measurePacket sets TokensUsed to the estimated size of the rendered
+// packet.
+//
+// Since the reported number is itself part of the output, the estimate
+// is repeated until it settles.
+//
+// Parameters:
+//   - packet: Packet to measure and update
+//   - format: Output format, "json" or "md"
+//
+// Returns:
+//   - int: Estimated tokens of the rendered packet
+//   - error: Non-nil if the packet cannot be rendered
+func measurePacket(packet *Packet, format string) (int, error) {
+	packet.TokensUsed = 0
+	for i := 0; i < 3; i++ {
+		out, err := renderPacket(packet, format)
+		if err != nil {
+			return 0, err
+		}
+		used := context.EstimateTokens(out)
+		if used == packet.TokensUsed {
+			break
+		}
+		packet.TokensUsed = used
+	}
+	return packet.TokensUsed, nil
+}
+
+// headerCost estimates the tokens added when a section gets its first item.
+//
+// Parameters:
+//   - s: Section being filled
+//   - format: Output format, "json" or "md"
+//
+// Returns:
+//   - int: Estimated token cost of the section heading
+func headerCost(s packetSection, format string) int {
+	if format == "json" {
+		// An empty list renders as "null"; a filled one as "[\n  ]"
+		return context.EstimateTokensString("[\n  ]")
+	}
+	return context.EstimateTokensString("## " + s.title + "\n\n")
+}
+
+// itemCost estimates the tokens one item adds to a rendered section.
+//
+// Parameters:
+//   - s: Section the item belongs to
+//   - item: Item text
+//   - format: Output format, "json" or "md"
+//
+// Returns:
+//   - int: Estimated token cost of the item
+func itemCost(s packetSection, item string, format string) int {
+	if format == "json" {
+		quoted, _ := json.Marshal(item)
+		return context.EstimateTokensString("    " + string(quoted) + ",\n")
+	}
+	return context.EstimateTokensString(s.prefix + item + "\n")
+}
+
+// truncateItem shortens an item so that it fits within a token allowance.
+//
+// Parameters:
+//   - s: Section the item belongs to
+//   - item: Item text to shorten
+//   - tokens: Token allowance for the item
+//   - format: Output format, "json" or "md"
+//
+// Returns:
+//   - string: Truncated item ending with a truncation marker
+//   - bool: False if the allowance is too small to be worth using
+func truncateItem(
+	s packetSection, item string, tokens int, format string,
+) (string, bool) {
+	if tokens < minTruncateTokens {
+		return "", false
+	}
+
+	runes := []rune(item)
+	n := len(runes)
+	for n > 0 {
+		short := strings.TrimSpace(string(runes[:n])) + truncationMarker
+		cost := itemCost(s, short, format)
+		if cost <= tokens {
+			return short, true
+		}
+		// Each token is roughly four characters; step down accordingly
+		n -= max(1, (cost-tokens)*4)
+	}
+
+	return "", false
+}
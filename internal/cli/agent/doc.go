@@ -16,7 +16,8 @@
 //   - agent.go: Command definition and flag registration
 //   - run.go: Main execution logic and context loading
 //   - extract.go: Functions for extracting content from context files
+//   - budget.go: Packing of extracted content into the token budget
 //   - sort.go: Priority sorting for tasks and decisions
-//   - out.go: Output formatting (Markdown and JSON)
+//   - out.go: Output rendering (Markdown and JSON)
 //   - types.go: Data structures for context packets
 package agent
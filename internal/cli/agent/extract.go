@@ -8,6 +8,7 @@ package agent
 
 import (
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/ActiveMemory/ctx/internal/config"
@@ -71,7 +72,7 @@ func extractConventions(ctx *context.Context) []string {
 //   - limit: Maximum number of decisions to return
 //
 // Returns:
-//   - []string: Decision titles (most recent last); nil if the file
+//   - []string: Decision titles (most recent first); nil if the file
 //     is not found
 func extractRecentDecisions(
 	ctx *context.Context, limit int,
@@ -84,6 +85,27 @@ func extractRecentDecisions(
 	return nil
 }
 
+// extractRecentLearnings extracts the most recent learning titles from
+// LEARNINGS.md.
+//
+// Parameters:
+//   - ctx: Loaded context containing the files
+//   - limit: Maximum number of learnings to return
+//
+// Returns:
+//   - []string: Learning titles (most recent first); nil if the file
+//     is not found
+func extractRecentLearnings(
+	ctx *context.Context, limit int,
+) []string {
+	for _, f := range ctx.Files {
+		if f.Name == config.FilenameLearning {
+			return extractLearningTitles(string(f.Content), limit)
+		}
+	}
+	return nil
+}
+
 // extractCheckboxItems extracts text from Markdown checkbox items.
 //
 // Matches both checked "- [x]" and unchecked "- [ ]" items.
@@ -103,22 +125,25 @@ func extractCheckboxItems(content string) []string {
 	return items
 }
 
-// extractUncheckedTasks extracts unchecked Markdown checkbox items.
+// extractUncheckedTasks extracts pending top-level tasks.
 //
-// Only matches "- [ ]" items (not checked). Returns items with the
-// "- [ ]" prefix preserved for display.
+// Subtasks are left out; they are listed with their parent in TASKS.md.
+// Returns items with the "- [ ]" prefix for display.
 //
 // Parameters:
-//   - content: Markdown content to parse
+//   - content: Markdown content of TASKS.md
 //
 // Returns:
-//   - []string: Unchecked task items with "- [ ]" prefix
+//   - []string: Pending task items with "- [ ]" prefix
 func extractUncheckedTasks(content string) []string {
-	re := regexp.MustCompile(`(?m)^-\s*\[\s*]\s*(.+)$`)
-	matches := re.FindAllStringSubmatch(content, -1)
-	items := make([]string, 0, len(matches))
-	for _, m := range matches {
-		items = append(items, "- [ ] "+strings.TrimSpace(m[1]))
+	doc := context.ParseDocument(config.FilenameTask, []byte(content))
+	var items []string
+	for _, e := range doc.Entries() {
+		task, ok := e.(*context.Task)
+		if !ok || task.State() != context.TaskPending {
+			continue
+		}
+		items = append(items, "- [ ] "+strings.TrimSpace(task.Text()))
 	}
 	return items
 }
@@ -150,28 +175,80 @@ func extractBulletItems(content string, limit int) []string {
 	return items
 }
 
-// extractDecisionTitles extracts decision titles from Markdown headings.
+// extractDecisionTitles extracts the most recent decision titles.
 //
-// Matches headings in the format "## [YYYY-MM-DD] Title" and returns
-// the most recent decisions (those appearing last in the file).
+// Only decisions in force are included: Proposed, Deprecated, and
+// superseded decisions are left out so agents do not act on them.
 //
 // Parameters:
-//   - content: Markdown content to parse
+//   - content: Markdown content of DECISIONS.md
 //   - limit: Maximum number of decision titles to return
 //
 // Returns:
-//   - []string: Decision titles without a timestamp prefix
+//   - []string: Decision titles without a timestamp prefix, most
+//     recent first
 func extractDecisionTitles(content string, limit int) []string {
-	re := regexp.MustCompile(`(?m)^##\s+\[[\d-]+]\s*(.+)$`)
-	matches := re.FindAllStringSubmatch(content, -1)
-	items := make([]string, 0, limit)
-	// Get the most recent (last) decisions
-	start := len(matches) - limit
-	if start < 0 {
-		start = 0
+	doc := context.ParseDocument(config.FilenameDecision, []byte(content))
+	var entries []datedTitle
+	for _, d := range doc.Decisions() {
+		if !d.IsActive() {
+			continue
+		}
+		entries = append(entries, datedTitle{d.Timestamp(), d.Title()})
+	}
+	return extractDatedTitles(entries, limit)
+}
+
+// extractLearningTitles extracts the most recent learning titles.
+//
+// Both the section format "## [YYYY-MM-DD-HHMMSS] Title" written by
+// "ctx add learning" and the older list format
+// "- **[YYYY-MM-DD-HHMMSS]** Text" are recognized.
+//
+// Parameters:
+//   - content: Markdown content of LEARNINGS.md
+//   - limit: Maximum number of learning titles to return
+//
+// Returns:
+//   - []string: Learning titles without a timestamp prefix, most
+//     recent first
+func extractLearningTitles(content string, limit int) []string {
+	doc := context.ParseDocument(config.FilenameLearning, []byte(content))
+	var entries []datedTitle
+	for _, l := range doc.Learnings() {
+		entries = append(entries, datedTitle{l.Timestamp(), l.Title()})
+	}
+	return extractDatedTitles(entries, limit)
+}
+
+// datedTitle is the timestamp and title of a decision or learning.
+type datedTitle struct {
+	timestamp string
+	title     string
+}
+
+// extractDatedTitles selects the most recent titles.
+//
+// Timestamps use the "YYYY-MM-DD[-HHMMSS]" format, which sorts
+// chronologically as plain strings. Entries with equal timestamps keep
+// their file order.
+//
+// Parameters:
+//   - entries: Timestamped titles in file order
+//   - limit: Maximum number of titles to return
+//
+// Returns:
+//   - []string: Trimmed titles, most recent first
+func extractDatedTitles(entries []datedTitle, limit int) []string {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].timestamp > entries[j].timestamp
+	})
+	if len(entries) > limit {
+		entries = entries[:limit]
 	}
-	for i := start; i < len(matches); i++ {
-		items = append(items, strings.TrimSpace(matches[i][1]))
+	items := make([]string, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, strings.TrimSpace(e.title))
 	}
 	return items
 }
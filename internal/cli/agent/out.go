@@ -10,114 +10,90 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
-	"time"
-
-	"github.com/spf13/cobra"
-
-	"github.com/ActiveMemory/ctx/internal/context"
 )
 
-// outputAgentJSON writes the context packet as pretty-printed JSON.
+// renderPacket renders a context packet in the requested format.
 //
 // Parameters:
-//   - cmd: Cobra command for output stream
-//   - ctx: Loaded context containing the files
-//   - budget: Token budget to include in the packet metadata
+//   - packet: Packed context packet
+//   - format: Output format, "json" for JSON, or any other value for Markdown
 //
 // Returns:
+//   - []byte: Rendered packet, exactly as it is written to the output
 //   - error: Non-nil if JSON encoding fails
-func outputAgentJSON(
-	cmd *cobra.Command, ctx *context.Context, budget int,
-) error {
-	packet := Packet{
-		Generated:    time.Now().UTC().Format(time.RFC3339),
-		Budget:       budget,
-		TokensUsed:   ctx.TotalTokens,
-		ReadOrder:    getReadOrder(ctx),
-		Constitution: extractConstitutionRules(ctx),
-		Tasks:        extractActiveTasks(ctx),
-		Conventions:  extractConventions(ctx),
-		Decisions:    extractRecentDecisions(ctx, 3),
+func renderPacket(packet *Packet, format string) ([]byte, error) {
+	if format == "json" {
+		return renderAgentJSON(packet)
 	}
+	return []byte(renderAgentMarkdown(packet)), nil
+}
 
-	enc := json.NewEncoder(cmd.OutOrStdout())
-	enc.SetIndent("", "  ")
-	return enc.Encode(packet)
+// renderAgentJSON renders the context packet as pretty-printed JSON.
+//
+// Parameters:
+//   - packet: Packed context packet
+//
+// Returns:
+//   - []byte: Indented JSON with a trailing newline
+//   - error: Non-nil if JSON encoding fails
+func renderAgentJSON(packet *Packet) ([]byte, error) {
+	out, err := json.MarshalIndent(packet, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
 }
 
-// outputAgentMarkdown writes the context packet as formatted Markdown.
+// renderAgentMarkdown renders the context packet as formatted Markdown.
 //
-// The output includes sections for read order, constitution rules,
-// current tasks, conventions, and recent decisions.
+// The output includes the read order, then one section per non-empty
+// packet section (constitution, tasks, conventions, decisions, learnings),
+// and finally a summary of items omitted to fit the budget.
 //
 // Parameters:
-//   - cmd: Cobra command for output stream
-//   - ctx: Loaded context containing the files
-//   - budget: Token budget to display in the header
+//   - packet: Packed context packet
 //
 // Returns:
-//   - error: Always nil (included for interface consistency)
-func outputAgentMarkdown(
-	cmd *cobra.Command, ctx *context.Context, budget int,
-) error {
+//   - string: Markdown document
+func renderAgentMarkdown(packet *Packet) string {
 	var sb strings.Builder
 
-	timestamp := time.Now().UTC().Format(time.RFC3339)
 	sb.WriteString("# Context Packet\n")
 	sb.WriteString(
 		fmt.Sprintf(
 			"Generated: %s | Budget: %d tokens | Used: %d\n\n",
-			timestamp, budget, ctx.TotalTokens,
+			packet.Generated, packet.Budget, packet.TokensUsed,
 		),
 	)
 
 	// Read order
 	sb.WriteString("## Read These Files (in order)\n")
-	for i, path := range getReadOrder(ctx) {
+	for i, path := range packet.ReadOrder {
 		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, path))
 	}
 	sb.WriteString("\n")
 
-	// Constitution
-	rules := extractConstitutionRules(ctx)
-	if len(rules) > 0 {
-		sb.WriteString("## Constitution (NEVER VIOLATE)\n")
-		for _, rule := range rules {
-			sb.WriteString(fmt.Sprintf("- %s\n", rule))
-		}
-		sb.WriteString("\n")
-	}
-
-	// Current tasks
-	tasks := extractActiveTasks(ctx)
-	if len(tasks) > 0 {
-		sb.WriteString("## Current Tasks\n")
-		for _, task := range tasks {
-			sb.WriteString(fmt.Sprintf("%s\n", task))
+	for _, s := range packetSections(packet) {
+		if len(*s.items) == 0 {
+			continue
 		}
-		sb.WriteString("\n")
-	}
-
-	// Conventions
-	conventions := extractConventions(ctx)
-	if len(conventions) > 0 {
-		sb.WriteString("## Key Conventions\n")
-		for _, conv := range conventions {
-			sb.WriteString(fmt.Sprintf("- %s\n", conv))
+		sb.WriteString(fmt.Sprintf("## %s\n", s.title))
+		for _, item := range *s.items {
+			sb.WriteString(fmt.Sprintf("%s%s\n", s.prefix, item))
 		}
 		sb.WriteString("\n")
 	}
 
-	// Recent decisions
-	decisions := extractRecentDecisions(ctx, 3)
-	if len(decisions) > 0 {
-		sb.WriteString("## Recent Decisions\n")
-		for _, dec := range decisions {
-			sb.WriteString(fmt.Sprintf("- %s\n", dec))
+	// Omitted items, in section priority order
+	if len(packet.Omitted) > 0 {
+		sb.WriteString("## Omitted (over budget)\n")
+		for _, s := range packetSections(packet) {
+			if n := packet.Omitted[s.key]; n > 0 {
+				sb.WriteString(fmt.Sprintf("- %s: %d\n", s.key, n))
+			}
 		}
 		sb.WriteString("\n")
 	}
 
-	cmd.Print(sb.String())
-	return nil
+	return sb.String()
 }
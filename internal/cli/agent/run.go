@@ -12,22 +12,27 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/ActiveMemory/ctx/internal/cli/recall"
 	"github.com/ActiveMemory/ctx/internal/context"
 )
 
 // runAgent executes the agent command logic.
 //
-// Loads context from .context/ and outputs a context packet in the
-// specified format (Markdown or JSON).
+// Loads context from .context/, packs a context packet to fit the token
+// budget, and outputs it in the specified format (Markdown or JSON).
 //
 // Parameters:
 //   - cmd: Cobra command for output stream
-//   - budget: Token budget to include in the output
+//   - budget: Token budget for the packet
 //   - format: Output format, "json" for JSON, or any other value for Markdown
+//   - withHistory: If true, add the session history relevant to the project
 //
 // Returns:
-//   - error: Non-nil if context loading fails or .context/ is not found
-func runAgent(cmd *cobra.Command, budget int, format string) error {
+//   - error: Non-nil if context loading, history search or rendering
+//     fails, or .context/ is not found
+func runAgent(
+	cmd *cobra.Command, budget int, format string, withHistory bool,
+) error {
 	ctx, err := context.Load("")
 	if err != nil {
 		var notFoundError *context.NotFoundError
@@ -37,9 +42,27 @@ func runAgent(cmd *cobra.Command, budget int, format string) error {
 		return err
 	}
 
-	if format == "json" {
-		return outputAgentJSON(cmd, ctx, budget)
+	var history []string
+	if withHistory {
+		res, err := recall.History(nil)
+		if err != nil {
+			return err
+		}
+		for _, r := range append(res.Related, res.Recent...) {
+			history = append(history, recall.HistoryItem(r))
+		}
+	}
+
+	packet, err := buildPacket(ctx, budget, format, history)
+	if err != nil {
+		return err
+	}
+
+	out, err := renderPacket(packet, format)
+	if err != nil {
+		return err
 	}
 
-	return outputAgentMarkdown(cmd, ctx, budget)
+	_, err = cmd.OutOrStdout().Write(out)
+	return err
 }
@@ -14,19 +14,25 @@ package agent
 // Fields:
 //   - Generated: RFC3339 timestamp of when the packet was created
 //   - Budget: Token budget specified by the user
-//   - TokensUsed: Actual token count of loaded context files
+//   - TokensUsed: Estimated token count of the emitted packet
 //   - ReadOrder: File paths in recommended reading order
 //   - Constitution: Rules from CONSTITUTION.md
 //   - Tasks: Active (unchecked) tasks from TASKS.md
 //   - Conventions: Key conventions from CONVENTIONS.md
 //   - Decisions: Recent decision titles from DECISIONS.md
+//   - Learnings: Recent learning titles from LEARNINGS.md
+//   - History: Relevant session history, with --with-history
+//   - Omitted: Number of items dropped per section to fit the budget
 type Packet struct {
-	Generated    string   `json:"generated"`
-	Budget       int      `json:"budget"`
-	TokensUsed   int      `json:"tokens_used"`
-	ReadOrder    []string `json:"read_order"`
-	Constitution []string `json:"constitution"`
-	Tasks        []string `json:"tasks"`
-	Conventions  []string `json:"conventions"`
-	Decisions    []string `json:"decisions"`
+	Generated    string         `json:"generated"`
+	Budget       int            `json:"budget"`
+	TokensUsed   int            `json:"tokens_used"`
+	ReadOrder    []string       `json:"read_order"`
+	Constitution []string       `json:"constitution"`
+	Tasks        []string       `json:"tasks"`
+	Conventions  []string       `json:"conventions"`
+	Decisions    []string       `json:"decisions"`
+	Learnings    []string       `json:"learnings"`
+	History      []string       `json:"history,omitempty"`
+	Omitted      map[string]int `json:"omitted,omitempty"`
 }
@@ -7,6 +7,7 @@
 package cli
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -210,3 +211,160 @@ func TestBinaryIntegration(t *testing.T) {
 		}
 	})
 }
+
+// TestBinaryCustomContextDir runs every writing command against a
+// non-default context directory.
+//
+// The directory is configured in turn through .contextrc, the CTX_DIR
+// environment variable, and the --context-dir flag. In each case all files
+// must end up in the configured directory and .context/ must never be
+// created.
+func TestBinaryCustomContextDir(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "cli-context-dir-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	binaryPath := filepath.Join(tmpDir, "ctx-test-binary")
+	buildCmd := exec.Command("go", "build", "-o", binaryPath, "./cmd/ctx")
+	buildCmd.Env = append(os.Environ(), "CGO_ENABLED=0")
+	projectRoot, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatalf("failed to get project root: %v", err)
+	}
+	buildCmd.Dir = projectRoot
+	if output, err := buildCmd.CombinedOutput(); err != nil {
+		t.Fatalf("failed to build binary: %v\n%s", err, output)
+	}
+
+	contextDir := filepath.Join("docs", "context")
+
+	tests := []struct {
+		name  string
+		setup func(projectDir string) error
+		env   []string
+		flags []string
+	}{
+		{
+			name: "contextrc",
+			setup: func(projectDir string) error {
+				return os.WriteFile(
+					filepath.Join(projectDir, ".contextrc"),
+					[]byte("context_dir: "+contextDir+"\n"), 0644,
+				)
+			},
+		},
+		{
+			name: "env",
+			env:  []string{"CTX_DIR=" + contextDir},
+		},
+		{
+			name:  "flag",
+			flags: []string{"--context-dir", contextDir},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := filepath.Join(tmpDir, tt.name)
+			if err := os.Mkdir(projectDir, 0755); err != nil {
+				t.Fatalf("failed to create project dir: %v", err)
+			}
+			if tt.setup != nil {
+				if err := tt.setup(projectDir); err != nil {
+					t.Fatalf("setup failed: %v", err)
+				}
+			}
+
+			run := func(stdin string, args ...string) string {
+				t.Helper()
+				cmd := exec.Command(binaryPath, append(args, tt.flags...)...)
+				cmd.Dir = projectDir
+				cmd.Env = append(os.Environ(), tt.env...)
+				cmd.Stdin = strings.NewReader(stdin)
+				output, err := cmd.CombinedOutput()
+				if err != nil {
+					t.Fatalf("ctx %s failed: %v\n%s",
+						strings.Join(args, " "), err, output)
+				}
+				return string(output)
+			}
+
+			run("", "init")
+			run("", "add", "task", "First task")
+			run("", "add", "decision", "Keep context in docs",
+				"--context", "Monorepo layout",
+				"--rationale", "Docs live together",
+				"--consequences", "Configure context_dir")
+			run("", "complete", "First task")
+			run("", "tasks", "snapshot", "before-archive")
+			run("", "tasks", "archive")
+			run("", "add", "task", "Second task")
+			run("", "complete", "Second task")
+			run("", "compact", "--archive")
+			run("", "session", "save", "custom dir")
+			run(
+				`<context-update type="convention">Watched convention</context-update>`+"\n",
+				"watch",
+			)
+
+			ctxDir := filepath.Join(projectDir, contextDir)
+			if err := os.Remove(filepath.Join(ctxDir, "CONSTITUTION.md")); err != nil {
+				t.Fatalf("failed to remove CONSTITUTION.md: %v", err)
+			}
+			run("", "drift", "--fix")
+
+			status := run("", "status")
+			if !strings.Contains(status, contextDir) {
+				t.Errorf("status does not report %s:\n%s", contextDir, status)
+			}
+			if out := run("", "agent"); !strings.Contains(out, contextDir) {
+				t.Errorf("agent read order does not use %s:\n%s", contextDir, out)
+			}
+
+			if _, err := os.Stat(filepath.Join(projectDir, ".context")); err == nil {
+				t.Error(".context/ was created despite a custom context directory")
+			}
+
+			expectations := []struct {
+				path     string
+				contains string
+			}{
+				{"DECISIONS.md", "Keep context in docs"},
+				{"CONVENTIONS.md", "Watched convention"},
+				{"CONSTITUTION.md", ""},
+			}
+			for _, e := range expectations {
+				content, err := os.ReadFile(filepath.Join(ctxDir, e.path))
+				if err != nil {
+					t.Errorf("failed to read %s: %v", e.path, err)
+					continue
+				}
+				if !strings.Contains(string(content), e.contains) {
+					t.Errorf("%s does not contain %q", e.path, e.contains)
+				}
+			}
+
+			for _, dir := range []string{"archive", "sessions"} {
+				entries, err := os.ReadDir(filepath.Join(ctxDir, dir))
+				if err != nil || len(entries) == 0 {
+					t.Errorf("expected files in %s/%s: %v", contextDir, dir, err)
+				}
+			}
+
+			archived, _ := os.ReadDir(filepath.Join(ctxDir, "archive"))
+			var names []string
+			for _, a := range archived {
+				names = append(names, a.Name())
+			}
+			if !strings.Contains(fmt.Sprint(names), "before-archive") {
+				t.Errorf("snapshot missing from archive: %v", names)
+			}
+		})
+	}
+}
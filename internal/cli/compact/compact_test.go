@@ -155,3 +155,53 @@ func TestCompactWithTasks(t *testing.T) {
 		t.Fatalf("compact failed: %v", err)
 	}
 }
+
+// TestCompactKeepsOpenSubtrees tests that compact only moves completed
+// tasks whose subtasks are all closed, and moves them with their subtasks.
+func TestCompactKeepsOpenSubtrees(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cli-compact-subtree-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	tasks := "# Tasks\n\n### Phase 1\n" +
+		"- [x] Open parent\n  - [ ] Open child\n" +
+		"- [x] Done parent\n  - [x] Done child\n" +
+		"- [ ] Pending\n\n" +
+		"## Completed\n\n- [x] Old\n"
+	if err := os.WriteFile(".context/TASKS.md", []byte(tasks), 0644); err != nil {
+		t.Fatalf("failed to write TASKS.md: %v", err)
+	}
+
+	compactCmd := Cmd()
+	compactCmd.SetArgs([]string{"--no-auto-save"})
+	if err := compactCmd.Execute(); err != nil {
+		t.Fatalf("compact failed: %v", err)
+	}
+
+	content, err := os.ReadFile(".context/TASKS.md")
+	if err != nil {
+		t.Fatalf("failed to read TASKS.md: %v", err)
+	}
+	want := "# Tasks\n\n### Phase 1\n" +
+		"- [x] Open parent\n  - [ ] Open child\n" +
+		"- [ ] Pending\n\n" +
+		"## Completed\n\n- [x] Old\n" +
+		"- [x] Done parent\n  - [x] Done child\n"
+	if string(content) != want {
+		t.Errorf("TASKS.md =\n%s\nwant:\n%s", content, want)
+	}
+}
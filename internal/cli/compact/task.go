@@ -10,7 +10,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 	"time"
 
@@ -21,11 +20,17 @@ import (
 	"github.com/ActiveMemory/ctx/internal/context"
 )
 
+// completedSection is the heading of the TASKS.md section that collects
+// completed tasks.
+const completedSection = "Completed"
+
 // compactTasks moves completed tasks to the "Completed" section in TASKS.md.
 //
-// Scans TASKS.md for checked items ("- [x]") outside the Completed section,
-// moves them into the Completed section, and optionally archives them to
-// .context/archive/.
+// Top-level tasks outside the Completed section move, with their
+// subtasks, once they are complete: checked ("- [x]") with no open
+// subtasks. Checked tasks with open subtasks stay in place. Moved tasks
+// are optionally archived to .context/archive/; without a Completed
+// section, tasks are only removed from TASKS.md when archived.
 //
 // Parameters:
 //   - cmd: Cobra command for output messages
@@ -50,91 +55,85 @@ func compactTasks(
 		return 0, nil
 	}
 
-	content := string(tasksFile.Content)
-	lines := strings.Split(content, "\n")
-
-	completedPattern := regexp.MustCompile(`^-\s*\[x]\s*(.+)$`)
-
-	var completedTasks []string
-	var newLines []string
-	inCompletedSection := false
-	changes := 0
+	doc := context.ParseDocument(config.FilenameTask, tasksFile.Content)
 
 	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
 
-	for _, line := range lines {
-		// Track if we're in the Completed section
-		if strings.HasPrefix(line, "## Completed") {
-			inCompletedSection = true
-			newLines = append(newLines, line)
+	var completedTasks []*context.Task
+	for _, e := range doc.Entries() {
+		task, ok := e.(*context.Task)
+		if !ok || task.State() != context.TaskDone ||
+			strings.HasPrefix(task.Section, completedSection) {
 			continue
 		}
-		if strings.HasPrefix(line, "## ") && inCompletedSection {
-			inCompletedSection = false
+		if open := task.OpenSubtasks(); len(open) > 0 {
+			cmd.Printf(
+				"%s Keeping completed task with %d open subtasks: %s\n",
+				yellow("○"), len(open), truncateString(task.Title(), 50),
+			)
+			continue
 		}
+		completedTasks = append(completedTasks, task)
+	}
 
-		// If completed task outside the Completed section, collect it
-		if !inCompletedSection && completedPattern.MatchString(line) {
-			matches := completedPattern.FindStringSubmatch(line)
-			if len(matches) > 1 {
-				completedTasks = append(completedTasks, matches[1])
-				cmd.Printf(
-					"%s Moving completed task: %s\n", green("✓"),
-					truncateString(matches[1], 50),
-				)
-				changes++
-				continue // Don't add to newLines
-			}
-		}
+	if len(completedTasks) == 0 {
+		return 0, nil
+	}
 
-		newLines = append(newLines, line)
+	var section string
+	for _, h := range doc.Headings() {
+		if strings.HasPrefix(h, completedSection) {
+			section = h
+			break
+		}
+	}
+	if section == "" && !archive {
+		// Nowhere to move the tasks to
+		return 0, nil
 	}
 
-	// If we have completed tasks to move, add them to the Completed section
-	if len(completedTasks) > 0 {
-		// Find the Completed section and add tasks there
-		for i, line := range newLines {
-			if strings.HasPrefix(line, "## Completed") {
-				// Find the next line that's either empty or another section
-				insertIdx := i + 1
-				for insertIdx < len(newLines) && newLines[insertIdx] != "" &&
-					!strings.HasPrefix(newLines[insertIdx], "## ") {
-					insertIdx++
-				}
-
-				// Insert completed tasks
-				var tasksToInsert []string
-				for _, task := range completedTasks {
-					tasksToInsert = append(tasksToInsert, fmt.Sprintf("- [x] %s", task))
-				}
-
-				// Insert at the right position
-				newContent := append(newLines[:insertIdx],
-					append(tasksToInsert, newLines[insertIdx:]...,
-					)...,
-				)
-				newLines = newContent
-				break
-			}
+	// Render the tasks before the document is re-parsed by the move
+	var archiveContent strings.Builder
+	for _, task := range completedTasks {
+		cmd.Printf(
+			"%s Moving completed task: %s\n", green("✓"),
+			truncateString(task.Title(), 50),
+		)
+		text := task.String()
+		archiveContent.WriteString(text)
+		if !strings.HasSuffix(text, "\n") {
+			archiveContent.WriteString("\n")
 		}
 	}
 
+	var err error
+	if section != "" {
+		err = doc.MoveTasks(completedTasks, section)
+	} else {
+		err = doc.RemoveTasks(completedTasks...)
+	}
+	if err != nil {
+		return 0, err
+	}
+
 	// Archive old content if requested
-	if archive && len(completedTasks) > 0 {
-		archiveDir := filepath.Join(config.DirContext, "archive")
+	if archive {
+		archiveDir := config.ContextPath(config.DirArchive)
 		if err := os.MkdirAll(archiveDir, 0755); err == nil {
 			archiveFile := filepath.Join(
 				archiveDir,
 				fmt.Sprintf("tasks-%s.md", time.Now().Format("2006-01-02")),
 			)
-			archiveContent := fmt.Sprintf(
+			content := fmt.Sprintf(
 				"# Archived Tasks - %s\n\n", time.Now().Format("2006-01-02"),
 			)
-			for _, task := range completedTasks {
-				archiveContent += fmt.Sprintf("- [x] %s\n", task)
+			if existing, err := os.ReadFile(archiveFile); err == nil {
+				content = string(existing) + "\n"
 			}
+			content += archiveContent.String()
 			if err := os.WriteFile(
-				archiveFile, []byte(archiveContent), 0644,
+				archiveFile, []byte(content), 0644,
 			); err == nil {
 				cmd.Printf(
 					"%s Archived %d tasks to %s\n", green("✓"),
@@ -145,14 +144,9 @@ func compactTasks(
 	}
 
 	// Write back
-	newContent := strings.Join(newLines, "\n")
-	if newContent != content {
-		if err := os.WriteFile(
-			tasksFile.Path, []byte(newContent), 0644,
-		); err != nil {
-			return 0, err
-		}
+	if err := os.WriteFile(tasksFile.Path, doc.Bytes(), 0644); err != nil {
+		return 0, err
 	}
 
-	return changes, nil
+	return len(completedTasks), nil
 }
@@ -12,27 +12,44 @@ import (
 
 // Cmd returns the "ctx complete" command for marking tasks as done.
 //
-// Tasks can be specified by number, partial text match, or full text.
+// Tasks can be specified by ID, number, partial text match, or full text.
 // The command updates TASKS.md by changing "- [ ]" to "- [x]".
 //
+// Flags:
+//   - --recursive, -r: Also complete the task's open subtasks
+//
 // Returns:
 //   - *cobra.Command: Configured complete command
 func Cmd() *cobra.Command {
+	var recursive bool
+
 	cmd := &cobra.Command{
 		Use:   "complete <task-id-or-text>",
 		Short: "Mark a task as completed",
 		Long: `Mark a task as completed in TASKS.md.
 
 You can specify a task by:
+  - Task ID (e.g., "ctx complete T-107")
   - Task number (e.g., "ctx complete 3")
   - Partial text match (e.g., "ctx complete auth")
   - Full task text (e.g., "ctx complete 'Implement user authentication'")
 
 The task will be marked with [x] 
-and optionally moved to the Completed section.`,
+and optionally moved to the Completed section.
+
+Completing a task with open subtasks leaves the subtasks open and lists
+them; the task is not archived until they are done. Use --recursive to
+complete the open subtasks as well.`,
 		Args: cobra.ExactArgs(1),
-		RunE: runComplete,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runComplete(cmd, args, recursive)
+		},
 	}
 
+	cmd.Flags().BoolVarP(
+		&recursive, "recursive", "r", false,
+		"Also complete the task's open subtasks",
+	)
+
 	return cmd
 }
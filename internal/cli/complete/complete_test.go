@@ -7,6 +7,7 @@
 package complete
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"strings"
@@ -62,3 +63,180 @@ func TestCompleteCommand(t *testing.T) {
 		t.Errorf("task was not marked as complete")
 	}
 }
+
+// TestCompleteByID tests completing a task by its stable ID.
+func TestCompleteByID(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cli-complete-id-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	for _, text := range []string{"Write docs", "Write tests"} {
+		addCmd := add.Cmd()
+		addCmd.SetArgs([]string{"task", text})
+		if err := addCmd.Execute(); err != nil {
+			t.Fatalf("add task command failed: %v", err)
+		}
+	}
+
+	// Lowercase and unpadded IDs are accepted
+	completeCmd := Cmd()
+	completeCmd.SetArgs([]string{"t-2"})
+	if err := completeCmd.Execute(); err != nil {
+		t.Fatalf("complete command failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, ".context", "TASKS.md"))
+	if err != nil {
+		t.Fatalf("failed to read TASKS.md: %v", err)
+	}
+	if !strings.Contains(string(content), "- [ ] Write docs") {
+		t.Errorf("T-001 should still be pending:\n%s", content)
+	}
+	if !strings.Contains(string(content), "- [x] Write tests") {
+		t.Errorf("T-002 was not marked as complete:\n%s", content)
+	}
+
+	completeCmd = Cmd()
+	completeCmd.SetArgs([]string{"T-002"})
+	completeCmd.SetOut(&bytes.Buffer{})
+	completeCmd.SetErr(&bytes.Buffer{})
+	if err := completeCmd.Execute(); err == nil {
+		t.Error("completing a done task should fail")
+	}
+
+	completeCmd = Cmd()
+	completeCmd.SetArgs([]string{"T-099"})
+	completeCmd.SetOut(&bytes.Buffer{})
+	completeCmd.SetErr(&bytes.Buffer{})
+	if err := completeCmd.Execute(); err == nil {
+		t.Error("completing an unknown ID should fail")
+	}
+}
+
+// TestCompleteSubtasks tests completing a task that has open subtasks.
+func TestCompleteSubtasks(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cli-complete-subtasks-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	tasksPath := filepath.Join(tmpDir, ".context", "TASKS.md")
+	tasks := "# Tasks\n\n" +
+		"- [ ] Parent #id:T-001\n" +
+		"  - [x] Done child #id:T-002\n" +
+		"  - [ ] Open child #id:T-003\n" +
+		"    - [ ] Open grandchild #id:T-004\n" +
+		"  - [-] Skipped child #id:T-005\n"
+	if err := os.WriteFile(tasksPath, []byte(tasks), 0644); err != nil {
+		t.Fatalf("failed to write TASKS.md: %v", err)
+	}
+
+	// Without --recursive, the parent is completed and open subtasks listed
+	var out bytes.Buffer
+	completeCmd := Cmd()
+	completeCmd.SetOut(&out)
+	completeCmd.SetArgs([]string{"T-001"})
+	if err := completeCmd.Execute(); err != nil {
+		t.Fatalf("complete command failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "2 subtasks are still open") ||
+		!strings.Contains(out.String(), "Open grandchild") {
+		t.Errorf("missing open subtask warning:\n%s", out.String())
+	}
+
+	content, err := os.ReadFile(tasksPath)
+	if err != nil {
+		t.Fatalf("failed to read TASKS.md: %v", err)
+	}
+	want := strings.Replace(tasks, "- [ ] Parent", "- [x] Parent", 1)
+	if string(content) != want {
+		t.Errorf("TASKS.md =\n%s\nwant:\n%s", content, want)
+	}
+
+	// --recursive closes the open subtasks of the completed parent
+	completeCmd = Cmd()
+	completeCmd.SetOut(&bytes.Buffer{})
+	completeCmd.SetArgs([]string{"T-001", "--recursive"})
+	if err := completeCmd.Execute(); err != nil {
+		t.Fatalf("complete --recursive failed: %v", err)
+	}
+
+	content, err = os.ReadFile(tasksPath)
+	if err != nil {
+		t.Fatalf("failed to read TASKS.md: %v", err)
+	}
+	want = strings.NewReplacer(
+		"- [ ] Open child", "- [x] Open child",
+		"- [ ] Open grandchild", "- [x] Open grandchild",
+	).Replace(want)
+	if string(content) != want {
+		t.Errorf("TASKS.md =\n%s\nwant:\n%s", content, want)
+	}
+}
+
+// TestCompleteOneSubtask tests the messages for a single open subtask.
+func TestCompleteOneSubtask(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	tasks := "# Tasks\n\n" +
+		"- [ ] Parent #id:T-001\n" +
+		"  - [ ] Open child #id:T-002\n"
+	if err := os.WriteFile(filepath.Join(".context", "TASKS.md"), []byte(tasks), 0644); err != nil {
+		t.Fatalf("failed to write TASKS.md: %v", err)
+	}
+
+	var out bytes.Buffer
+	completeCmd := Cmd()
+	completeCmd.SetOut(&out)
+	completeCmd.SetArgs([]string{"T-001"})
+	if err := completeCmd.Execute(); err != nil {
+		t.Fatalf("complete command failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "1 subtask is still open") {
+		t.Errorf("missing singular open subtask warning:\n%s", out.String())
+	}
+
+	out.Reset()
+	completeCmd = Cmd()
+	completeCmd.SetOut(&out)
+	completeCmd.SetArgs([]string{"T-001", "--recursive"})
+	if err := completeCmd.Execute(); err != nil {
+		t.Fatalf("complete --recursive failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Also completed 1 open subtask\n") {
+		t.Errorf("missing singular recursive message:\n%s", out.String())
+	}
+}
@@ -9,114 +9,110 @@ package complete
 import (
 	"fmt"
 	"os"
-	"path/filepath"
-	"regexp"
 	"strconv"
-	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
+	"github.com/ActiveMemory/ctx/internal/cli/task"
 	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
 )
 
 // runComplete executes the complete command logic.
 //
-// Finds a task in TASKS.md by number or text match and marks it complete
-// by changing "- [ ]" to "- [x]".
+// Finds a task in TASKS.md by ID, number, or text match and marks it
+// complete by changing "- [ ]" to "- [x]". Open subtasks are completed
+// too when recursive is set; otherwise they are left open and reported.
 //
 // Parameters:
 //   - cmd: Cobra command for output messages
-//   - args: Command arguments; args[0] is the task number or search text
+//   - args: Command arguments; args[0] is the task ID, number, or search
+//     text
+//   - recursive: If true, also complete the task's open subtasks
 //
 // Returns:
 //   - error: Non-nil if the task is not found, multiple matches, or file
 //     operations fail
-func runComplete(cmd *cobra.Command, args []string) error {
+func runComplete(cmd *cobra.Command, args []string, recursive bool) error {
 	query := args[0]
 
-	filePath := filepath.Join(config.DirContext, config.FilenameTask)
+	filePath := config.ContextPath(config.FilenameTask)
 
 	// Check if the file exists
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		return fmt.Errorf("TASKS.md not found. Run 'ctx init' first")
 	}
 
-	// Read existing content
-	content, err := os.ReadFile(filePath)
+	// Read and parse existing content
+	doc, err := context.LoadDocument("", config.FilenameTask)
 	if err != nil {
 		return fmt.Errorf("failed to read TASKS.md: %w", err)
 	}
 
-	// Parse tasks and find matching one
-	lines := strings.Split(string(content), "\n")
-	taskPattern := regexp.MustCompile(`^(\s*)-\s*\[\s*]\s*(.+)$`)
-
-	var taskNumber int
-	isNumber := false
-	if num, err := strconv.Atoi(query); err == nil {
-		taskNumber = num
-		isNumber = true
+	matched, err := task.FindTask(doc, query)
+	if err != nil {
+		return err
 	}
-
-	currentTaskNum := 0
-	matchedLine := -1
-	matchedTask := ""
-
-	for i, line := range lines {
-		matches := taskPattern.FindStringSubmatch(line)
-		if matches != nil {
-			currentTaskNum++
-			taskText := matches[2]
-
-			// Match by number
-			if isNumber && currentTaskNum == taskNumber {
-				matchedLine = i
-				matchedTask = taskText
-				break
-			}
-
-			// Match by text (case-insensitive partial match)
-			if !isNumber && strings.Contains(
-				strings.ToLower(taskText), strings.ToLower(query),
-			) {
-				if matchedLine != -1 {
-					// Multiple matches - be more specific
-					return fmt.Errorf(
-						"multiple tasks match %q. Be more specific or use task number",
-						query,
-					)
-				}
-				matchedLine = i
-				matchedTask = taskText
-			}
-		}
+	// A completed task with open subtasks can still be completed
+	// recursively to close them
+	open := matched.OpenSubtasks()
+	switch state := matched.State(); {
+	case state == context.TaskPending:
+	case state == context.TaskDone && recursive && len(open) > 0:
+	default:
+		return fmt.Errorf("task %s is not pending", matched.ID())
 	}
 
-	if matchedLine == -1 {
-		if isNumber {
-			return fmt.Errorf(
-				"task #%d not found. Use 'ctx status' to see tasks", taskNumber,
-			)
+	// Mark the task as complete and write back
+	matched.SetState(context.TaskDone)
+	if recursive {
+		for _, sub := range open {
+			sub.SetState(context.TaskDone)
 		}
-		return fmt.Errorf(
-			"no task matching %q found. Use 'ctx status' to see tasks", query,
-		)
 	}
-
-	// Mark the task as complete
-	lines[matchedLine] = taskPattern.ReplaceAllString(
-		lines[matchedLine], "$1- [x] $2",
-	)
-
-	// Write back
-	newContent := strings.Join(lines, "\n")
-	if err := os.WriteFile(filePath, []byte(newContent), 0644); err != nil {
+	if err := doc.Save(); err != nil {
 		return fmt.Errorf("failed to write TASKS.md: %w", err)
 	}
 
 	green := color.New(color.FgGreen).SprintFunc()
-	cmd.Printf("%s Completed: %s\n", green("✓"), matchedTask)
+	cmd.Printf("%s Completed: %s\n", green("✓"), matched.Text())
+
+	switch {
+	case len(open) == 0:
+	case recursive:
+		cmd.Printf("  Also completed %s\n", plural(len(open), "open subtask"))
+	default:
+		verb := "are"
+		if len(open) == 1 {
+			verb = "is"
+		}
+		yellow := color.New(color.FgYellow).SprintFunc()
+		cmd.Printf(
+			"%s %s %s still open; the task stays in TASKS.md "+
+				"until they are done:\n", yellow("○"), plural(len(open), "subtask"), verb,
+		)
+		for _, sub := range open {
+			cmd.Printf("  - [ ] %s\n", sub.Title())
+		}
+		cmd.Println("  Use --recursive to complete them too.")
+	}
 
 	return nil
 }
+
+// plural formats a count with a noun, adding an "s" unless the count
+// is one.
+//
+// Parameters:
+//   - n: Count
+//   - noun: Singular noun
+//
+// Returns:
+//   - string: E.g. "1 subtask" or "3 subtasks"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(n) + " " + noun + "s"
+}
@@ -0,0 +1,121 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package decision
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// Cmd returns the "ctx decision" command with lifecycle subcommands.
+//
+// Returns:
+//   - *cobra.Command: Configured decision command with subcommands
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decision",
+		Short: "Manage the status of decisions",
+		Long: `Manage the status of decisions in DECISIONS.md.
+
+Decisions are identified by ID (e.g., D-014) or by a unique part of their
+title. The Status field is updated in place; everything else in the entry
+is left as written.
+
+Only Accepted decisions that have not been superseded are included in
+'ctx agent' output, so reversed decisions stop reaching agents.
+
+Subcommands:
+  propose    Mark a decision as Proposed
+  accept     Mark a decision as Accepted
+  supersede  Replace a decision with a newer one
+  deprecate  Mark a decision as Deprecated`,
+	}
+
+	cmd.AddCommand(statusCmd(
+		"propose", context.StatusProposed,
+		"Mark a decision as Proposed",
+		"Proposed decisions are under discussion and are not shown to agents.",
+	))
+	cmd.AddCommand(statusCmd(
+		"accept", context.StatusAccepted,
+		"Mark a decision as Accepted",
+		"Accepted decisions are in force and are shown to agents.",
+	))
+	cmd.AddCommand(supersedeCmd())
+	cmd.AddCommand(statusCmd(
+		"deprecate", context.StatusDeprecated,
+		"Mark a decision as Deprecated",
+		"Deprecated decisions no longer apply and have no replacement.",
+	))
+
+	return cmd
+}
+
+// statusCmd returns a subcommand that sets the status of a decision.
+//
+// Parameters:
+//   - name: Subcommand name
+//   - status: Status written to the decision
+//   - short: Short help text
+//   - detail: Sentence describing what the status means
+//
+// Returns:
+//   - *cobra.Command: Configured status subcommand
+func statusCmd(name, status, short, detail string) *cobra.Command {
+	return &cobra.Command{
+		Use:   name + " <id-or-title>",
+		Short: short,
+		Long: short + ` in DECISIONS.md.
+
+` + detail + `
+
+Examples:
+  ctx decision ` + name + ` D-014
+  ctx decision ` + name + ` "PostgreSQL"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSetStatus(cmd, args[0], status)
+		},
+	}
+}
+
+// supersedeCmd returns the decision supersede subcommand.
+//
+// Flags:
+//   - --by: The newer decision replacing the old one (required)
+//
+// Returns:
+//   - *cobra.Command: Configured supersede subcommand
+func supersedeCmd() *cobra.Command {
+	var by string
+
+	cmd := &cobra.Command{
+		Use:   "supersede <old> --by <new>",
+		Short: "Replace a decision with a newer one",
+		Long: `Mark a decision as Superseded by a newer decision.
+
+The old decision gets "Status: Superseded" and a "Superseded By" field
+referencing the new one; the new decision gets a "Supersedes" field
+referencing the old one. Decisions without an ID are given one so that
+the links resolve with 'ctx show'.
+
+Example:
+  ctx decision supersede D-003 --by D-014`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSupersede(cmd, args[0], by)
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&by, "by", "", "ID or title of the decision that replaces it",
+	)
+	_ = cmd.MarkFlagRequired("by")
+
+	return cmd
+}
@@ -0,0 +1,207 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package decision
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/cli/add"
+	"github.com/ActiveMemory/ctx/internal/cli/initialize"
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// TestDecisionLifecycle tests status changes and superseding.
+func TestDecisionLifecycle(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cli-decision-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	for _, title := range []string{"Use REST", "Use GraphQL", "Use XML"} {
+		addCmd := add.Cmd()
+		addCmd.SetArgs([]string{
+			"decision", title,
+			"--context", "c", "--rationale", "r", "--consequences", "c",
+		})
+		if err := addCmd.Execute(); err != nil {
+			t.Fatalf("add decision failed: %v", err)
+		}
+	}
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := Cmd()
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+	decisions := func() map[string]*context.Decision {
+		doc, err := context.LoadDocument("", config.FilenameDecision)
+		if err != nil {
+			t.Fatalf("failed to load DECISIONS.md: %v", err)
+		}
+		byID := make(map[string]*context.Decision)
+		for _, d := range doc.Decisions() {
+			byID[d.ID()] = d
+		}
+		return byID
+	}
+
+	t.Run("propose and accept", func(t *testing.T) {
+		out, err := run("propose", "graphql")
+		if err != nil {
+			t.Fatalf("propose failed: %v", err)
+		}
+		if !strings.Contains(out, "D-002: Accepted → Proposed") {
+			t.Errorf("unexpected output: %s", out)
+		}
+		if got := decisions()["D-002"].Lifecycle(); got != context.StatusProposed {
+			t.Errorf("status = %q, want Proposed", got)
+		}
+
+		if _, err := run("accept", "D-2"); err != nil {
+			t.Fatalf("accept failed: %v", err)
+		}
+		if got := decisions()["D-002"].Lifecycle(); got != context.StatusAccepted {
+			t.Errorf("status = %q, want Accepted", got)
+		}
+	})
+
+	t.Run("deprecate", func(t *testing.T) {
+		if _, err := run("deprecate", "D-003"); err != nil {
+			t.Fatalf("deprecate failed: %v", err)
+		}
+		if decisions()["D-003"].IsActive() {
+			t.Error("deprecated decision should not be active")
+		}
+	})
+
+	t.Run("supersede links both ways", func(t *testing.T) {
+		if _, err := run("supersede", "D-001", "--by", "D-002"); err != nil {
+			t.Fatalf("supersede failed: %v", err)
+		}
+		all := decisions()
+		old, replacement := all["D-001"], all["D-002"]
+		if old.Lifecycle() != context.StatusSuperseded ||
+			old.SupersededBy() != "D-002" {
+			t.Errorf("old decision not superseded:\n%s", old)
+		}
+		if got := replacement.Supersedes(); len(got) != 1 || got[0] != "D-001" {
+			t.Errorf("Supersedes() = %v, want [D-001]", got)
+		}
+		if !replacement.IsActive() {
+			t.Error("replacement should stay active")
+		}
+
+		// Repeating the command does not duplicate the link
+		if _, err := run("supersede", "D-001", "--by", "D-002"); err != nil {
+			t.Fatalf("repeated supersede failed: %v", err)
+		}
+		if got := decisions()["D-002"].Supersedes(); len(got) != 1 {
+			t.Errorf("Supersedes() = %v after repeat", got)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		cases := [][]string{
+			{"accept", "D-001"},                     // superseded
+			{"supersede", "D-003", "--by", "D-001"}, // replacement superseded
+			{"supersede", "D-002", "--by", "D-002"}, // self
+			{"supersede", "D-001", "--by", "D-003"}, // already superseded
+			{"accept", "Use"},                       // ambiguous title
+			{"accept", "D-042"},                     // unknown ID
+		}
+		for _, args := range cases {
+			if _, err := run(args...); err == nil {
+				t.Errorf("%v should fail", args)
+			}
+		}
+	})
+}
+
+// TestSupersedeAssignsIDs tests superseding decisions written without IDs.
+func TestSupersedeAssignsIDs(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	if err := os.MkdirAll(".context", 0755); err != nil {
+		t.Fatalf("failed to create .context: %v", err)
+	}
+	content := `# Decisions
+
+## [2026-01-02] Use Postgres
+
+**Status**: Accepted
+
+## [2026-01-01] Use MySQL
+
+**ID**: D-004
+
+**Status**: Accepted (implemented)
+`
+	if err := os.WriteFile(
+		".context/DECISIONS.md", []byte(content), 0644,
+	); err != nil {
+		t.Fatalf("failed to write DECISIONS.md: %v", err)
+	}
+
+	cmd := Cmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"supersede", "mysql", "--by", "postgres"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("supersede failed: %v", err)
+	}
+
+	got, err := os.ReadFile(".context/DECISIONS.md")
+	if err != nil {
+		t.Fatalf("failed to read DECISIONS.md: %v", err)
+	}
+	want := `# Decisions
+
+## [2026-01-02] Use Postgres
+
+**ID**: D-005
+
+**Status**: Accepted
+
+**Supersedes**: [[D-004]]
+
+## [2026-01-01] Use MySQL
+
+**ID**: D-004
+
+**Status**: Superseded
+
+**Superseded By**: [[D-005]]
+`
+	if string(got) != want {
+		t.Errorf("DECISIONS.md =\n%s\nwant:\n%s", got, want)
+	}
+}
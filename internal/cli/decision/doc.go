@@ -0,0 +1,25 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+// Package decision implements the "ctx decision" command for managing the
+// lifecycle of decisions in DECISIONS.md.
+//
+// Each subcommand rewrites the Status field of a decision in place:
+//   - propose: Mark a decision as Proposed
+//   - accept: Mark a decision as Accepted
+//   - supersede: Mark a decision as Superseded by a newer one, linking
+//     both ways with "Superseded By" and "Supersedes" fields
+//   - deprecate: Mark a decision as Deprecated
+//
+// Only Accepted, non-superseded decisions are included in "ctx agent"
+// packets.
+//
+// # File Organization
+//
+//   - decision.go: Command and subcommand definitions
+//   - run.go: Status updates and linking
+//   - match.go: Decision lookup by ID or title
+package decision
@@ -0,0 +1,75 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package decision
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// findDecision looks up a decision by ID or by title.
+//
+// An ID (e.g., "D-014" or "d-14") must match exactly. Otherwise the query
+// is matched case-insensitively against titles and must match exactly one
+// decision.
+//
+// Parameters:
+//   - doc: Parsed DECISIONS.md
+//   - query: Decision ID or part of its title
+//
+// Returns:
+//   - *context.Decision: Matching decision
+//   - error: Non-nil if no decision or more than one decision matches
+func findDecision(
+	doc *context.Document, query string,
+) (*context.Decision, error) {
+	if id, ok := context.NormalizeID(query); ok {
+		for _, d := range doc.Decisions() {
+			if dID, _ := context.NormalizeID(d.ID()); dID == id {
+				return d, nil
+			}
+		}
+		return nil, fmt.Errorf("decision %s not found", id)
+	}
+
+	var matched *context.Decision
+	for _, d := range doc.Decisions() {
+		if !strings.Contains(
+			strings.ToLower(d.Title()), strings.ToLower(query),
+		) {
+			continue
+		}
+		if matched != nil {
+			return nil, fmt.Errorf(
+				"multiple decisions match %q. Be more specific or use an ID",
+				query,
+			)
+		}
+		matched = d
+	}
+	if matched == nil {
+		return nil, fmt.Errorf("no decision matching %q found", query)
+	}
+	return matched, nil
+}
+
+// label returns a short name for a decision in messages: its ID if it
+// has one, its title otherwise.
+//
+// Parameters:
+//   - d: Decision to name
+//
+// Returns:
+//   - string: ID or quoted title
+func label(d *context.Decision) string {
+	if d.ID() != "" {
+		return d.ID()
+	}
+	return fmt.Sprintf("%q", d.Title())
+}
@@ -0,0 +1,185 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package decision
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// loadDecisions reads and parses DECISIONS.md.
+//
+// Returns:
+//   - *context.Document: Parsed DECISIONS.md
+//   - error: Non-nil if the context directory or the file is missing
+func loadDecisions() (*context.Document, error) {
+	if !context.Exists("") {
+		return nil, fmt.Errorf(
+			"no .context/ directory found. Run 'ctx init' first",
+		)
+	}
+	doc, err := context.LoadDocument("", config.FilenameDecision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read DECISIONS.md: %w", err)
+	}
+	return doc, nil
+}
+
+// runSetStatus executes the propose, accept, and deprecate subcommands.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - query: Decision ID or part of its title
+//   - status: Status to set
+//
+// Returns:
+//   - error: Non-nil if the decision is not found, has been superseded,
+//     or file operations fail
+func runSetStatus(cmd *cobra.Command, query, status string) error {
+	doc, err := loadDecisions()
+	if err != nil {
+		return err
+	}
+
+	d, err := findDecision(doc, query)
+	if err != nil {
+		return err
+	}
+
+	if by := d.SupersededBy(); by != "" {
+		return fmt.Errorf(
+			"decision %s was superseded by %s; update %s instead",
+			label(d), by, by,
+		)
+	}
+
+	previous := d.Lifecycle()
+	if previous == status {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		cmd.Printf("%s %s is already %s\n", yellow("○"), label(d), status)
+		return nil
+	}
+
+	d.SetStatus(status)
+	if err := doc.Save(); err != nil {
+		return fmt.Errorf("failed to write DECISIONS.md: %w", err)
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cmd.Printf(
+		"%s %s: %s → %s\n", green("✓"), label(d), previous, status,
+	)
+	return nil
+}
+
+// runSupersede executes the supersede subcommand.
+//
+// The old decision is marked Superseded and linked to the new one with a
+// "Superseded By" field; the new decision lists the old one in its
+// "Supersedes" field. Either decision lacking an ID is given one.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - oldQuery: ID or title of the decision being replaced
+//   - newQuery: ID or title of the decision replacing it
+//
+// Returns:
+//   - error: Non-nil if either decision is not found, they are the same,
+//     either is already superseded, or file operations fail
+func runSupersede(cmd *cobra.Command, oldQuery, newQuery string) error {
+	doc, err := loadDecisions()
+	if err != nil {
+		return err
+	}
+
+	old, err := findDecision(doc, oldQuery)
+	if err != nil {
+		return err
+	}
+	replacement, err := findDecision(doc, newQuery)
+	if err != nil {
+		return err
+	}
+	if old == replacement {
+		return fmt.Errorf("a decision cannot supersede itself")
+	}
+	if by := replacement.SupersededBy(); by != "" {
+		return fmt.Errorf(
+			"decision %s was itself superseded by %s",
+			label(replacement), by,
+		)
+	}
+
+	if err := assignIDs(old, replacement); err != nil {
+		return err
+	}
+	oldID, _ := context.NormalizeID(old.ID())
+	newID, _ := context.NormalizeID(replacement.ID())
+
+	if by := old.SupersededBy(); by != "" && by != newID {
+		return fmt.Errorf(
+			"decision %s was already superseded by %s", oldID, by,
+		)
+	}
+
+	old.SetStatus(context.StatusSuperseded)
+	old.SetField(context.FieldSupersededBy, "[["+newID+"]]")
+
+	supersedes := replacement.Supersedes()
+	if !slices.Contains(supersedes, oldID) {
+		supersedes = append(supersedes, oldID)
+	}
+	refs := make([]string, len(supersedes))
+	for i, id := range supersedes {
+		refs[i] = "[[" + id + "]]"
+	}
+	replacement.SetField(context.FieldSupersedes, strings.Join(refs, ", "))
+
+	if err := doc.Save(); err != nil {
+		return fmt.Errorf("failed to write DECISIONS.md: %w", err)
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cmd.Printf("%s %s superseded by %s\n", green("✓"), oldID, newID)
+	return nil
+}
+
+// assignIDs gives an ID to each decision that lacks a valid one.
+//
+// Parameters:
+//   - decisions: Decisions to check, numbered in the order given
+//
+// Returns:
+//   - error: Non-nil if the context files cannot be indexed
+func assignIDs(decisions ...*context.Decision) error {
+	var missing []*context.Decision
+	for _, d := range decisions {
+		if _, ok := context.NormalizeID(d.ID()); !ok {
+			missing = append(missing, d)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	idx, err := context.LoadIndex("")
+	if err != nil {
+		return fmt.Errorf("failed to assign an ID: %w", err)
+	}
+	_, next, _ := context.ParseID(idx.Next(context.KindDecision))
+	for i, d := range missing {
+		d.SetID(context.FormatID(context.KindDecision, next+i))
+	}
+	return nil
+}
@@ -38,6 +38,8 @@ Checks performed:
   - Staleness indicators (many completed tasks)
   - Constitution rule violations (potential secrets)
   - Required files are present
+  - Entry IDs are unique and [[ID]] references resolve
+  - Task dependencies (#after:T-012) exist and form no cycles
 
 Use --json for machine-readable output.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
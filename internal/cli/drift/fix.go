@@ -50,7 +50,8 @@ func applyFixes(
 	green := color.New(color.FgGreen).SprintFunc()
 	yellow := color.New(color.FgYellow).SprintFunc()
 
-	// Process warnings (staleness, missing_file, dead_path)
+	// Process warnings (staleness, missing_file, dead_path, entry references,
+	// task dependencies)
 	for _, issue := range report.Warnings {
 		switch issue.Type {
 		case "staleness":
@@ -76,6 +77,16 @@ func applyFixes(
 			cmd.Printf("%s Cannot auto-fix dead path in %s:%d (%s)\n",
 				yellow("○"), issue.File, issue.Line, issue.Path)
 			result.skipped++
+
+		case "unknown_reference", "archived_reference", "duplicate_id":
+			cmd.Printf("%s Cannot auto-fix entry reference in %s:%d (%s)\n",
+				yellow("○"), issue.File, issue.Line, issue.Message)
+			result.skipped++
+
+		case "missing_dependency", "dependency_cycle":
+			cmd.Printf("%s Cannot auto-fix task dependency in %s:%d (%s)\n",
+				yellow("○"), issue.File, issue.Line, issue.Message)
+			result.skipped++
 		}
 	}
 
@@ -152,7 +163,7 @@ func fixStaleness(cmd *cobra.Command, ctx *context.Context) error {
 	}
 
 	// Create an archive directory
-	archiveDir := filepath.Join(config.DirContext, "archive")
+	archiveDir := config.ContextPath(config.DirArchive)
 	if err := os.MkdirAll(archiveDir, 0755); err != nil {
 		return fmt.Errorf("failed to create archive directory: %w", err)
 	}
@@ -209,11 +220,12 @@ func fixMissingFile(cmd *cobra.Command, filename string) error {
 		return fmt.Errorf("no template available for %s: %w", filename, err)
 	}
 
-	targetPath := filepath.Join(config.DirContext, filename)
+	targetPath := config.ContextPath(filename)
 
-	// Ensure .context/ directory exists
-	if err := os.MkdirAll(config.DirContext, 0755); err != nil {
-		return fmt.Errorf("failed to create .context/: %w", err)
+	// Ensure the context directory exists
+	contextDir := config.GetContextDir()
+	if err := os.MkdirAll(contextDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s/: %w", contextDir, err)
 	}
 
 	if err := os.WriteFile(targetPath, content, 0644); err != nil {
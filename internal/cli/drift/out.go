@@ -62,6 +62,8 @@ func outputDriftText(cmd *cobra.Command, report *drift.Report) error {
 		// Group by type
 		var pathRefs []drift.Issue
 		var staleness []drift.Issue
+		var entryRefs []drift.Issue
+		var taskDeps []drift.Issue
 		var other []drift.Issue
 
 		for _, w := range report.Warnings {
@@ -70,6 +72,10 @@ func outputDriftText(cmd *cobra.Command, report *drift.Report) error {
 				pathRefs = append(pathRefs, w)
 			case "staleness":
 				staleness = append(staleness, w)
+			case "unknown_reference", "archived_reference", "duplicate_id":
+				entryRefs = append(entryRefs, w)
+			case "missing_dependency", "dependency_cycle":
+				taskDeps = append(taskDeps, w)
 			default:
 				other = append(other, w)
 			}
@@ -93,6 +99,22 @@ func outputDriftText(cmd *cobra.Command, report *drift.Report) error {
 			cmd.Println()
 		}
 
+		if len(entryRefs) > 0 {
+			cmd.Println("  Entry References:")
+			for _, w := range entryRefs {
+				cmd.Printf("  - %s:%d %s\n", w.File, w.Line, w.Message)
+			}
+			cmd.Println()
+		}
+
+		if len(taskDeps) > 0 {
+			cmd.Println("  Task Dependencies:")
+			for _, w := range taskDeps {
+				cmd.Printf("  - %s:%d %s\n", w.File, w.Line, w.Message)
+			}
+			cmd.Println()
+		}
+
 		if len(other) > 0 {
 			cmd.Println("  Other:")
 			for _, w := range other {
@@ -25,6 +25,10 @@ func formatCheckName(name string) string {
 		return "Constitution rules respected"
 	case "required_files":
 		return "All required files present"
+	case "entry_references":
+		return "Entry references resolve"
+	case "task_dependencies":
+		return "Task dependencies resolve without cycles"
 	default:
 		return name
 	}
@@ -39,7 +39,7 @@ func runInit(cmd *cobra.Command, force, minimal, merge bool) error {
 		return err
 	}
 
-	contextDir := config.DirContext
+	contextDir := config.GetContextDir()
 
 	// Check if .context/ already exists
 	if _, err := os.Stat(contextDir); err == nil {
@@ -136,7 +136,10 @@ func runInit(cmd *cobra.Command, force, minimal, merge bool) error {
 	}
 
 	cmd.Println("\nNext steps:")
-	cmd.Println("  1. Edit .context/TASKS.md to add your current tasks")
+	cmd.Printf(
+		"  1. Edit %s to add your current tasks\n",
+		filepath.Join(contextDir, config.FilenameTask),
+	)
 	cmd.Println("  2. Run 'ctx status' to see context summary")
 	cmd.Println("  3. Run 'ctx agent' to get AI-ready context packet")
 
@@ -28,6 +28,18 @@
 //   - aider: Aider AI pair programming tool
 //   - generic: Template for custom tools
 //
+// # In-Process Runner
+//
+// "ctx loop run" runs the loop without a script. Each iteration's output
+// is streamed to the terminal and logged to .context/loops/<run-id>/,
+// and <context-update> tags in it are applied through the watch command's
+// update handlers. The runner enforces iteration and wall-clock limits
+// and stops the running tool on Ctrl-C.
+//
+// Optional stop conditions end a run when a TASKS.md phase is complete,
+// when a token or cost budget is spent, or when iterations stop making
+// progress. Each run records why it stopped in summary.json.
+//
 // # Completion Signal
 //
 // The completion signal (default: "SYSTEM_CONVERGED") indicates the AI has
@@ -38,6 +50,13 @@
 // # File Organization
 //
 //   - loop.go: Command definition and flag handling
-//   - run.go: Main loop script generation logic
+//   - proc_unix.go, proc_other.go: Stopping the tool's processes
+//   - run.go: Main loop script generation and run command logic
+//   - runner.go: In-process loop runner and iteration logs
 //   - script.go: Shell script templates for each tool
+//   - stop.go: Progress tracking and phase convergence checks
+//   - summary.go: Run summary file
+//   - tool.go: Tool commands and validation for the runner
+//   - types.go: Runner settings and results
+//   - usage.go: Token and cost usage from tool output and transcripts
 package loop
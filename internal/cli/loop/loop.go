@@ -7,6 +7,8 @@
 package loop
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 )
 
@@ -24,6 +26,9 @@ import (
 //     (default "SYSTEM_CONVERGED")
 //   - --output, -o: Output script filename (default "loop.sh")
 //
+// Subcommands:
+//   - run: Run the loop in-process instead of generating a script
+//
 // Returns:
 //   - *cobra.Command: Configured loop command with flags registered
 func Cmd() *cobra.Command {
@@ -49,7 +54,9 @@ Examples:
   ctx loop --tool aider              # Generate for Aider
   ctx loop --prompt TASKS.md         # Use custom prompt file
   ctx loop --max-iterations 10       # Limit to 10 iterations
-  ctx loop -o my-loop.sh             # Output to custom file`,
+  ctx loop -o my-loop.sh             # Output to custom file
+
+Use 'ctx loop run' to run the loop directly, without a script.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runLoop(
 				cmd, promptFile, tool, maxIterations, completionMsg, outputFile,
@@ -78,5 +85,132 @@ Examples:
 		"loop.sh", "Output script filename",
 	)
 
+	cmd.AddCommand(runCmd())
+
+	return cmd
+}
+
+// runCmd returns the "ctx loop run" subcommand.
+//
+// Flags:
+//   - --prompt, -p: Prompt file to use (default "PROMPT.md")
+//   - --tool, -t: AI tool - claude, aider, or generic (default "claude")
+//   - --command: Shell command to run instead of the tool's default
+//   - --max-iterations, -n: Maximum iterations, 0 for unlimited
+//   - --completion, -c: Completion signal to detect
+//   - --timeout: Wall-clock limit for the whole run
+//   - --iteration-timeout: Wall-clock limit per iteration
+//   - --delay: Pause between iterations
+//   - --no-updates: Do not apply <context-update> tags from the output
+//
+// Returns:
+//   - *cobra.Command: Configured run subcommand
+func runCmd() *cobra.Command {
+	var (
+		cfg       runConfig
+		noUpdates bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run a Ralph loop in-process",
+		Long: `Run a Ralph loop directly instead of generating a script.
+
+Each iteration runs the AI tool with the prompt file, streams its output
+to the terminal, and captures it to .context/loops/<run-id>/. The loop
+stops when the output contains the completion signal, after
+--max-iterations, when --timeout expires, or on Ctrl-C, which stops the
+running tool. It can also stop:
+
+  --until-phase N        when every task of phase N in TASKS.md is done
+  --no-changes N         after N iterations in a row that leave the git
+                         working tree unchanged (commits count as changes)
+  --no-task-changes N    after N iterations in a row that leave TASKS.md
+                         unchanged
+  --repeated-failures N  after N iterations in a row that fail with the
+                         same output
+  --max-tokens N         when the tokens used reach N
+  --max-cost N           when the cost reaches N US dollars
+
+Tokens and cost are read from the tool output (Claude Code JSON output,
+aider usage reports); for claude, tokens are also read from its session
+transcripts. The stop reason is written to summary.json in the run
+directory.
+
+<context-update> tags in the output are applied after each iteration,
+as 'ctx watch' does. A tool that exits with an error does not stop the
+loop.
+
+By default, claude runs as 'claude --print <prompt>' and aider as
+'aider --message-file <prompt>'. Use --command to run any shell command
+instead; it receives the prompt on stdin.
+
+Examples:
+  ctx loop run
+  ctx loop run --max-iterations 10 --timeout 2h
+  ctx loop run --until-phase "Phase 1" --no-changes 3 --max-tokens 2000000
+  ctx loop run --tool generic --command "my-agent --stdin"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg.applyUpdates = !noUpdates
+			return runLoopRun(cmd, cfg)
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&cfg.promptFile, "prompt", "p", "PROMPT.md", "Prompt file to use",
+	)
+	cmd.Flags().StringVarP(
+		&cfg.tool, "tool", "t", "claude", "AI tool: claude, aider, or generic",
+	)
+	cmd.Flags().StringVar(
+		&cfg.command, "command", "",
+		"Shell command to run each iteration (prompt on stdin)",
+	)
+	cmd.Flags().IntVarP(
+		&cfg.maxIterations, "max-iterations", "n",
+		0, "Maximum iterations (0 = unlimited)",
+	)
+	cmd.Flags().StringVarP(
+		&cfg.completionMsg, "completion", "c",
+		"SYSTEM_CONVERGED", "Completion signal to detect",
+	)
+	cmd.Flags().DurationVar(
+		&cfg.timeout, "timeout", 0, "Time limit for the whole run (0 = none)",
+	)
+	cmd.Flags().DurationVar(
+		&cfg.iterationTimeout, "iteration-timeout", 0,
+		"Time limit per iteration (0 = none)",
+	)
+	cmd.Flags().DurationVar(
+		&cfg.delay, "delay", time.Second, "Pause between iterations",
+	)
+	cmd.Flags().BoolVar(
+		&noUpdates, "no-updates", false,
+		"Do not apply <context-update> tags from the output",
+	)
+	cmd.Flags().IntVar(
+		&cfg.noChanges, "no-changes", 0,
+		"Stop after N iterations without git working tree changes (0 = never)",
+	)
+	cmd.Flags().IntVar(
+		&cfg.noTaskChanges, "no-task-changes", 0,
+		"Stop after N iterations without TASKS.md changes (0 = never)",
+	)
+	cmd.Flags().IntVar(
+		&cfg.repeatedFailures, "repeated-failures", 0,
+		"Stop after N iterations failing with the same output (0 = never)",
+	)
+	cmd.Flags().IntVar(
+		&cfg.maxTokens, "max-tokens", 0, "Token budget for the run (0 = none)",
+	)
+	cmd.Flags().Float64Var(
+		&cfg.maxCost, "max-cost", 0, "Cost budget in USD for the run (0 = none)",
+	)
+	cmd.Flags().StringVar(
+		&cfg.untilPhase, "until-phase", "",
+		"Stop when every task in this TASKS.md phase is done",
+	)
+
 	return cmd
 }
@@ -7,8 +7,20 @@
 package loop
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/cli/initialize"
+	"github.com/ActiveMemory/ctx/internal/cli/watch"
 )
 
 // TestLoopCommand tests the loop command.
@@ -42,3 +54,428 @@ func TestLoopCommand(t *testing.T) {
 		t.Error("loop.sh was not created")
 	}
 }
+
+// stubTool is a shell command standing in for an AI tool. It counts its
+// runs in a file, echoes the prompt from stdin, emits a context update,
+// and prints the completion signal on its third run.
+const stubTool = `n=$(cat count 2>/dev/null || echo 0); n=$((n+1)); echo $n > count
+echo "run $n: $(cat)"
+echo '<context-update type="task">Stub task '$n'</context-update>'
+[ $n -ge 3 ] && echo DONE
+exit 1`
+
+// newTestRunner changes to a new project directory and returns a runner
+// for a stub command that records the context updates it would apply.
+// Runs are limited to 10 iterations unless the config sets a limit.
+func newTestRunner(
+	t *testing.T, cfg runConfig, out io.Writer,
+) (*runner, *[]watch.ContextUpdate) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("PROMPT.md", []byte("Do the work"), 0644); err != nil {
+		t.Fatalf("failed to write prompt: %v", err)
+	}
+	dir, err := filepath.Abs(filepath.Join(".context", "loops", "test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create run directory: %v", err)
+	}
+	cfg.promptFile = "PROMPT.md"
+	cfg.tool = "generic"
+	if cfg.maxIterations == 0 {
+		cfg.maxIterations = 10
+	}
+
+	var applied []watch.ContextUpdate
+	r := &runner{
+		cfg: cfg,
+		out: out,
+		dir: dir,
+		apply: func(u watch.ContextUpdate) error {
+			applied = append(applied, u)
+			return nil
+		},
+	}
+	return r, &applied
+}
+
+// TestRunnerStopConditions tests the stop conditions of the loop runner.
+func TestRunnerStopConditions(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        runConfig
+		setup      func(t *testing.T)
+		wantReason string
+		wantIters  int
+	}{
+		{
+			name: "completion signal",
+			cfg: runConfig{
+				command: stubTool, completionMsg: "DONE", applyUpdates: true,
+			},
+			wantReason: stopCompleted,
+			wantIters:  3,
+		},
+		{
+			name: "max iterations",
+			cfg: runConfig{
+				command: stubTool, completionMsg: "DONE", maxIterations: 2,
+			},
+			wantReason: stopMaxIterations,
+			wantIters:  2,
+		},
+		{
+			name: "run timeout",
+			cfg: runConfig{
+				command: "sleep 5", completionMsg: "DONE",
+				timeout: 200 * time.Millisecond,
+			},
+			wantReason: stopTimeout,
+			wantIters:  1,
+		},
+		{
+			name: "iteration timeout does not stop the run",
+			cfg: runConfig{
+				command: "sleep 5", completionMsg: "DONE", maxIterations: 2,
+				iterationTimeout: 100 * time.Millisecond,
+			},
+			wantReason: stopMaxIterations,
+			wantIters:  2,
+		},
+		{
+			name: "token budget",
+			cfg: runConfig{
+				command:   `echo "Tokens: 1.5k sent, 500 received. Cost: $0.01 message, $0.01 session."`,
+				maxTokens: 5000,
+			},
+			wantReason: stopTokenBudget,
+			wantIters:  3,
+		},
+		{
+			name: "cost budget",
+			cfg: runConfig{
+				command: `echo '{"type":"result","total_cost_usd":0.25,` +
+					`"usage":{"input_tokens":10,"output_tokens":20}}'`,
+				maxCost: 1,
+			},
+			wantReason: stopCostBudget,
+			wantIters:  4,
+		},
+		{
+			name: "repeated failure",
+			cfg: runConfig{
+				command: "echo error; exit 1", repeatedFailures: 2,
+			},
+			wantReason: stopRepeatedFailure,
+			wantIters:  2,
+		},
+		{
+			name: "changing failures are not repeated",
+			cfg: runConfig{
+				command: "date +%N; exit 1", repeatedFailures: 2,
+				maxIterations: 3,
+			},
+			wantReason: stopMaxIterations,
+			wantIters:  3,
+		},
+		{
+			name: "no task changes",
+			cfg: runConfig{
+				command: "echo working", noTaskChanges: 2,
+			},
+			setup:      writeTasks("- [ ] Task\n"),
+			wantReason: stopNoTaskChanges,
+			wantIters:  2,
+		},
+		{
+			name: "changing working tree",
+			cfg: runConfig{
+				command: "date +%N > progress", noChanges: 2,
+				maxIterations: 3,
+			},
+			setup:      initGit,
+			wantReason: stopMaxIterations,
+			wantIters:  3,
+		},
+		{
+			name: "no git changes",
+			cfg: runConfig{
+				command: "echo working", noChanges: 2,
+			},
+			setup:      initGit,
+			wantReason: stopNoChanges,
+			wantIters:  2,
+		},
+		{
+			name: "phase complete",
+			cfg: runConfig{
+				command:    `sed -i.bak 's/\[ \] One/[x] One/' .context/TASKS.md`,
+				untilPhase: "Phase 1: Setup",
+			},
+			setup: writeTasks("## Phase 1: Setup\n- [ ] One\n- [x] Two\n" +
+				"## Phase 2: Build\n- [ ] Three\n"),
+			wantReason: stopPhaseComplete,
+			wantIters:  1,
+		},
+		{
+			name: "phase already complete",
+			cfg: runConfig{
+				command: "echo working", untilPhase: "Phase 2: Build",
+			},
+			setup:      writeTasks("## Phase 1\n- [ ] One\n## Phase 2: Build\n- [-] Two\n"),
+			wantReason: stopPhaseComplete,
+			wantIters:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			r, applied := newTestRunner(t, tt.cfg, &out)
+			if tt.setup != nil {
+				tt.setup(t)
+			}
+
+			result := &runResult{dir: r.dir}
+			start := time.Now()
+			if err := r.run(context.Background(), result); err != nil {
+				t.Fatalf("run failed: %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > 4*time.Second {
+				t.Errorf("run took %s; the tool was not stopped", elapsed)
+			}
+
+			if result.reason != tt.wantReason {
+				t.Errorf("reason = %q, want %q", result.reason, tt.wantReason)
+			}
+			if result.iterations != tt.wantIters {
+				t.Errorf("iterations = %d, want %d", result.iterations, tt.wantIters)
+			}
+
+			if len(result.history) != tt.wantIters {
+				t.Errorf("history has %d iterations, want %d",
+					len(result.history), tt.wantIters)
+			}
+			for n := 1; n <= tt.wantIters; n++ {
+				name := filepath.Join(r.dir, fmt.Sprintf("iteration-%03d.log", n))
+				if _, err := os.Stat(name); err != nil {
+					t.Errorf("missing iteration log: %v", err)
+				}
+			}
+
+			if tt.cfg.applyUpdates {
+				if len(*applied) != 3 || (*applied)[2].Content != "Stub task 3" {
+					t.Errorf("applied updates = %+v", *applied)
+				}
+				if !strings.Contains(out.String(), "run 1: Do the work") {
+					t.Errorf("tool output not streamed:\n%s", out.String())
+				}
+			} else if len(*applied) != 0 {
+				t.Errorf("updates applied without applyUpdates: %+v", *applied)
+			}
+		})
+	}
+}
+
+// writeTasks returns a setup function writing .context/TASKS.md in the
+// current directory.
+func writeTasks(content string) func(t *testing.T) {
+	return func(t *testing.T) {
+		if err := os.MkdirAll(".context", 0755); err != nil {
+			t.Fatalf("failed to create .context: %v", err)
+		}
+		err := os.WriteFile(".context/TASKS.md", []byte("# Tasks\n\n"+content), 0644)
+		if err != nil {
+			t.Fatalf("failed to write TASKS.md: %v", err)
+		}
+	}
+}
+
+// initGit makes the current directory a git repository, skipping the
+// test if git is not installed.
+func initGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	if out, err := exec.Command("git", "init", "-q", ".").CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+}
+
+// TestParseUsage tests reading token and cost reports from tool output.
+func TestParseUsage(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   usage
+	}{
+		{
+			name:   "no report",
+			output: "Working on it\nDone\n",
+			want:   usage{},
+		},
+		{
+			name: "claude json result",
+			output: `{"type":"assistant","message":{"usage":{"input_tokens":5,"output_tokens":5}}}
+{"type":"result","total_cost_usd":0.125,"usage":{"input_tokens":100,"output_tokens":20,"cache_read_input_tokens":900}}
+`,
+			want: usage{tokens: 120, cost: 0.125, hasTokens: true, hasCost: true},
+		},
+		{
+			name: "aider reports",
+			output: "Tokens: 2.4k sent, 1.1k cache write, 135 received. Cost: $0.02 message, $0.02 session.\n" +
+				"Tokens: 1M sent, 1k received.\n",
+			want: usage{tokens: 1003535, cost: 0.02, hasTokens: true, hasCost: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseUsage(tt.output); got != tt.want {
+				t.Errorf("parseUsage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSessionTokens tests reading token usage from session transcripts.
+func TestSessionTokens(t *testing.T) {
+	dir := t.TempDir()
+	cwd, _ := os.Getwd()
+	since := time.Now().Add(-time.Minute)
+
+	line := func(uuid string, ts time.Time, in, out int) string {
+		return fmt.Sprintf(`{"uuid":%q,"sessionId":"s1","type":"assistant",`+
+			`"slug":"test","cwd":%q,"timestamp":%q,"message":{"role":"assistant",`+
+			`"content":"ok","usage":{"input_tokens":%d,"output_tokens":%d}}}`,
+			uuid, cwd, ts.Format(time.RFC3339Nano), in, out) + "\n"
+	}
+	transcript := line("a", since.Add(-time.Hour), 1000, 1000) +
+		line("b", since.Add(time.Second), 100, 20) +
+		line("c", since.Add(2*time.Second), 30, 7)
+	path := filepath.Join(dir, "project", "s1.jsonl")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(transcript), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tokens, ok := sessionTokens(dir, since)
+	if !ok || tokens != 157 {
+		t.Errorf("sessionTokens() = %d, %v; want 157, true", tokens, ok)
+	}
+
+	if _, ok := sessionTokens(dir, time.Now().Add(time.Hour)); ok {
+		t.Error("transcripts older than the iteration should be ignored")
+	}
+}
+
+// TestRunnerInterrupt tests that cancelling a run stops the running tool.
+func TestRunnerInterrupt(t *testing.T) {
+	r, _ := newTestRunner(t, runConfig{
+		command: "echo started; sleep 5", completionMsg: "DONE",
+	}, io.Discard)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	result := &runResult{dir: r.dir}
+	start := time.Now()
+	if err := r.run(ctx, result); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 4*time.Second {
+		t.Errorf("run took %s; the tool was not stopped", elapsed)
+	}
+	if result.reason != stopInterrupted {
+		t.Errorf("reason = %q, want %q", result.reason, stopInterrupted)
+	}
+
+	log, err := os.ReadFile(filepath.Join(r.dir, "iteration-001.log"))
+	if err != nil {
+		t.Fatalf("failed to read log: %v", err)
+	}
+	if !strings.Contains(string(log), "started") ||
+		!strings.Contains(string(log), "stopped (interrupted)") {
+		t.Errorf("unexpected log:\n%s", log)
+	}
+}
+
+// TestLoopRunCommand tests "ctx loop run" end to end with a stub tool.
+func TestLoopRunCommand(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cli-loop-run-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+	if err := os.WriteFile("PROMPT.md", []byte("Work"), 0644); err != nil {
+		t.Fatalf("failed to create PROMPT.md: %v", err)
+	}
+
+	loopCmd := Cmd()
+	loopCmd.SetOut(io.Discard)
+	loopCmd.SetArgs([]string{
+		"run", "--tool", "generic", "--command", stubTool,
+		"--completion", "DONE", "--delay", "0",
+	})
+	if err := loopCmd.Execute(); err != nil {
+		t.Fatalf("loop run failed: %v", err)
+	}
+
+	tasks, err := os.ReadFile(".context/TASKS.md")
+	if err != nil {
+		t.Fatalf("failed to read TASKS.md: %v", err)
+	}
+	for _, want := range []string{"Stub task 1", "Stub task 3"} {
+		if !strings.Contains(string(tasks), want) {
+			t.Errorf("TASKS.md missing %q from context updates", want)
+		}
+	}
+
+	runs, err := os.ReadDir(".context/loops")
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("expected one run directory, got %v (%v)", runs, err)
+	}
+	runDir := filepath.Join(".context/loops", runs[0].Name())
+	logs, _ := os.ReadDir(runDir)
+	if len(logs) != 4 {
+		t.Errorf("got %d files in the run directory, want 3 logs and a summary",
+			len(logs))
+	}
+
+	data, err := os.ReadFile(filepath.Join(runDir, summaryFile))
+	if err != nil {
+		t.Fatalf("failed to read run summary: %v", err)
+	}
+	var summary runSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("invalid run summary: %v", err)
+	}
+	if summary.Reason != stopCompleted || summary.Iterations != 3 ||
+		len(summary.Log) != 3 || summary.Log[2].Updates != 1 {
+		t.Errorf("unexpected run summary: %+v", summary)
+	}
+
+	loopCmd = Cmd()
+	loopCmd.SetOut(io.Discard)
+	loopCmd.SetErr(io.Discard)
+	loopCmd.SetArgs([]string{"run", "--tool", "generic"})
+	if err := loopCmd.Execute(); err == nil {
+		t.Error("generic tool without --command should fail")
+	}
+}
@@ -0,0 +1,26 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+//go:build !unix
+
+package loop
+
+import (
+	"os"
+	"os/exec"
+	"time"
+)
+
+// setInterrupt makes cancelling c interrupt the tool process, which is
+// killed if it is still running after the grace period.
+//
+// Parameters:
+//   - c: Command to configure before it is started
+//   - grace: Time the tool gets to exit after the interrupt
+func setInterrupt(c *exec.Cmd, grace time.Duration) {
+	c.Cancel = func() error { return c.Process.Signal(os.Interrupt) }
+	c.WaitDelay = grace
+}
@@ -0,0 +1,35 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+//go:build unix
+
+package loop
+
+import (
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// setInterrupt makes cancelling c interrupt the tool's whole process
+// group, so that commands run through "sh -c" stop along with the shell.
+// Processes of the group still running after the grace period are
+// killed; os/exec itself only kills the group leader.
+//
+// Parameters:
+//   - c: Command to configure before it is started
+//   - grace: Time the group gets to exit after the interrupt
+func setInterrupt(c *exec.Cmd, grace time.Duration) {
+	c.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	c.Cancel = func() error {
+		pgid := c.Process.Pid
+		time.AfterFunc(grace, func() {
+			_ = syscall.Kill(-pgid, syscall.SIGKILL)
+		})
+		return syscall.Kill(-pgid, syscall.SIGINT)
+	}
+	c.WaitDelay = grace
+}
@@ -0,0 +1,65 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+//go:build unix
+
+package loop
+
+import (
+	"bufio"
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestSetInterruptKillsGroup tests that processes ignoring the interrupt
+// are killed after the grace period, grandchildren included.
+func TestSetInterruptKillsGroup(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := exec.CommandContext(ctx, "sh", "-c", `trap "" INT; sleep 30 & echo $!; wait`)
+	stdout, err := c.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	setInterrupt(c, 100*time.Millisecond)
+	if err := c.Start(); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+
+	// The shell prints the PID of its background sleep
+	line, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Fatalf("failed to read the child PID: %v", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil {
+		t.Fatalf("unexpected output %q", line)
+	}
+
+	cancel()
+	_ = c.Wait()
+
+	for deadline := time.Now().Add(2 * time.Second); running(pid); {
+		if time.Now().After(deadline) {
+			_ = syscall.Kill(pid, syscall.SIGKILL)
+			t.Fatalf("grandchild %d survived the grace period", pid)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// running reports whether a process exists and is not a zombie.
+func running(pid int) bool {
+	if syscall.Kill(pid, 0) != nil {
+		return false
+	}
+	stat, err := exec.Command("ps", "-o", "stat=", "-p", strconv.Itoa(pid)).Output()
+	return err == nil && !strings.HasPrefix(strings.TrimSpace(string(stat)), "Z")
+}
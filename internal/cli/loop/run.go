@@ -9,9 +9,14 @@ package loop
 import (
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/cli/watch"
+	"github.com/ActiveMemory/ctx/internal/context"
 )
 
 // runLoop executes the loop command logic.
@@ -69,3 +74,119 @@ func runLoop(
 
 	return nil
 }
+
+// runLoopRun executes the run subcommand logic.
+//
+// Creates a log directory for the run under .context/loops/, runs the
+// tool until a stop condition is met, and prints a summary. Ctrl-C (or
+// SIGTERM) interrupts the running tool and ends the run.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - cfg: Run settings
+//
+// Returns:
+//   - error: Non-nil if the settings are invalid, the context or prompt
+//     file is missing, the tool cannot be run, or the run was interrupted
+func runLoopRun(cmd *cobra.Command, cfg runConfig) error {
+	if err := validateRunConfig(cfg); err != nil {
+		return err
+	}
+	if !context.Exists("") {
+		return fmt.Errorf("no .context/ directory found. Run 'ctx init' first")
+	}
+	if _, err := os.Stat(cfg.promptFile); err != nil {
+		return fmt.Errorf("prompt file not found: %s", cfg.promptFile)
+	}
+	if cfg.untilPhase != "" {
+		phase, err := resolvePhase(cfg.untilPhase)
+		if err != nil {
+			return err
+		}
+		cfg.untilPhase = phase
+	}
+
+	id, dir, err := createRunDir(newRunID())
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(
+		cmd.Context(), os.Interrupt, syscall.SIGTERM,
+	)
+	defer stop()
+
+	cmd.Printf("Starting loop run %s\n", id)
+	cmd.Printf("Prompt: %s\n", cfg.promptFile)
+	cmd.Printf("Logs: %s\n", dir)
+	if cfg.untilPhase != "" {
+		cmd.Printf("Until complete: %s\n", cfg.untilPhase)
+	}
+
+	r := &runner{
+		cfg:        cfg,
+		out:        cmd.OutOrStdout(),
+		dir:        dir,
+		apply:      watch.ApplyUpdate,
+		sessionDir: sessionDir(cfg.tool),
+	}
+	result := &runResult{id: id, dir: dir}
+	runErr := r.run(ctx, result)
+	if runErr != nil {
+		result.reason = stopError
+		result.detail = runErr.Error()
+	}
+
+	printRunResult(cmd, result)
+	if err := writeSummary(result); err != nil {
+		cmd.Printf("%s Failed to write run summary: %v\n",
+			color.YellowString("○"), err)
+	}
+	if runErr != nil {
+		return runErr
+	}
+	if result.reason == stopInterrupted {
+		return fmt.Errorf("loop run %s interrupted", id)
+	}
+	return nil
+}
+
+// printRunResult prints why a loop run stopped.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - result: Finished run
+func printRunResult(cmd *cobra.Command, result *runResult) {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	var msg string
+	switch result.reason {
+	case stopCompleted:
+		msg = green("✓") + " Completion signal detected"
+	case stopPhaseComplete:
+		msg = green("✓") + " Phase complete"
+	case stopMaxIterations:
+		msg = yellow("○") + " Reached maximum iterations"
+	case stopTimeout:
+		msg = yellow("○") + " Run timed out"
+	case stopInterrupted:
+		msg = yellow("○") + " Interrupted"
+	case stopTokenBudget, stopCostBudget:
+		msg = yellow("○") + " Budget spent"
+	case stopNoChanges, stopNoTaskChanges, stopRepeatedFailure:
+		msg = yellow("○") + " No progress"
+	default:
+		msg = yellow("○") + " Stopped"
+	}
+
+	cmd.Println()
+	cmd.Printf("%s after %d iterations\n", msg, result.iterations)
+	if result.detail != "" {
+		cmd.Printf("Reason: %s\n", result.detail)
+	}
+	if result.tokens > 0 || result.cost > 0 {
+		cmd.Printf("Usage: %s\n", formatUsage(result.tokens, result.cost))
+	}
+	cmd.Printf("Logs: %s\n", result.dir)
+}
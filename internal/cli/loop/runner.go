@@ -0,0 +1,379 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package loop
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/ActiveMemory/ctx/internal/cli/watch"
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// killGrace is how long a cancelled tool gets to exit after an interrupt
+// before it is killed.
+const killGrace = 5 * time.Second
+
+// newRunID returns an ID for a loop run based on the current time.
+//
+// Returns:
+//   - string: Run ID, e.g. "2026-01-28-143000"
+func newRunID() string {
+	return time.Now().Format("2006-01-02-150405")
+}
+
+// createRunDir creates the log directory of a loop run under
+// .context/loops/, adding a numeric suffix if the ID is taken.
+//
+// Parameters:
+//   - id: Preferred run ID
+//
+// Returns:
+//   - string: Final run ID
+//   - string: Path of the created directory
+//   - error: Non-nil if the directory cannot be created
+func createRunDir(id string) (string, string, error) {
+	base := config.ContextPath(config.DirLoops)
+	if err := os.MkdirAll(base, 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create loops directory: %w", err)
+	}
+
+	runID := id
+	for n := 2; ; n++ {
+		dir := filepath.Join(base, runID)
+		err := os.Mkdir(dir, 0755)
+		if err == nil {
+			return runID, dir, nil
+		}
+		if !os.IsExist(err) {
+			return "", "", fmt.Errorf("failed to create run directory: %w", err)
+		}
+		runID = fmt.Sprintf("%s-%d", id, n)
+	}
+}
+
+// run executes iterations until a stop condition is met.
+//
+// The run stops when the output contains the completion signal, when the
+// maximum number of iterations is reached, when the run times out, or
+// when ctx is cancelled (e.g., by Ctrl-C). With the corresponding
+// settings, it also stops when the --until-phase phase is complete, when
+// the token or cost budget is spent, or when iterations stop making
+// progress. A tool exiting with an error does not stop the run by itself.
+//
+// Parameters:
+//   - ctx: Context whose cancellation interrupts the run
+//   - result: Result to fill in; id and dir must be set
+//
+// Returns:
+//   - error: Non-nil if the prompt or TASKS.md cannot be read, the tool
+//     cannot be started, or a log cannot be written
+func (r *runner) run(ctx context.Context, result *runResult) error {
+	result.started = time.Now()
+	if r.cfg.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.cfg.timeout)
+		defer cancel()
+	}
+
+	if done, err := r.phaseDone(result); err != nil || done {
+		return err
+	}
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	prog := newProgress(r.cfg)
+
+	for n := 1; ; n++ {
+		if r.cfg.maxIterations > 0 && n > r.cfg.maxIterations {
+			result.reason = stopMaxIterations
+			result.detail = fmt.Sprintf(
+				"reached the limit of %d iterations", r.cfg.maxIterations,
+			)
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			r.stopOnContext(result, err)
+			return nil
+		}
+
+		_, _ = fmt.Fprintf(r.out, "\n%s\n\n", cyan(fmt.Sprintf("=== Iteration %d ===", n)))
+		result.iterations = n
+
+		it, err := r.iterate(ctx, n)
+		if err != nil {
+			return err
+		}
+		r.record(result, it)
+
+		if err := ctx.Err(); err != nil {
+			r.stopOnContext(result, err)
+			return nil
+		}
+		if it.completed {
+			result.reason = stopCompleted
+			result.detail = fmt.Sprintf(
+				"the output contained %q", r.cfg.completionMsg,
+			)
+			return nil
+		}
+		if done, err := r.phaseDone(result); err != nil || done {
+			return err
+		}
+		if r.overBudget(result) {
+			return nil
+		}
+		if reason, detail := prog.update(it); reason != "" {
+			result.reason, result.detail = reason, detail
+			return nil
+		}
+
+		if r.cfg.delay > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(r.cfg.delay):
+			}
+		}
+	}
+}
+
+// record adds a finished iteration to the run result and reports its
+// token and cost usage.
+//
+// Parameters:
+//   - result: Result to update
+//   - it: Finished iteration
+func (r *runner) record(result *runResult, it iteration) {
+	result.tokens += it.usage.tokens
+	result.cost += it.usage.cost
+	it.output = ""
+	result.history = append(result.history, it)
+
+	if it.usage.hasTokens || it.usage.hasCost {
+		_, _ = fmt.Fprintf(r.out, "Usage: %s (run total: %s)\n",
+			formatUsage(it.usage.tokens, it.usage.cost),
+			formatUsage(result.tokens, result.cost))
+	}
+	if r.cfg.maxCost > 0 && !it.usage.hasCost && it.number == 1 {
+		_, _ = fmt.Fprintf(r.out,
+			"%s The tool did not report a cost; --max-cost applies only "+
+				"to reported costs\n", color.YellowString("○"))
+	}
+}
+
+// phaseDone checks whether the --until-phase phase is complete and, if
+// so, records it as the stop reason.
+//
+// Parameters:
+//   - result: Result to update
+//
+// Returns:
+//   - bool: True if the run should stop
+//   - error: Non-nil if TASKS.md cannot be read
+func (r *runner) phaseDone(result *runResult) (bool, error) {
+	if r.cfg.untilPhase == "" {
+		return false, nil
+	}
+	done, err := phaseComplete(r.cfg.untilPhase)
+	if err != nil || !done {
+		return false, err
+	}
+	result.reason = stopPhaseComplete
+	result.detail = fmt.Sprintf("all tasks in %q are done", r.cfg.untilPhase)
+	return true, nil
+}
+
+// overBudget checks the token and cost budgets and, if one is spent,
+// records it as the stop reason.
+//
+// Parameters:
+//   - result: Result holding the consumption so far
+//
+// Returns:
+//   - bool: True if the run should stop
+func (r *runner) overBudget(result *runResult) bool {
+	switch {
+	case r.cfg.maxTokens > 0 && result.tokens >= r.cfg.maxTokens:
+		result.reason = stopTokenBudget
+		result.detail = fmt.Sprintf(
+			"used %d tokens of a %d token budget", result.tokens, r.cfg.maxTokens,
+		)
+	case r.cfg.maxCost > 0 && result.cost >= r.cfg.maxCost:
+		result.reason = stopCostBudget
+		result.detail = fmt.Sprintf(
+			"spent $%.2f of a $%.2f budget", result.cost, r.cfg.maxCost,
+		)
+	default:
+		return false
+	}
+	return true
+}
+
+// stopOnContext records why a cancelled run stopped.
+//
+// Parameters:
+//   - result: Result to update
+//   - err: Error returned by ctx.Err()
+func (r *runner) stopOnContext(result *runResult, err error) {
+	result.reason = stopReason(err)
+	if result.reason == stopTimeout {
+		result.detail = fmt.Sprintf("the run exceeded %s", r.cfg.timeout)
+	} else {
+		result.detail = "the run was interrupted"
+	}
+}
+
+// iterate runs the tool once, streaming its output to the terminal and
+// the iteration log, then applies the context updates it printed.
+//
+// Parameters:
+//   - ctx: Context whose cancellation stops the tool
+//   - n: Iteration number
+//
+// Returns:
+//   - iteration: Outcome of the iteration
+//   - error: Non-nil if the prompt, the tool, or the log fails
+func (r *runner) iterate(ctx context.Context, n int) (iteration, error) {
+	it := iteration{number: n}
+
+	prompt, err := os.ReadFile(r.cfg.promptFile)
+	if err != nil {
+		return it, fmt.Errorf("failed to read prompt file: %w", err)
+	}
+
+	logPath := filepath.Join(r.dir, fmt.Sprintf("iteration-%03d.log", n))
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return it, fmt.Errorf("failed to create iteration log: %w", err)
+	}
+	defer func() { _ = logFile.Close() }()
+
+	if r.cfg.iterationTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.cfg.iterationTimeout)
+		defer cancel()
+	}
+
+	name, args, stdin := toolCommand(r.cfg, string(prompt))
+	c := exec.CommandContext(ctx, name, args...)
+	c.Stdin = strings.NewReader(stdin)
+	setInterrupt(c, killGrace)
+
+	var captured bytes.Buffer
+	w := io.MultiWriter(r.out, logFile, &captured)
+	c.Stdout = w
+	c.Stderr = w
+
+	start := time.Now()
+	runErr := c.Run()
+	it.duration = time.Since(start)
+	it.output = captured.String()
+
+	var exitErr *exec.ExitError
+	switch {
+	case runErr == nil:
+	case errors.As(runErr, &exitErr):
+		it.exitCode = exitErr.ExitCode()
+	case ctx.Err() != nil:
+		it.exitCode = -1
+	default:
+		return it, fmt.Errorf("failed to run %s: %w", name, runErr)
+	}
+
+	footer := fmt.Sprintf(
+		"\n--- iteration %d: exit %d after %s ---\n",
+		n, it.exitCode, it.duration.Round(time.Millisecond),
+	)
+	if ctx.Err() != nil {
+		footer = fmt.Sprintf(
+			"\n--- iteration %d: stopped (%s) after %s ---\n",
+			n, stopReason(ctx.Err()), it.duration.Round(time.Millisecond),
+		)
+	}
+	if _, err := logFile.WriteString(footer); err != nil {
+		return it, fmt.Errorf("failed to write iteration log: %w", err)
+	}
+
+	it.completed = r.cfg.completionMsg != "" &&
+		strings.Contains(it.output, r.cfg.completionMsg)
+	it.usage = parseUsage(it.output)
+	if !it.usage.hasTokens && r.sessionDir != "" {
+		it.usage.tokens, it.usage.hasTokens = sessionTokens(r.sessionDir, start)
+	}
+	if r.cfg.applyUpdates && ctx.Err() == nil {
+		it.updates = r.applyUpdates(it.output)
+	}
+
+	return it, nil
+}
+
+// applyUpdates applies the <context-update> tags found in tool output.
+//
+// Parameters:
+//   - output: Captured output of an iteration
+//
+// Returns:
+//   - int: Number of updates applied successfully
+func (r *runner) applyUpdates(output string) int {
+	green := color.New(color.FgGreen).SprintFunc()
+	applied := 0
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		for _, update := range watch.ParseUpdates(scanner.Text()) {
+			if err := r.apply(update); err != nil {
+				_, _ = fmt.Fprintf(r.out, "%s Failed to apply [%s]: %v\n",
+					color.RedString("✗"), update.Type, err)
+				continue
+			}
+			_, _ = fmt.Fprintf(r.out, "%s Applied: [%s] %s\n",
+				green("✓"), update.Type, update.Content)
+			applied++
+		}
+	}
+	return applied
+}
+
+// formatUsage formats a token count and cost for progress messages.
+//
+// Parameters:
+//   - tokens: Token count
+//   - cost: Cost in USD; omitted if zero
+//
+// Returns:
+//   - string: E.g. "12345 tokens, $0.42"
+func formatUsage(tokens int, cost float64) string {
+	if cost == 0 {
+		return fmt.Sprintf("%d tokens", tokens)
+	}
+	return fmt.Sprintf("%d tokens, $%.2f", tokens, cost)
+}
+
+// stopReason maps a context error to the reason the run stopped.
+//
+// Parameters:
+//   - err: Error returned by ctx.Err()
+//
+// Returns:
+//   - string: stopTimeout for deadlines, stopInterrupted otherwise
+func stopReason(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return stopTimeout
+	}
+	return stopInterrupted
+}
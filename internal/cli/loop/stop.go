@@ -0,0 +1,229 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package loop
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/cli/task"
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// progress tracks consecutive iterations that made no progress.
+//
+// Fields:
+//   - cfg: Run settings holding the limits
+//   - git: Fingerprint of the git working tree; "" if not tracked
+//   - tasks: Fingerprint of TASKS.md
+//   - failure: Fingerprint of the last failing output; "" after a success
+//   - idleGit: Iterations in a row that left the working tree unchanged
+//   - idleTasks: Iterations in a row that left TASKS.md unchanged
+//   - failures: Iterations in a row that failed with the same output
+type progress struct {
+	cfg       runConfig
+	git       string
+	tasks     string
+	failure   string
+	idleGit   int
+	idleTasks int
+	failures  int
+}
+
+// newProgress records the state of the working tree and TASKS.md before
+// the first iteration.
+//
+// Parameters:
+//   - cfg: Run settings; only the limits that are set are tracked
+//
+// Returns:
+//   - *progress: Tracker with no idle iterations
+func newProgress(cfg runConfig) *progress {
+	p := &progress{cfg: cfg}
+	if cfg.noChanges > 0 {
+		p.git = gitFingerprint()
+	}
+	if cfg.noTaskChanges > 0 {
+		p.tasks = tasksFingerprint()
+	}
+	return p
+}
+
+// update records the outcome of an iteration and checks whether the
+// loop has stalled.
+//
+// The git check is skipped outside a git repository.
+//
+// Parameters:
+//   - it: Finished iteration
+//
+// Returns:
+//   - string: Stop reason; "" if the loop should go on
+//   - string: Description of the stall
+func (p *progress) update(it iteration) (string, string) {
+	if it.exitCode != 0 {
+		hash := fingerprint([]byte(strings.TrimSpace(it.output)))
+		if hash == p.failure {
+			p.failures++
+		} else {
+			p.failure, p.failures = hash, 1
+		}
+	} else {
+		p.failure, p.failures = "", 0
+	}
+
+	if p.git != "" {
+		if git := gitFingerprint(); git == p.git {
+			p.idleGit++
+		} else {
+			p.git, p.idleGit = git, 0
+		}
+	}
+
+	if p.cfg.noTaskChanges > 0 {
+		if tasks := tasksFingerprint(); tasks == p.tasks {
+			p.idleTasks++
+		} else {
+			p.tasks, p.idleTasks = tasks, 0
+		}
+	}
+
+	switch {
+	case p.cfg.repeatedFailures > 0 && p.failures >= p.cfg.repeatedFailures:
+		return stopRepeatedFailure, fmt.Sprintf(
+			"the tool failed with the same output %d times in a row", p.failures,
+		)
+	case p.git != "" && p.idleGit >= p.cfg.noChanges:
+		return stopNoChanges, fmt.Sprintf(
+			"no changes to the git working tree in %d iterations", p.idleGit,
+		)
+	case p.cfg.noTaskChanges > 0 && p.idleTasks >= p.cfg.noTaskChanges:
+		return stopNoTaskChanges, fmt.Sprintf(
+			"no changes to %s in %d iterations", config.FilenameTask, p.idleTasks,
+		)
+	}
+	return "", ""
+}
+
+// gitFingerprint hashes the state of the git working tree: the current
+// commit, the status, the staged and unstaged diffs, and the content of
+// untracked files. Loop logs are left out, since the runner writes them
+// every iteration.
+//
+// Returns:
+//   - string: Fingerprint; "" if the current directory is not in a git
+//     repository
+func gitFingerprint() string {
+	top, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return ""
+	}
+
+	pathspec := []string{"--", "."}
+	loops, err := filepath.Abs(config.ContextPath(config.DirLoops))
+	if err == nil {
+		rel, err := filepath.Rel(strings.TrimSpace(string(top)), loops)
+		if err == nil && !strings.HasPrefix(rel, "..") {
+			pathspec = append(pathspec, ":(top,exclude)"+filepath.ToSlash(rel))
+		}
+	}
+
+	var state bytes.Buffer
+	for _, args := range [][]string{
+		{"rev-parse", "HEAD"},
+		{"status", "--porcelain", "-uall"},
+		{"diff"},
+		{"diff", "--cached"},
+	} {
+		// HEAD does not exist before the first commit
+		out, _ := exec.Command("git", append(args, pathspec...)...).Output()
+		state.Write(out)
+	}
+
+	untracked, _ := exec.Command("git", append(
+		[]string{"ls-files", "--others", "--exclude-standard", "-z"}, pathspec...,
+	)...).Output()
+	for _, name := range strings.Split(string(untracked), "\x00") {
+		if content, err := os.ReadFile(name); err == nil {
+			state.Write(content)
+		}
+	}
+
+	return fingerprint(state.Bytes())
+}
+
+// tasksFingerprint hashes the content of TASKS.md.
+//
+// Returns:
+//   - string: Fingerprint; "" if the file cannot be read
+func tasksFingerprint() string {
+	content, err := os.ReadFile(config.ContextPath(config.FilenameTask))
+	if err != nil {
+		return ""
+	}
+	return fingerprint(content)
+}
+
+// fingerprint returns the hex SHA-256 hash of data.
+//
+// Parameters:
+//   - data: Data to hash
+//
+// Returns:
+//   - string: Hex-encoded hash
+func fingerprint(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// resolvePhase resolves the --until-phase name against TASKS.md.
+//
+// Parameters:
+//   - name: Phase heading text or part of it
+//
+// Returns:
+//   - string: Full phase heading text
+//   - error: Non-nil if TASKS.md cannot be read or the phase is not found
+func resolvePhase(name string) (string, error) {
+	doc, err := context.LoadDocument("", config.FilenameTask)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", config.FilenameTask, err)
+	}
+	return task.FindPhase(doc, name)
+}
+
+// phaseComplete reports whether every task of a phase, subtasks
+// included, is done or skipped.
+//
+// Parameters:
+//   - phase: Full phase heading text
+//
+// Returns:
+//   - bool: True if the phase has no open tasks
+//   - error: Non-nil if TASKS.md cannot be read
+func phaseComplete(phase string) (bool, error) {
+	doc, err := context.LoadDocument("", config.FilenameTask)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", config.FilenameTask, err)
+	}
+	for _, t := range doc.Tasks() {
+		if t.Phase != phase {
+			continue
+		}
+		if s := t.State(); s != context.TaskDone && s != context.TaskSkipped {
+			return false, nil
+		}
+	}
+	return true, nil
+}
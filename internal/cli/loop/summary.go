@@ -0,0 +1,58 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package loop
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// summaryFile is the name of the run summary in a run's log directory.
+const summaryFile = "summary.json"
+
+// writeSummary writes the summary of a finished run to summary.json in
+// its log directory.
+//
+// Parameters:
+//   - result: Finished run
+//
+// Returns:
+//   - error: Non-nil if the file cannot be written
+func writeSummary(result *runResult) error {
+	summary := runSummary{
+		ID:         result.id,
+		Started:    result.started,
+		Ended:      time.Now(),
+		Iterations: result.iterations,
+		Reason:     result.reason,
+		Detail:     result.detail,
+		Tokens:     result.tokens,
+		CostUSD:    result.cost,
+		Log:        []iterationSummary{},
+	}
+	for _, it := range result.history {
+		summary.Log = append(summary.Log, iterationSummary{
+			Number:     it.number,
+			ExitCode:   it.exitCode,
+			DurationMS: it.duration.Milliseconds(),
+			Completed:  it.completed,
+			Updates:    it.updates,
+			Tokens:     it.usage.tokens,
+			CostUSD:    it.usage.cost,
+		})
+	}
+
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(
+		filepath.Join(result.dir, summaryFile), append(data, '\n'), 0644,
+	)
+}
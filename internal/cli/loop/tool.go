@@ -0,0 +1,76 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package loop
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// toolCommand returns the command that runs one iteration of the loop.
+//
+// A custom command is run through "sh -c" with the prompt on stdin.
+// Otherwise, claude receives the prompt as an argument to --print and
+// aider reads the prompt file itself, mirroring the generated scripts.
+//
+// Parameters:
+//   - cfg: Run settings; cfg.tool must be valid
+//   - prompt: Current content of the prompt file
+//
+// Returns:
+//   - string: Program to run
+//   - []string: Program arguments
+//   - string: Standard input for the program
+func toolCommand(cfg runConfig, prompt string) (string, []string, string) {
+	if cfg.command != "" {
+		return "sh", []string{"-c", cfg.command}, prompt
+	}
+
+	switch cfg.tool {
+	case "aider":
+		absPrompt, _ := filepath.Abs(cfg.promptFile)
+		return "aider", []string{"--message-file", absPrompt}, ""
+	default:
+		return "claude", []string{"--print", prompt}, ""
+	}
+}
+
+// validateRunConfig checks the settings of a loop run before it starts.
+//
+// Parameters:
+//   - cfg: Run settings to check
+//
+// Returns:
+//   - error: Non-nil if the tool is unknown, the generic tool has no
+//     command, or a limit or budget is negative
+func validateRunConfig(cfg runConfig) error {
+	switch cfg.tool {
+	case "claude", "aider":
+	case "generic":
+		if cfg.command == "" {
+			return fmt.Errorf("the generic tool requires --command")
+		}
+	default:
+		return fmt.Errorf(
+			"invalid tool %q: must be claude, aider, or generic", cfg.tool,
+		)
+	}
+
+	if cfg.maxIterations < 0 {
+		return fmt.Errorf("--max-iterations must not be negative")
+	}
+	if cfg.timeout < 0 || cfg.iterationTimeout < 0 || cfg.delay < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+	if cfg.noChanges < 0 || cfg.noTaskChanges < 0 || cfg.repeatedFailures < 0 {
+		return fmt.Errorf("iteration limits must not be negative")
+	}
+	if cfg.maxTokens < 0 || cfg.maxCost < 0 {
+		return fmt.Errorf("budgets must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package loop
+
+import (
+	"io"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/cli/watch"
+)
+
+// Stop reasons recorded when a loop run ends.
+const (
+	stopCompleted       = "completed"
+	stopMaxIterations   = "max_iterations"
+	stopTimeout         = "timeout"
+	stopInterrupted     = "interrupted"
+	stopNoChanges       = "no_changes"
+	stopNoTaskChanges   = "no_task_changes"
+	stopRepeatedFailure = "repeated_failure"
+	stopTokenBudget     = "token_budget"
+	stopCostBudget      = "cost_budget"
+	stopPhaseComplete   = "phase_complete"
+	stopError           = "error"
+)
+
+// runConfig holds the settings of a "ctx loop run" invocation.
+//
+// Fields:
+//   - promptFile: Prompt file, re-read before every iteration
+//   - tool: AI tool - "claude", "aider", or "generic"
+//   - command: Shell command run for each iteration instead of the tool's
+//     default; receives the prompt on stdin
+//   - maxIterations: Maximum iterations, 0 for unlimited
+//   - completionMsg: String in the output that ends the loop
+//   - timeout: Wall-clock limit for the whole run, 0 for none
+//   - iterationTimeout: Wall-clock limit per iteration, 0 for none
+//   - delay: Pause between iterations
+//   - applyUpdates: If true, apply <context-update> tags from the output
+//   - noChanges: Iterations in a row without changes to the git working
+//     tree that stop the run, 0 for no limit
+//   - noTaskChanges: Iterations in a row without changes to TASKS.md that
+//     stop the run, 0 for no limit
+//   - repeatedFailures: Iterations in a row failing with the same output
+//     that stop the run, 0 for no limit
+//   - maxTokens: Token budget for the whole run, 0 for none
+//   - maxCost: Cost budget in USD for the whole run, 0 for none
+//   - untilPhase: Phase of TASKS.md whose completion ends the run
+type runConfig struct {
+	promptFile       string
+	tool             string
+	command          string
+	maxIterations    int
+	completionMsg    string
+	timeout          time.Duration
+	iterationTimeout time.Duration
+	delay            time.Duration
+	applyUpdates     bool
+	noChanges        int
+	noTaskChanges    int
+	repeatedFailures int
+	maxTokens        int
+	maxCost          float64
+	untilPhase       string
+}
+
+// runner executes the iterations of a loop run.
+//
+// Fields:
+//   - cfg: Run settings
+//   - out: Destination for streamed tool output and progress messages
+//   - dir: Directory receiving the iteration logs
+//   - apply: Applies a context update; watch.ApplyUpdate outside tests
+//   - sessionDir: Directory of session transcripts to read token usage
+//     from when the tool does not report it; "" to not look
+type runner struct {
+	cfg        runConfig
+	out        io.Writer
+	dir        string
+	apply      func(watch.ContextUpdate) error
+	sessionDir string
+}
+
+// iteration is the outcome of one run of the tool.
+//
+// Fields:
+//   - number: 1-based iteration number
+//   - exitCode: Exit code of the tool; -1 if it was killed
+//   - duration: Wall-clock time of the iteration
+//   - completed: True if the output contained the completion signal
+//   - updates: Number of context updates applied
+//   - usage: Tokens and cost consumed, as far as they are known
+//   - output: Captured stdout and stderr
+type iteration struct {
+	number    int
+	exitCode  int
+	duration  time.Duration
+	completed bool
+	updates   int
+	usage     usage
+	output    string
+}
+
+// runResult summarizes a finished loop run.
+//
+// Fields:
+//   - id: Run ID, also the name of the log directory
+//   - dir: Log directory
+//   - iterations: Number of iterations started
+//   - reason: Why the loop stopped; one of the stop* constants
+//   - detail: Human-readable explanation of the stop reason
+//   - started: Start time of the run
+//   - tokens: Tokens consumed by all iterations
+//   - cost: Cost in USD of all iterations
+//   - history: Finished iterations, without their output
+type runResult struct {
+	id         string
+	dir        string
+	iterations int
+	reason     string
+	detail     string
+	started    time.Time
+	tokens     int
+	cost       float64
+	history    []iteration
+}
+
+// runSummary is the summary.json file written to the log directory of a
+// loop run.
+type runSummary struct {
+	ID         string             `json:"id"`
+	Started    time.Time          `json:"started"`
+	Ended      time.Time          `json:"ended"`
+	Iterations int                `json:"iterations"`
+	Reason     string             `json:"reason"`
+	Detail     string             `json:"detail,omitempty"`
+	Tokens     int                `json:"tokens,omitempty"`
+	CostUSD    float64            `json:"cost_usd,omitempty"`
+	Log        []iterationSummary `json:"log"`
+}
+
+// iterationSummary describes one iteration in a run summary.
+type iterationSummary struct {
+	Number     int     `json:"number"`
+	ExitCode   int     `json:"exit_code"`
+	DurationMS int64   `json:"duration_ms"`
+	Completed  bool    `json:"completed,omitempty"`
+	Updates    int     `json:"updates,omitempty"`
+	Tokens     int     `json:"tokens,omitempty"`
+	CostUSD    float64 `json:"cost_usd,omitempty"`
+}
@@ -0,0 +1,194 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package loop
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// aiderTokensPattern matches aider's per-message usage report, e.g.
+// "Tokens: 2.4k sent, 1.1k cache write, 135 received. Cost: $0.02
+// message, $0.05 session."
+var aiderTokensPattern = regexp.MustCompile(
+	`^Tokens: ([\d.]+[kKmM]?) sent,.*?([\d.]+[kKmM]?) received\.`,
+)
+
+// aiderCostPattern matches the per-message cost in aider's usage report.
+var aiderCostPattern = regexp.MustCompile(`Cost: \$([\d.]+) message`)
+
+// usage is the token and cost consumption of one iteration.
+//
+// Fields:
+//   - tokens: Input plus output tokens
+//   - cost: Cost in USD
+//   - hasTokens: True if the tool reported token counts
+//   - hasCost: True if the tool reported a cost
+type usage struct {
+	tokens    int
+	cost      float64
+	hasTokens bool
+	hasCost   bool
+}
+
+// jsonResult holds the usage fields of a JSON result line, as printed by
+// "claude --print --output-format json" (or stream-json).
+type jsonResult struct {
+	TotalCostUSD *float64 `json:"total_cost_usd"`
+	Usage        *struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// parseUsage extracts token and cost reports from tool output.
+//
+// Two formats are recognized: JSON result lines with top-level "usage"
+// and "total_cost_usd" fields (Claude Code's JSON output formats) and
+// aider's "Tokens: ... sent, ... received. Cost: $... message" lines.
+// Reports are summed over the output.
+//
+// Parameters:
+//   - output: Captured output of an iteration
+//
+// Returns:
+//   - usage: Reported consumption; zero if the tool reported nothing
+func parseUsage(output string) usage {
+	var u usage
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+
+		if strings.HasPrefix(line, "{") {
+			var r jsonResult
+			if json.Unmarshal([]byte(line), &r) != nil {
+				continue
+			}
+			if r.Usage != nil {
+				u.tokens += r.Usage.InputTokens + r.Usage.OutputTokens
+				u.hasTokens = true
+			}
+			if r.TotalCostUSD != nil {
+				u.cost += *r.TotalCostUSD
+				u.hasCost = true
+			}
+			continue
+		}
+
+		if m := aiderTokensPattern.FindStringSubmatch(line); m != nil {
+			u.tokens += parseCount(m[1]) + parseCount(m[2])
+			u.hasTokens = true
+			if c := aiderCostPattern.FindStringSubmatch(line); c != nil {
+				cost, _ := strconv.ParseFloat(c[1], 64)
+				u.cost += cost
+				u.hasCost = true
+			}
+		}
+	}
+	return u
+}
+
+// parseCount parses a token count with an optional k or M suffix, e.g.
+// "2.4k".
+//
+// Parameters:
+//   - s: Count as printed by the tool
+//
+// Returns:
+//   - int: Token count; 0 if s is not a number
+func parseCount(s string) int {
+	mult := 1.0
+	switch strings.ToLower(s[len(s)-1:]) {
+	case "k":
+		mult = 1e3
+		s = s[:len(s)-1]
+	case "m":
+		mult = 1e6
+		s = s[:len(s)-1]
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return int(n*mult + 0.5)
+}
+
+// sessionTokens sums the tokens recorded in session transcripts during
+// an iteration, for tools that do not report usage in their output.
+//
+// Transcripts are JSONL files below dir (e.g., ~/.claude/projects)
+// modified since the iteration started. Only sessions run in the
+// current working directory and messages from the iteration count.
+//
+// Parameters:
+//   - dir: Directory holding session transcripts
+//   - since: Start of the iteration
+//
+// Returns:
+//   - int: Input plus output tokens
+//   - bool: True if any message of the iteration was found
+func sessionTokens(dir string, since time.Time) (int, bool) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return 0, false
+	}
+
+	tokens, found := 0, false
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".jsonl" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.ModTime().Before(since) {
+			return nil
+		}
+		sessions, err := parser.ParseFile(path)
+		if err != nil {
+			return nil
+		}
+		for _, s := range sessions {
+			if s.CWD != cwd {
+				continue
+			}
+			for _, msg := range s.Messages {
+				if msg.Timestamp.Before(since) {
+					continue
+				}
+				tokens += msg.TokensIn + msg.TokensOut
+				found = true
+			}
+		}
+		return nil
+	})
+	return tokens, found
+}
+
+// sessionDir returns the directory where a tool keeps its session
+// transcripts, for reading token usage.
+//
+// Parameters:
+//   - tool: AI tool name
+//
+// Returns:
+//   - string: Transcript directory; "" if the tool has none or the home
+//     directory is unknown
+func sessionDir(tool string) string {
+	if tool != "claude" {
+		return ""
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".claude", "projects")
+}
@@ -0,0 +1,285 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+	"github.com/ActiveMemory/ctx/internal/recall/search"
+)
+
+const (
+	// historyRecent is the number of recent sessions in the history.
+	historyRecent = 3
+	// historyRelated is the number of related chunks in the history.
+	historyRelated = 5
+	// maxQueryTasks is the number of active tasks used to find related
+	// history.
+	maxQueryTasks = 10
+	// maxQueryFiles is the number of changed files used to find related
+	// history.
+	maxQueryFiles = 20
+	// recentCommits is the number of commits whose files count as
+	// recently changed.
+	recentCommits = 5
+	// maxRecentLength is the length beyond which the opening question of
+	// a recent session is truncated.
+	maxRecentLength = 100
+)
+
+// runRecallAuto handles "ctx recall --auto".
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - dirs: Additional directories of session files to index
+//   - budget: Token budget for the section
+//
+// Returns:
+//   - error: Non-nil if a directory is invalid or the index cannot be
+//     read, updated or written
+func runRecallAuto(cmd *cobra.Command, dirs []string, budget int) error {
+	if err := checkDirs(dirs); err != nil {
+		return err
+	}
+	if budget <= 0 {
+		return fmt.Errorf("--budget must be positive")
+	}
+
+	res, err := History(dirs)
+	if err != nil {
+		return err
+	}
+	cmd.Print(renderHistory(res, budget))
+	return nil
+}
+
+// History finds the session history relevant to the current project.
+//
+// Sessions are matched by the working directory and ranked higher on the
+// current git branch. Related chunks are found by searching for the
+// active tasks in TASKS.md and the recently changed files. The search
+// index is updated first, as by "ctx recall search".
+//
+// Parameters:
+//   - dirs: Additional directories of session files to index
+//
+// Returns:
+//   - *search.AutoResult: Recent sessions and related chunks
+//   - error: Non-nil if the index cannot be read, updated or written
+func History(dirs []string) (*search.AutoResult, error) {
+	idx, _, err := updateIndex(dirs)
+	if err != nil {
+		return nil, err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	terms := append(activeTasks(), changedFiles()...)
+	res := idx.Auto(search.AutoQuery{
+		CWD:     cwd,
+		Branch:  gitBranch(),
+		Text:    strings.Join(terms, "\n"),
+		Recent:  historyRecent,
+		Related: historyRelated,
+	})
+	return &res, nil
+}
+
+// HistoryItem formats a history result as a one-line item, e.g.
+// "2026-01-20 brave-sailing-mercury (thinking): ...".
+//
+// Parameters:
+//   - r: Recent session or related chunk
+//
+// Returns:
+//   - string: Date, session, chunk type for non-turns, and snippet
+func HistoryItem(r search.Result) string {
+	c := r.Chunk
+	name := c.Slug
+	if name == "" {
+		name = c.SessionID
+	}
+	label := c.Timestamp.Local().Format("2006-01-02") + " " + name
+	if c.Type != search.TypeTurnPair {
+		label += " (" + c.Type + ")"
+	}
+
+	text := r.Snippet
+	if runes := []rune(text); len(runes) > maxRecentLength && c.Type == search.TypeTurnPair {
+		text = string(runes[:maxRecentLength-3]) + "..."
+	}
+	return label + ": " + text
+}
+
+// renderHistory renders history as a "Relevant History" Markdown section
+// that fits a token budget.
+//
+// Related chunks take precedence over recent sessions; items that do not
+// fit are left out and counted.
+//
+// Parameters:
+//   - res: History to render
+//   - budget: Token budget for the section
+//
+// Returns:
+//   - string: Markdown section
+func renderHistory(res *search.AutoResult, budget int) string {
+	recentHeading := "### Recent"
+	if cwd, err := os.Getwd(); err == nil {
+		recentHeading += " (" + filepath.Base(cwd)
+		if branch := gitBranch(); branch != "" {
+			recentHeading += ", " + branch
+		}
+		recentHeading += ")"
+	}
+
+	remaining := budget - context.EstimateTokensString("## Relevant History\n\n")
+	fit := func(results []search.Result, heading string) []string {
+		var items []string
+		for _, r := range results {
+			item := "- " + HistoryItem(r) + "\n"
+			cost := context.EstimateTokensString(item)
+			if len(items) == 0 {
+				cost += context.EstimateTokensString(heading + "\n\n")
+			}
+			if cost > remaining {
+				continue
+			}
+			items = append(items, item)
+			remaining -= cost
+		}
+		return items
+	}
+	related := fit(res.Related, "### Related")
+	recent := fit(res.Recent, recentHeading)
+
+	var sb strings.Builder
+	sb.WriteString("## Relevant History\n\n")
+	if len(recent)+len(related) == 0 {
+		sb.WriteString("No history found for this project.\n")
+	}
+	for _, s := range []struct {
+		heading string
+		items   []string
+	}{
+		{recentHeading, recent},
+		{"### Related", related},
+	} {
+		if len(s.items) == 0 {
+			continue
+		}
+		sb.WriteString(s.heading + "\n")
+		for _, item := range s.items {
+			sb.WriteString(item)
+		}
+		sb.WriteString("\n")
+	}
+
+	if omitted := len(res.Recent) + len(res.Related) - len(recent) - len(related); omitted > 0 {
+		sb.WriteString(fmt.Sprintf("_%s omitted to fit the budget._\n", plural(omitted, "item")))
+	}
+	return sb.String()
+}
+
+// activeTasks returns the titles of the pending top-level tasks in
+// TASKS.md, in-progress tasks first.
+//
+// Returns:
+//   - []string: Up to maxQueryTasks titles; nil without a TASKS.md
+func activeTasks() []string {
+	doc, err := context.LoadDocument("", config.FilenameTask)
+	if err != nil {
+		return nil
+	}
+
+	var started, pending []string
+	for _, e := range doc.Entries() {
+		t, ok := e.(*context.Task)
+		if !ok || t.State() != context.TaskPending {
+			continue
+		}
+		if t.HasLabel(context.LabelInProgress) {
+			started = append(started, t.Title())
+		} else {
+			pending = append(pending, t.Title())
+		}
+	}
+	tasks := append(started, pending...)
+	if len(tasks) > maxQueryTasks {
+		tasks = tasks[:maxQueryTasks]
+	}
+	return tasks
+}
+
+// changedFiles returns search terms for the files changed in the working
+// tree and in the latest commits: each file's name without extension and
+// the name of its directory.
+//
+// Returns:
+//   - []string: Terms for up to maxQueryFiles files; nil outside a git
+//     repository
+func changedFiles() []string {
+	var paths []string
+	if out, err := exec.Command("git", "status", "--porcelain").Output(); err == nil {
+		for _, line := range strings.Split(string(out), "\n") {
+			if len(line) > 3 {
+				// Renames are reported as "old -> new"
+				_, path, _ := strings.Cut(line[3:], " -> ")
+				if path == "" {
+					path = line[3:]
+				}
+				paths = append(paths, path)
+			}
+		}
+	}
+	out, err := exec.Command(
+		"git", "log", "--name-only", "--format=", "-n", fmt.Sprint(recentCommits),
+	).Output()
+	if err == nil {
+		paths = append(paths, strings.Fields(string(out))...)
+	}
+
+	seen := make(map[string]bool)
+	var terms []string
+	for _, p := range paths {
+		p = filepath.ToSlash(strings.Trim(p, `"`))
+		if seen[p] || len(terms) == maxQueryFiles {
+			continue
+		}
+		seen[p] = true
+		name := strings.TrimSuffix(filepath.Base(p), filepath.Ext(p))
+		terms = append(terms, filepath.Base(filepath.Dir(p))+" "+name)
+	}
+	return terms
+}
+
+// gitBranch returns the current git branch, or "" outside a repository
+// or on a detached HEAD.
+//
+// Returns:
+//   - string: Branch name
+func gitBranch() string {
+	out, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	branch := strings.TrimSpace(string(out))
+	if branch == "HEAD" {
+		return ""
+	}
+	return branch
+}
@@ -6,11 +6,25 @@
 
 // Package recall provides CLI commands for browsing and searching AI session history.
 //
-// The recall system parses JSONL session files from various AI coding assistants
-// (currently Claude Code) and provides commands to list, view, and search sessions.
+// The recall system parses session files from various AI coding assistants
+// (Claude Code and Aider) and provides commands to list, view, and search sessions.
 //
 // Commands:
 //   - ctx recall list: List all parsed sessions
 //   - ctx recall show <id>: Show session details
-//   - ctx recall serve: Start web server for browsing (Phase 3)
+//   - ctx recall export <id>: Write sessions as Markdown, HTML or JSON files
+//   - ctx recall search <query>: Search session history
+//   - ctx recall reason <query>: Search past reasoning by category and outcome
+//   - ctx recall serve: Start a local web server for browsing sessions
+//   - ctx recall import: Stage decisions and learnings found in sessions
+//   - ctx recall --auto: Print the history relevant to the current project
+//
+// History and HistoryItem are also used by "ctx agent --with-history".
+//
+// The web server renders pages from templates embedded from templates/,
+// with no external assets, and serves the same data as JSON under /api/.
+//
+// Import stages candidates in .context/import/candidates.md, a checklist
+// read with the TASKS.md parser, and writes accepted ones with the
+// formatting of "ctx add".
 package recall
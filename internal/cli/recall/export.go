@@ -0,0 +1,116 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/recall/export"
+)
+
+// exportFlags holds the flag values of the recall export command.
+//
+// Fields:
+//   - all: Export every session
+//   - latest: Export the most recent session
+//   - format: Export format: md, html or json
+//   - out: Directory to write the exports to; empty for .context/sessions
+//   - noThinking: Leave out reasoning blocks
+//   - noToolResults: Leave out tool results
+//   - noSidechains: Leave out subagent conversations
+type exportFlags struct {
+	all           bool
+	latest        bool
+	format        string
+	out           string
+	noThinking    bool
+	noToolResults bool
+	noSidechains  bool
+}
+
+// runRecallExport handles the recall export command.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - args: Session IDs or slugs
+//   - flags: All flag values from the command
+//
+// Returns:
+//   - error: Non-nil if the format is unknown, no session is selected,
+//     or a session cannot be read or written
+func runRecallExport(cmd *cobra.Command, args []string, flags exportFlags) error {
+	format := export.Format(flags.format)
+	if !slices.Contains(export.Formats, format) {
+		return fmt.Errorf("unknown format %q. Valid formats: md, html, json", flags.format)
+	}
+	if !flags.all && !flags.latest && len(args) == 0 {
+		return fmt.Errorf("please provide a session ID, or use --latest or --all")
+	}
+
+	sessions := findSessions(cmd)
+	if len(sessions) == 0 {
+		return fmt.Errorf("no sessions found")
+	}
+
+	selected := sessions
+	if !flags.all {
+		selected = nil
+		if flags.latest {
+			selected = append(selected, sessions[0])
+		}
+		for _, arg := range args {
+			s, err := findSession(cmd, sessions, []string{arg}, false)
+			if err != nil {
+				return err
+			}
+			if !slices.Contains(selected, s) {
+				selected = append(selected, s)
+			}
+		}
+	}
+
+	out := flags.out
+	if out == "" {
+		out = config.ContextPath(config.DirSessions)
+	}
+	if err := os.MkdirAll(out, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", out, err)
+	}
+
+	opts := export.Options{
+		Thinking:    !flags.noThinking,
+		ToolResults: !flags.noToolResults,
+		Sidechains:  !flags.noSidechains,
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	for _, s := range selected {
+		if err := s.Load(); err != nil {
+			return fmt.Errorf("failed to read session %s: %w", s.ID, err)
+		}
+		var buf bytes.Buffer
+		if err := export.Render(&buf, s, format, opts); err != nil {
+			return fmt.Errorf("failed to render session %s: %w", s.ID, err)
+		}
+		path := filepath.Join(out, export.FileName(s, format))
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		cmd.Printf("%s Wrote %s\n", green("✓"), path)
+	}
+	if len(selected) > 1 {
+		cmd.Printf("Exported %s to %s\n", plural(len(selected), "session"), out)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRecallExport tests writing sessions to files.
+func TestRecallExport(t *testing.T) {
+	home := setupRecallHome(t)
+
+	line := func(uuid, typ, second, content string) claudeLine {
+		return claudeLine{
+			uuid: uuid, session: "abcdef123456789", slug: "export-me", typ: typ,
+			at: "2026-01-10T10:00:" + second + "Z", content: content,
+		}
+	}
+	writeClaudeSession(t, filepath.Join(home, ".claude", "projects", "ctx"),
+		line("u1", "user", "00", `"Write the exporter"`),
+		line("a1", "assistant", "01", `[{"type":"thinking","thinking":"Plan it"},{"type":"text","text":"Done"}]`),
+	)
+
+	run := func(args ...string) (string, error) {
+		return runRecall(t, append([]string{"export"}, args...)...)
+	}
+
+	out := filepath.Join(home, "exports")
+	if _, err := run("export-me", "--out", out, "--no-thinking"); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(out, "2026-01-10-export-me-abcdef12.md"))
+	if err != nil {
+		t.Fatalf("export not written: %v", err)
+	}
+	if !strings.Contains(string(data), "# export-me\n") || !strings.Contains(string(data), "Done") ||
+		strings.Contains(string(data), "Plan it") {
+		t.Errorf("unexpected export:\n%s", data)
+	}
+
+	if _, err := run("--all", "--format", "html", "--out", out); err != nil {
+		t.Fatalf("export --all failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(out, "2026-01-10-export-me-abcdef12.html")); err != nil {
+		t.Errorf("HTML export not written: %v", err)
+	}
+
+	if _, err := run("--latest", "--format", "pdf"); err == nil {
+		t.Error("export with an unknown format succeeded")
+	}
+	if _, err := run("--out", out); err == nil {
+		t.Error("export without a session succeeded")
+	}
+}
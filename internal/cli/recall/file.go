@@ -0,0 +1,182 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+	"github.com/ActiveMemory/ctx/internal/recall/provenance"
+)
+
+// filePromptLen is the length prompts are cut to in recall file output.
+const filePromptLen = 100
+
+// fileFlags holds the flag values of the recall file command.
+//
+// Fields:
+//   - dirs: Additional directories to scan for session files
+//   - limit: Maximum number of sessions listed
+//   - edits: Only list edits, leaving out reads and commands
+type fileFlags struct {
+	dirs  []string
+	limit int
+	edits bool
+}
+
+// fileSession is a session that touched a file, with its touches.
+//
+// Fields:
+//   - session: The loaded session
+//   - touches: Tool calls that touched the file
+type fileSession struct {
+	session *parser.Session
+	touches []provenance.Touch
+}
+
+// runRecallFile handles the recall file command.
+//
+// Sessions are matched by the files recorded in their cached metadata,
+// so only the sessions that touched the file are loaded.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - path: Path of the file, relative to the current directory or
+//     absolute
+//   - flags: All flag values from the command
+//
+// Returns:
+//   - error: Non-nil if the path cannot be resolved or a directory is
+//     invalid
+func runRecallFile(cmd *cobra.Command, path string, flags fileFlags) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	if err := checkDirs(flags.dirs); err != nil {
+		return err
+	}
+
+	sessions := findSessions(cmd, flags.dirs...)
+
+	var found []fileSession
+	for _, s := range sessions {
+		if flags.limit > 0 && len(found) == flags.limit {
+			break
+		}
+		if _, ok := slices.BinarySearch(s.Files, abs); !ok {
+			continue
+		}
+		if err := s.Load(); err != nil {
+			color.New(color.FgYellow).Fprintf(cmd.ErrOrStderr(),
+				"○ Skipped session %s: %v\n", s.ID, err)
+			continue
+		}
+		touches := provenance.Touches(s, abs)
+		if flags.edits {
+			touches = slices.DeleteFunc(touches, func(t provenance.Touch) bool {
+				return t.Kind != provenance.KindEdit
+			})
+		}
+		if len(touches) > 0 {
+			found = append(found, fileSession{session: s, touches: touches})
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	if len(found) == 0 {
+		fmt.Fprintf(out, "No sessions touched %s.\n", path)
+		return nil
+	}
+
+	edits := 0
+	for _, f := range found {
+		for _, t := range f.touches {
+			if t.Kind == provenance.KindEdit {
+				edits++
+			}
+		}
+	}
+	color.New(color.Bold).Fprintf(out, "%s", path)
+	fmt.Fprintf(out, ": %s, %s\n", plural(len(found), "session"), plural(edits, "edit"))
+	for _, f := range found {
+		fmt.Fprintln(out)
+		printFileSession(out, f)
+	}
+	return nil
+}
+
+// printFileSession prints a session and its touches of a file, grouped
+// by the prompt that led to them.
+//
+// Parameters:
+//   - w: Writer for output
+//   - f: Session and touches to print
+func printFileSession(w io.Writer, f fileSession) {
+	s := f.session
+	dim := color.New(color.FgHiBlack)
+
+	name := s.Slug
+	if name == "" {
+		name = s.ID
+	}
+	color.New(color.Bold).Fprint(w, name)
+	dim.Fprintf(w, " (%s)", shortID(s.ID, 8))
+	fmt.Fprintf(w, "  %s  %s", s.StartTime.Local().Format("2006-01-02 15:04"), s.Project)
+	if s.GitBranch != "" {
+		dim.Fprintf(w, " (%s)", s.GitBranch)
+	}
+	fmt.Fprintln(w)
+
+	prompt := "\x00"
+	for _, t := range f.touches {
+		if t.Prompt != prompt {
+			prompt = t.Prompt
+			if prompt == "" {
+				dim.Fprintln(w, "  > (no prompt)")
+			} else {
+				fmt.Fprintf(w, "  > %s\n", oneLine(prompt, filePromptLen))
+			}
+		}
+
+		fmt.Fprintf(w, "    %s %-7s %s", t.Time.Local().Format("15:04"), t.Kind, t.Tool)
+		var notes []string
+		if t.Subagent {
+			notes = append(notes, "subagent")
+		}
+		if t.Abandoned {
+			notes = append(notes, "abandoned branch")
+		}
+		if len(notes) > 0 {
+			dim.Fprintf(w, " (%s)", strings.Join(notes, ", "))
+		}
+		fmt.Fprintln(w)
+
+		if t.Snippet == "" {
+			continue
+		}
+		for _, line := range strings.Split(t.Snippet, "\n") {
+			switch {
+			case t.Kind != provenance.KindEdit:
+				dim.Fprintf(w, "        %s\n", line)
+			case strings.HasPrefix(line, "-"):
+				color.New(color.FgRed).Fprintf(w, "        %s\n", line)
+			case strings.HasPrefix(line, "+"):
+				color.New(color.FgGreen).Fprintf(w, "        %s\n", line)
+			default:
+				fmt.Fprintf(w, "        %s\n", line)
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRecallFile tests listing the sessions that touched a file.
+func TestRecallFile(t *testing.T) {
+	setupRecallHome(t)
+	project := t.TempDir()
+	t.Chdir(project)
+
+	line := func(uuid, typ, content string) claudeLine {
+		return claudeLine{uuid: uuid, slug: "drift-fix", cwd: project, typ: typ, content: content}
+	}
+	dir := t.TempDir()
+	writeClaudeSession(t, dir,
+		line("u1", "user", `"Fix the drift false positive"`),
+		line("a1", "assistant", `[{"type":"tool_use","id":"t1","name":"Read","input":{"file_path":"internal/drift/detector.go"}}]`),
+		line("a2", "assistant", `[{"type":"tool_use","id":"t2","name":"Edit","input":{"file_path":"internal/drift/detector.go","old_string":"stale := true","new_string":"stale := false"}}]`),
+		line("a3", "assistant", `[{"type":"tool_use","id":"t3","name":"Edit","input":{"file_path":"README.md","old_string":"a","new_string":"b"}}]`),
+	)
+
+	run := func(args ...string) string {
+		out, err := runRecall(t, append([]string{"file", "--dir", dir}, args...)...)
+		if err != nil {
+			t.Fatalf("file failed: %v\n%s", err, out)
+		}
+		return out
+	}
+
+	out := run("internal/drift/detector.go")
+	for _, want := range []string{
+		"internal/drift/detector.go: 1 session, 1 edit",
+		"drift-fix",
+		"> Fix the drift false positive",
+		"read    Read",
+		"edit    Edit",
+		"- stale := true",
+		"+ stale := false",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "- a") {
+		t.Errorf("output has the edit of another file:\n%s", out)
+	}
+
+	if out := run("--edits", "internal/drift/detector.go"); strings.Contains(out, "Read") {
+		t.Errorf("--edits output has reads:\n%s", out)
+	}
+	if out := run("other.go"); !strings.Contains(out, "No sessions touched other.go.") {
+		t.Errorf("output for an untouched file = %q", out)
+	}
+}
@@ -0,0 +1,99 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// claudeLine is one line of a Claude Code session file. Empty fields
+// take the defaults of claudeLine.String.
+type claudeLine struct {
+	uuid    string
+	session string
+	slug    string
+	cwd     string
+	// typ is "user" or "assistant"; it is also the message role.
+	typ string
+	// at is the RFC 3339 timestamp of the line.
+	at string
+	// content is the JSON of the message content: a string or a list
+	// of blocks.
+	content string
+	// message holds extra JSON members of the message, e.g. its usage.
+	message string
+	// extra holds extra JSON members of the line, e.g. a toolUseResult.
+	extra string
+}
+
+// String returns the line as JSON, ending with a newline.
+func (l claudeLine) String() string {
+	or := func(s, def string) string {
+		if s == "" {
+			return def
+		}
+		return s
+	}
+	message := `{"role":"` + l.typ + `","content":` + or(l.content, `""`)
+	if l.message != "" {
+		message += "," + l.message
+	}
+	line := `{"uuid":"` + l.uuid + `","sessionId":"` + or(l.session, "s1") +
+		`","slug":"` + or(l.slug, or(l.session, "s1")) + `","type":"` + l.typ +
+		`","timestamp":"` + or(l.at, "2026-01-10T10:00:00Z") + `","cwd":"` + or(l.cwd, "/src/ctx") +
+		`","version":"2.1.0","message":` + message + `}`
+	if l.extra != "" {
+		line += "," + l.extra
+	}
+	return line + "}\n"
+}
+
+// setupRecallHome points HOME and the user cache directory at a temporary
+// directory, so that only the sessions a test writes are found.
+//
+// Returns the home directory.
+func setupRecallHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+	return home
+}
+
+// writeClaudeSession writes the lines to s.jsonl in dir, creating dir if
+// needed.
+func writeClaudeSession(t *testing.T, dir string, lines ...claudeLine) {
+	t.Helper()
+	var content strings.Builder
+	for _, l := range lines {
+		content.WriteString(l.String())
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "s.jsonl"), []byte(content.String()), 0644); err != nil {
+		t.Fatalf("failed to write sessions: %v", err)
+	}
+}
+
+// runRecall runs "ctx recall" with the given arguments.
+//
+// Returns what the command wrote to stdout and stderr, and its error.
+func runRecall(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	cmd := Cmd()
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
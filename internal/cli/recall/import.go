@@ -0,0 +1,424 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/cli/add"
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+	"github.com/ActiveMemory/ctx/internal/recall/extract"
+)
+
+// fieldSource is the entry field recording the session an imported
+// decision or learning came from.
+const fieldSource = "Source"
+
+// importFlags holds the flag values of the recall import command.
+//
+// Fields:
+//   - dirs: Additional directories to scan for session files
+//   - project: Only scan sessions whose project contains this text
+//   - since: Only stage candidates from messages on or after this date
+//   - dryRun: Print what would be staged or imported without writing
+//   - apply: Import accepted candidates instead of scanning sessions
+type importFlags struct {
+	dirs    []string
+	project string
+	since   string
+	dryRun  bool
+	apply   bool
+}
+
+// runRecallImport handles the recall import command.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - flags: All flag values from the command
+//
+// Returns:
+//   - error: Non-nil if the context directory is missing, a flag is
+//     invalid, or reading or writing files fails
+func runRecallImport(cmd *cobra.Command, flags importFlags) error {
+	if !context.Exists("") {
+		return fmt.Errorf("no .context/ directory found. Run 'ctx init' first")
+	}
+	if flags.apply {
+		if len(flags.dirs) > 0 || flags.project != "" || flags.since != "" {
+			return fmt.Errorf("--apply cannot be combined with --dir, --project or --since")
+		}
+		return applyCandidates(cmd, flags.dryRun)
+	}
+
+	var since time.Time
+	if flags.since != "" {
+		t, err := time.ParseInLocation("2006-01-02", flags.since, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --since date %q, expected YYYY-MM-DD", flags.since)
+		}
+		since = t
+	}
+	if err := checkDirs(flags.dirs); err != nil {
+		return err
+	}
+
+	return stageCandidates(cmd, flags.dirs, flags.project, since, flags.dryRun)
+}
+
+// stageCandidates scans sessions for decisions and learnings and adds
+// the new ones to the staging file.
+//
+// Candidates already recorded in DECISIONS.md or LEARNINGS.md, or already
+// staged, accepted or rejected, are skipped.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - dirs: Additional directories to scan for session files
+//   - project: Only scan sessions whose project contains this text
+//   - since: Only stage candidates from messages at or after this time;
+//     zero for no limit
+//   - dryRun: Print the candidates without staging them
+//
+// Returns:
+//   - error: Non-nil if sessions or context files cannot be read, or the
+//     staging file cannot be written
+func stageCandidates(
+	cmd *cobra.Command, dirs []string, project string, since time.Time,
+	dryRun bool,
+) error {
+	sessions := findSessions(cmd, dirs...)
+
+	known, err := recordedInsights()
+	if err != nil {
+		return err
+	}
+	path := stagePath()
+	items, err := readStage(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	for _, it := range items {
+		known.add(it.text)
+	}
+
+	scanned, skipped := 0, 0
+	var found []staged
+	for _, s := range sessions {
+		if project != "" &&
+			!strings.Contains(strings.ToLower(s.Project), strings.ToLower(project)) {
+			continue
+		}
+		if err := s.Load(); err != nil {
+			return fmt.Errorf("failed to read session %s: %w", s.ID, err)
+		}
+		scanned++
+		for _, c := range extract.Session(s) {
+			at := c.Time
+			if at.IsZero() {
+				at = s.StartTime
+			}
+			if !since.IsZero() && at.Before(since) {
+				continue
+			}
+			if known.has(c.Text) {
+				skipped++
+				continue
+			}
+			known.add(c.Text)
+			found = append(found, staged{
+				kind:    c.Kind,
+				state:   context.TaskPending,
+				text:    capitalize(c.Text),
+				session: c.SessionID,
+				at:      at.UTC().Format(time.RFC3339),
+				excerpt: c.Excerpt,
+			})
+		}
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	cmd.Printf("Scanned %s\n", plural(scanned, "session"))
+	for _, it := range found {
+		cmd.Printf("  %-8s  %s\n", it.kind, it.text)
+	}
+	if skipped > 0 {
+		cmd.Printf("%s Skipped %d already recorded or staged\n", yellow("○"), skipped)
+	}
+	if len(found) == 0 {
+		cmd.Println("No new candidates found.")
+		return nil
+	}
+	if dryRun {
+		cmd.Printf("Would stage %s in %s\n",
+			plural(len(found), "candidate"), path)
+		return nil
+	}
+
+	if err := writeStage(path, append(items, found...)); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	cmd.Printf("%s Staged %s in %s\n", green("✓"),
+		plural(len(found), "candidate"), path)
+	cmd.Println("  Mark them [x] to import or [-] to reject, then run 'ctx recall import --apply'")
+	return nil
+}
+
+// applyCandidates imports the accepted candidates of the staging file
+// into DECISIONS.md and LEARNINGS.md.
+//
+// Entries are formatted as by "ctx add", with placeholders for the fields
+// a session excerpt cannot fill, and a Source field naming the session.
+// Imported candidates are removed from the staging file; pending and
+// rejected ones stay.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - dryRun: Print the entries that would be added without writing
+//
+// Returns:
+//   - error: Non-nil if nothing is staged or a file cannot be read or written
+func applyCandidates(cmd *cobra.Command, dryRun bool) error {
+	path := stagePath()
+	items, err := readStage(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if items == nil {
+		return fmt.Errorf("no staged candidates; run 'ctx recall import' first")
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	var remaining []staged
+	applied := 0
+	for i, it := range items {
+		if it.state != context.TaskDone {
+			remaining = append(remaining, it)
+			continue
+		}
+		if dryRun {
+			cmd.Printf("Would add %s: %s\n", it.kind, it.text)
+			applied++
+			continue
+		}
+		id, fName, err := importEntry(it)
+		if err != nil {
+			// Keep the rest staged so the import can be retried
+			if werr := writeStage(path, append(remaining, items[i:]...)); werr != nil {
+				return fmt.Errorf("%w (and failed to update %s: %v)", err, path, werr)
+			}
+			return err
+		}
+		cmd.Printf("%s Added %s to %s\n", green("✓"), id, fName)
+		applied++
+	}
+
+	if applied == 0 {
+		cmd.Println("No accepted candidates. Mark candidates [x] in " + path + " to import them.")
+		return nil
+	}
+	if !dryRun {
+		if err := writeStage(path, remaining); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	if pending := countPending(remaining); pending > 0 {
+		cmd.Printf("%s %s left for review in %s\n", yellow("○"),
+			plural(pending, "candidate"), path)
+	}
+	return nil
+}
+
+// importEntry formats an accepted candidate as a decision or learning and
+// adds it to its context file.
+//
+// Parameters:
+//   - it: Accepted candidate
+//
+// Returns:
+//   - string: ID of the new entry
+//   - string: Name of the file it was added to
+//   - error: Non-nil if the file cannot be read or written
+func importEntry(it staged) (string, string, error) {
+	fType := string(it.kind)
+	fName := config.FileType[fType]
+	filePath := config.ContextPath(fName)
+
+	existing, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+	id, err := add.EntryID(fType)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to assign an ID: %w", err)
+	}
+
+	ctxText := "[Context from recall import - please update]"
+	if it.excerpt != "" {
+		ctxText = fmt.Sprintf("Found in an AI session: %q", it.excerpt)
+	}
+	var entry string
+	if it.kind == extract.KindDecision {
+		entry = add.FormatDecision(id, it.text, ctxText,
+			"[Rationale from recall import - please update]",
+			"[Consequences from recall import - please update]")
+	} else {
+		entry = add.FormatLearning(id, it.text, ctxText,
+			"[Lesson from recall import - please update]",
+			"[Application from recall import - please update]")
+	}
+	entry = withSource(fName, entry, it)
+
+	content := add.AppendEntry(existing, entry, fType, "")
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		return "", "", fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+	return id, fName, nil
+}
+
+// withSource adds a Source field naming the session and message time of
+// a candidate to a formatted entry.
+//
+// Parameters:
+//   - fName: Context file the entry belongs to
+//   - entry: Formatted decision or learning
+//   - it: Candidate the entry was made from
+//
+// Returns:
+//   - string: Entry with the Source field appended
+func withSource(fName, entry string, it staged) string {
+	if it.session == "" {
+		return entry
+	}
+	source := "session " + it.session
+	if it.at != "" {
+		source += " at " + it.at
+	}
+
+	doc := context.ParseDocument(fName, []byte(entry))
+	for _, e := range doc.Entries() {
+		switch r := e.(type) {
+		case *context.Decision:
+			r.SetField(fieldSource, source)
+		case *context.Learning:
+			r.SetField(fieldSource, source)
+		}
+	}
+	return doc.String()
+}
+
+// capitalize upper-cases the first letter of an insight, which starts
+// mid-sentence in the session, so that it reads as an entry title.
+//
+// Parameters:
+//   - s: Insight text
+//
+// Returns:
+//   - string: Text with its first letter upper-cased
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// countPending returns the number of candidates awaiting review.
+//
+// Parameters:
+//   - items: Staged candidates
+//
+// Returns:
+//   - int: Number of pending candidates
+func countPending(items []staged) int {
+	n := 0
+	for _, it := range items {
+		if it.state == context.TaskPending {
+			n++
+		}
+	}
+	return n
+}
+
+// insightSet holds normalized insight texts for deduplication.
+//
+// Fields:
+//   - texts: Normalized texts of insights and entry titles
+//   - bodies: Normalized full texts of recorded entries
+type insightSet struct {
+	texts  map[string]bool
+	bodies []string
+}
+
+// add records an insight text.
+//
+// Parameters:
+//   - text: Insight text, normalized before it is stored
+func (s *insightSet) add(text string) {
+	s.texts[extract.Normalize(text)] = true
+}
+
+// has reports whether an insight is already known: equal to a recorded
+// text or contained in a recorded entry.
+//
+// Parameters:
+//   - text: Insight text
+//
+// Returns:
+//   - bool: True if the insight is a duplicate
+func (s *insightSet) has(text string) bool {
+	key := extract.Normalize(text)
+	if s.texts[key] {
+		return true
+	}
+	for _, body := range s.bodies {
+		if strings.Contains(body, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordedInsights collects the decisions and learnings in the context
+// directory, for deduplicating candidates against them.
+//
+// Returns:
+//   - *insightSet: Titles and texts of the recorded entries
+//   - error: Non-nil if a context file exists but cannot be read
+func recordedInsights() (*insightSet, error) {
+	set := &insightSet{texts: make(map[string]bool)}
+	for _, name := range []string{config.FilenameDecision, config.FilenameLearning} {
+		doc, err := context.LoadDocument("", name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		for _, d := range doc.Decisions() {
+			set.add(d.Title())
+			set.bodies = append(set.bodies, extract.Normalize(d.String()))
+		}
+		for _, l := range doc.Learnings() {
+			set.add(l.Title())
+			set.bodies = append(set.bodies, extract.Normalize(l.String()))
+		}
+	}
+	return set, nil
+}
@@ -0,0 +1,134 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/cli/add"
+	"github.com/ActiveMemory/ctx/internal/cli/initialize"
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// importSessions is a Claude Code session with two decisions and a
+// learning in its assistant message.
+const importSessions = `{"uuid":"m1","sessionId":"sess-import","slug":"quiet-running-hopper","type":"user","timestamp":"2026-01-20T10:00:00Z","cwd":"/home/test/alpha","version":"2.1.0","message":{"role":"user","content":[{"type":"text","text":"Set up storage"}]}}
+{"uuid":"m2","parentUuid":"m1","sessionId":"sess-import","slug":"quiet-running-hopper","type":"assistant","timestamp":"2026-01-20T10:05:00Z","cwd":"/home/test/alpha","version":"2.1.0","message":{"role":"assistant","content":[{"type":"thinking","thinking":"Turns out the sqlite driver needs cgo to build here."},{"type":"text","text":"I decided to use PostgreSQL for primary storage.\nWe'll use goose for schema migrations."}]}}
+`
+
+// runImport runs "ctx recall import" with the given arguments.
+func runImport(t *testing.T, args ...string) string {
+	t.Helper()
+	out, err := runRecall(t, append([]string{"import"}, args...)...)
+	if err != nil {
+		t.Fatalf("recall import %v failed: %v\n%s", args, err, out)
+	}
+	return out
+}
+
+// TestRecallImport tests staging, deduplication and applying candidates.
+func TestRecallImport(t *testing.T) {
+	setupRecallHome(t)
+	t.Chdir(t.TempDir())
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	sessions := t.TempDir()
+	if err := os.WriteFile(filepath.Join(sessions, "s.jsonl"), []byte(importSessions), 0644); err != nil {
+		t.Fatalf("failed to write sessions: %v", err)
+	}
+
+	// An existing decision covers one of the candidates
+	decisions, err := os.ReadFile(".context/DECISIONS.md")
+	if err != nil {
+		t.Fatalf("failed to read DECISIONS.md: %v", err)
+	}
+	entry := add.FormatDecision("D-001", "Use goose for schema migrations", "c", "r", "q")
+	if err := os.WriteFile(".context/DECISIONS.md", add.AppendEntry(decisions, entry, "decision", ""), 0644); err != nil {
+		t.Fatalf("failed to write DECISIONS.md: %v", err)
+	}
+
+	out := runImport(t, "--dir", sessions, "--dry-run")
+	if !strings.Contains(out, "Would stage 2 candidates") {
+		t.Errorf("dry run output = %q", out)
+	}
+	if _, err := os.Stat(stagePath()); !os.IsNotExist(err) {
+		t.Fatalf("dry run created the staging file")
+	}
+
+	out = runImport(t, "--dir", sessions)
+	if !strings.Contains(out, "Skipped 1 already recorded or staged") {
+		t.Errorf("import output = %q", out)
+	}
+	items, err := readStage(stagePath())
+	if err != nil {
+		t.Fatalf("readStage failed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("staged %d candidates, want 2: %+v", len(items), items)
+	}
+	want := staged{
+		kind:    "decision",
+		state:   context.TaskPending,
+		text:    "Use PostgreSQL for primary storage",
+		session: "sess-import",
+		at:      "2026-01-20T10:05:00Z",
+		excerpt: "I decided to use PostgreSQL for primary storage.",
+	}
+	if items[0] != want {
+		t.Errorf("staged decision = %+v, want %+v", items[0], want)
+	}
+
+	// Importing again stages nothing new
+	if out := runImport(t, "--dir", sessions); !strings.Contains(out, "No new candidates") {
+		t.Errorf("second import output = %q", out)
+	}
+
+	// Accept the decision, reject the learning
+	items[0].state = context.TaskDone
+	items[1].state = context.TaskSkipped
+	if err := writeStage(stagePath(), items); err != nil {
+		t.Fatalf("writeStage failed: %v", err)
+	}
+
+	out = runImport(t, "--apply")
+	if !strings.Contains(out, "Added D-002 to DECISIONS.md") {
+		t.Errorf("apply output = %q", out)
+	}
+
+	doc, err := context.LoadDocument("", "DECISIONS.md")
+	if err != nil {
+		t.Fatalf("failed to load DECISIONS.md: %v", err)
+	}
+	d := doc.Decisions()[0]
+	if d.Title() != "Use PostgreSQL for primary storage" || d.ID() != "D-002" {
+		t.Errorf("imported decision = %q %q", d.ID(), d.Title())
+	}
+	if got := d.Field("Source"); got != "session sess-import at 2026-01-20T10:05:00Z" {
+		t.Errorf("Source = %q", got)
+	}
+
+	items, err = readStage(stagePath())
+	if err != nil {
+		t.Fatalf("readStage failed: %v", err)
+	}
+	if len(items) != 1 || items[0].state != context.TaskSkipped {
+		t.Errorf("staging file after apply = %+v, want only the rejected learning", items)
+	}
+
+	// Neither the imported nor the rejected candidate is staged again
+	if out := runImport(t, "--dir", sessions); !strings.Contains(out, "Skipped 3") {
+		t.Errorf("import after apply output = %q", out)
+	}
+}
@@ -0,0 +1,128 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/recall/provenance"
+)
+
+// formatDiff and formatMbox are the output formats of recall patches.
+const (
+	formatDiff = "diff"
+	formatMbox = "mbox"
+)
+
+// runRecallPatches handles the recall patches command.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - args: Session ID or slug
+//   - latest: Use the latest session
+//   - format: Output format: diff or mbox
+//
+// Returns:
+//   - error: Non-nil if the format is unknown, or the session cannot be
+//     found or read
+func runRecallPatches(cmd *cobra.Command, args []string, latest bool, format string) error {
+	if !slices.Contains([]string{formatDiff, formatMbox}, format) {
+		return fmt.Errorf("unknown format %q. Valid formats: diff, mbox", format)
+	}
+
+	sessions := findSessions(cmd)
+	if len(sessions) == 0 {
+		return fmt.Errorf("no sessions found")
+	}
+	session, err := findSession(cmd, sessions, args, latest)
+	if err != nil {
+		return err
+	}
+	if err := session.Load(); err != nil {
+		return fmt.Errorf("failed to read session %s: %w", session.ID, err)
+	}
+
+	patches := provenance.Patches(session)
+	if format == formatDiff {
+		if len(patches) == 0 {
+			cmd.Printf("No edits found in session %s.\n", session.ID)
+			return nil
+		}
+		printPatches(cmd.OutOrStdout(), patches)
+		return nil
+	}
+
+	// git am stops at the first patch that does not apply
+	var applicable []provenance.Patch
+	for _, p := range patches {
+		if p.Exact && !p.Failed {
+			applicable = append(applicable, p)
+		}
+	}
+	if left := len(patches) - len(applicable); left > 0 {
+		color.New(color.FgYellow).Fprintf(cmd.ErrOrStderr(),
+			"○ Left out %s that failed or whose file content was unknown\n", plural(left, "edit"))
+	}
+	return provenance.WriteMbox(cmd.OutOrStdout(), session, applicable)
+}
+
+// printPatches prints patches as git diffs, each after a comment line
+// with its number, time, tool and file, and the prompt that led to it.
+//
+// Parameters:
+//   - w: Writer for output
+//   - patches: Patches to print
+func printPatches(w io.Writer, patches []provenance.Patch) {
+	header := color.New(color.Bold)
+	dim := color.New(color.FgHiBlack)
+
+	prompt := ""
+	for i, p := range patches {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if p.Prompt != prompt {
+			prompt = p.Prompt
+			dim.Fprintf(w, "# Prompt: %s\n", oneLine(prompt, filePromptLen))
+		}
+		header.Fprintf(w, "# %d/%d %s %s %s", i+1, len(patches),
+			p.Time.Local().Format("15:04:05"), p.Tool, p.Name)
+		if p.Subagent {
+			dim.Fprint(w, " (subagent)")
+		}
+		fmt.Fprintln(w)
+		if p.Failed {
+			color.New(color.FgRed).Fprintf(w, "# FAILED: %s\n", p.Error)
+		}
+		if !p.Exact {
+			dim.Fprintln(w, "# File content before the edit unknown: hunks have no line numbers")
+		}
+
+		for _, line := range strings.SplitAfter(strings.TrimSuffix(p.Diff(), "\n"), "\n") {
+			switch {
+			case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"),
+				strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "new file"):
+				header.Fprint(w, line)
+			case strings.HasPrefix(line, "@@"):
+				color.New(color.FgCyan).Fprint(w, line)
+			case strings.HasPrefix(line, "-"):
+				color.New(color.FgRed).Fprint(w, line)
+			case strings.HasPrefix(line, "+"):
+				color.New(color.FgGreen).Fprint(w, line)
+			default:
+				fmt.Fprint(w, line)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,63 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRecallPatches tests replaying the edits of a session.
+func TestRecallPatches(t *testing.T) {
+	home := setupRecallHome(t)
+
+	line := func(uuid, typ, second, content string) claudeLine {
+		return claudeLine{
+			uuid: uuid, session: "abcdef123456789", slug: "drift-fix", typ: typ,
+			at: "2026-01-10T10:00:" + second + "Z", content: content,
+		}
+	}
+	result := line("u2", "user", "02", `[{"type":"tool_result","tool_use_id":"t1","content":"ok"}]`)
+	result.extra = `"toolUseResult":{"originalFile":"package drift\n\nvar stale = true\n"}`
+	writeClaudeSession(t, filepath.Join(home, ".claude", "projects", "ctx"),
+		line("u1", "user", "00", `"Fix the drift false positive"`),
+		line("a1", "assistant", "01", `[{"type":"tool_use","id":"t1","name":"Edit","input":{"file_path":"/src/ctx/drift.go","old_string":"true","new_string":"false"}}]`),
+		result,
+		line("a2", "assistant", "03", `[{"type":"tool_use","id":"t2","name":"Edit","input":{"file_path":"/src/ctx/drift.go","old_string":"nope","new_string":"x"}}]`),
+		line("u3", "user", "04", `[{"type":"tool_result","tool_use_id":"t2","content":"String to replace not found","is_error":true}]`),
+	)
+
+	run := func(args ...string) string {
+		out, err := runRecall(t, append([]string{"patches"}, args...)...)
+		if err != nil {
+			t.Fatalf("patches failed: %v\n%s", err, out)
+		}
+		return out
+	}
+
+	out := run("drift-fix")
+	for _, want := range []string{
+		"# Prompt: Fix the drift false positive",
+		"# 1/2",
+		"@@ -1,3 +1,3 @@\n package drift\n \n-var stale = true\n+var stale = false\n",
+		"# FAILED: String to replace not found",
+		"@@ @@\n-nope\n+x\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("diff output missing %q:\n%s", want, out)
+		}
+	}
+
+	out = run("--latest", "--format", "mbox")
+	if !strings.Contains(out, "Subject: [PATCH 1/1] Edit drift.go\n") || strings.Contains(out, "nope") {
+		t.Errorf("mbox should hold only the applied edit:\n%s", out)
+	}
+	if !strings.Contains(out, "Left out 1 edit") {
+		t.Errorf("output = %q, want a note on the failed edit", out)
+	}
+}
@@ -0,0 +1,194 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/recall/reason"
+	"github.com/ActiveMemory/ctx/internal/recall/search"
+)
+
+// reasonFlags holds the flag values of the recall reason command.
+//
+// Fields:
+//   - dirs: Additional directories of session files to index
+//   - project: Only show reasoning from projects containing this text
+//   - category: Only show reasoning of this category
+//   - outcome: Only show reasoning with this outcome
+//   - limit: Maximum number of results
+type reasonFlags struct {
+	dirs     []string
+	project  string
+	category string
+	outcome  string
+	limit    int
+}
+
+// runRecallReason handles the recall reason command.
+//
+// Thinking blocks matching the query are listed with their reasoning
+// category and outcome, followed by the anti-patterns among them: groups
+// of similar reasoning that mostly failed.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - args: Query words
+//   - flags: All flag values from the command
+//
+// Returns:
+//   - error: Non-nil if an argument is invalid or the index cannot be
+//     read, updated or written
+func runRecallReason(cmd *cobra.Command, args []string, flags reasonFlags) error {
+	query := strings.Join(args, " ")
+	if len(search.Tokenize(query)) == 0 {
+		return fmt.Errorf("query %q has no searchable words", query)
+	}
+	if flags.category != "" && !slices.Contains(reason.Categories, reason.Category(flags.category)) {
+		return fmt.Errorf("unknown category %q. Valid categories: %s",
+			flags.category, joinValues(reason.Categories))
+	}
+	if flags.outcome != "" && !slices.Contains(reason.Outcomes, reason.Outcome(flags.outcome)) {
+		return fmt.Errorf("unknown outcome %q. Valid outcomes: %s",
+			flags.outcome, joinValues(reason.Outcomes))
+	}
+	if err := checkDirs(flags.dirs); err != nil {
+		return err
+	}
+
+	idx, stats, err := updateIndex(flags.dirs)
+	if err != nil {
+		return err
+	}
+	warnParseErrors(cmd, stats.Errors)
+
+	// Anti-patterns need every outcome; the listing only the requested one
+	all := idx.Search(query, search.Options{
+		Project:  flags.project,
+		Type:     search.TypeThinking,
+		Category: flags.category,
+	})
+	var results []search.Result
+	for _, r := range all {
+		if flags.outcome != "" && r.Chunk.Outcome != flags.outcome {
+			continue
+		}
+		results = append(results, r)
+		if flags.limit > 0 && len(results) == flags.limit {
+			break
+		}
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No reasoning found for %q.\n", query)
+		return nil
+	}
+
+	out := cmd.OutOrStdout()
+	dim := color.New(color.FgHiBlack)
+	fmt.Fprintf(out, "Found %s:\n\n", plural(len(results), "relevant pattern"))
+	for i, r := range results {
+		c := r.Chunk
+		fmt.Fprintf(out, "%2d. %s %s - %s\n", i+1, outcomeLabel(c.Outcome),
+			categoryName(c.Category), sessionLabel(c))
+		fmt.Fprintf(out, "    %q\n", r.Snippet)
+		if c.Evidence != "" {
+			fmt.Fprintf(out, "    → %s\n", c.Evidence)
+		}
+		dim.Fprintf(out, "    /session/%s#msg-%d\n", c.SessionID, c.Message)
+		fmt.Fprintln(out)
+	}
+
+	patterns := search.AntiPatterns(all)
+	if len(patterns) == 0 {
+		return nil
+	}
+	color.New(color.Bold).Fprintln(out, "Anti-patterns (similar reasoning that mostly failed):")
+	for _, p := range patterns {
+		fmt.Fprintf(out, "- %s: failed %d of %d times\n",
+			strings.Join(p.Terms, ", "), len(p.Failures), p.Occurrences)
+		f := p.Failures[0]
+		fmt.Fprintf(out, "  e.g. %s: %q\n", sessionLabel(f.Chunk), f.Snippet)
+		if p.Better != nil {
+			fmt.Fprintf(out, "  Better: %s: %q\n", sessionLabel(p.Better.Chunk), p.Better.Snippet)
+		}
+	}
+
+	return nil
+}
+
+// outcomeLabel returns the colored "[OUTCOME]" label of a thinking chunk.
+//
+// Parameters:
+//   - outcome: Outcome of the chunk
+//
+// Returns:
+//   - string: Label, green for success and red for failure
+func outcomeLabel(outcome string) string {
+	label := "[" + strings.ToUpper(outcome) + "]"
+	switch reason.Outcome(outcome) {
+	case reason.OutcomeSuccess:
+		return color.GreenString(label)
+	case reason.OutcomeFailure:
+		return color.RedString(label)
+	}
+	return color.YellowString(label)
+}
+
+// categoryName returns the display name of a reasoning category, e.g.
+// "Error Analysis" for "error_analysis".
+//
+// Parameters:
+//   - category: Reasoning category
+//
+// Returns:
+//   - string: Title-cased name
+func categoryName(category string) string {
+	words := strings.Split(category, "_")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// sessionLabel returns the date and session of a chunk, e.g.
+// "2026-01-20 (brave-sailing-mercury)".
+//
+// Parameters:
+//   - c: Chunk to label
+//
+// Returns:
+//   - string: Local date and session slug, or ID without a slug
+func sessionLabel(c *search.Chunk) string {
+	name := c.Slug
+	if name == "" {
+		name = c.SessionID
+	}
+	return c.Timestamp.Local().Format("2006-01-02") + " (" + name + ")"
+}
+
+// joinValues joins string-typed values with commas.
+//
+// Parameters:
+//   - values: Values to join
+//
+// Returns:
+//   - string: Comma-separated values
+func joinValues[T ~string](values []T) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = string(v)
+	}
+	return strings.Join(s, ", ")
+}
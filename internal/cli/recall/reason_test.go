@@ -0,0 +1,89 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// reasonSession returns the lines of a Claude Code session in which a
+// thinking block is followed by a failed tool call, or by the user
+// confirming success.
+func reasonSession(id, day, thinking, result string, failed bool) []claudeLine {
+	reply := fmt.Sprintf(`[{"type":"text","text":%q}]`, result)
+	if failed {
+		reply = fmt.Sprintf(
+			`[{"type":"tool_result","tool_use_id":"t-%s","content":%q,"is_error":true}]`,
+			id, result,
+		)
+	}
+	line := func(n int, typ, content string) claudeLine {
+		return claudeLine{
+			uuid: fmt.Sprintf("%s-%d", id, n), session: id, slug: "slug-" + id,
+			cwd: "/src/app", typ: typ,
+			at:      fmt.Sprintf("2026-01-%sT10:00:0%dZ", day, n),
+			content: content,
+		}
+	}
+	return []claudeLine{
+		line(1, "user", `[{"type":"text","text":"The cache is stale"}]`),
+		line(2, "assistant", fmt.Sprintf(
+			`[{"type":"thinking","thinking":%q},{"type":"tool_use","id":"t-%s","name":"Bash","input":{}}]`,
+			thinking, id)),
+		line(3, "user", reply),
+	}
+}
+
+// TestRecallReason tests listing reasoning with outcomes and reporting
+// anti-patterns.
+func TestRecallReason(t *testing.T) {
+	setupRecallHome(t)
+
+	hypothesis := "Maybe the cache TTL is too long. I suspect the redis cache keeps stale entries."
+	dir := t.TempDir()
+	writeClaudeSession(t, dir, slices.Concat(
+		reasonSession("fail0", "10", hypothesis, "ERR unknown key", true),
+		reasonSession("fail1", "11", hypothesis, "ERR unknown key", true),
+		reasonSession("fail2", "12", hypothesis, "ERR unknown key", true),
+		reasonSession("ok", "15",
+			"Let me break this down: 1. find where the redis cache is written 2. invalidate it on update",
+			"Great, all tests pass now.", false),
+	)...)
+
+	run := func(args ...string) (string, error) {
+		return runRecall(t, append([]string{"reason", "--dir", dir}, args...)...)
+	}
+
+	out, err := run("redis", "cache")
+	if err != nil {
+		t.Fatalf("reason failed: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"[FAILURE] Hypothesis", "→ ERR unknown key",
+		"[SUCCESS] Decomposition", "→ Great, all tests pass now.",
+		"Anti-patterns", "failed 3 of 3 times",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	out, err = run("redis", "cache", "--outcome", "success")
+	if err != nil {
+		t.Fatalf("reason failed: %v\n%s", err, out)
+	}
+	if strings.Contains(out, "FAILURE") || !strings.Contains(out, "SUCCESS") {
+		t.Errorf("--outcome success output:\n%s", out)
+	}
+
+	if _, err := run("redis", "--category", "guess"); err == nil {
+		t.Error("unknown --category was accepted")
+	}
+}
@@ -8,6 +8,9 @@ package recall
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/recall/export"
+	"github.com/ActiveMemory/ctx/internal/recall/stats"
 )
 
 // Cmd returns the recall command with subcommands.
@@ -16,30 +19,86 @@ import (
 // history across multiple tools (Claude Code, Aider, etc.).
 //
 // Returns:
-//   - *cobra.Command: The recall command with list, show, and serve subcommands
+//   - *cobra.Command: The recall command with list, show, patches, export,
+//     search, reason, stats, tools, file, serve, import, and reindex
+//     subcommands
 func Cmd() *cobra.Command {
+	var (
+		auto   bool
+		budget int
+		dirs   []string
+	)
+
 	cmd := &cobra.Command{
 		Use:   "recall",
 		Short: "Browse and search AI session history",
 		Long: `Browse and search AI session history from Claude Code and other tools.
 
-The recall system parses JSONL session files and provides commands to
-list sessions, view details, and search across your conversation history.
+The recall system parses Claude Code session files from ~/.claude/projects,
+Codex CLI rollouts from ~/.codex/sessions and Aider chat histories from
+project roots, and provides commands to list sessions, view details, and
+search across your conversation history.
+
+With --auto, print a "Relevant History" section for the current project:
+the latest sessions run in this directory and the session chunks that
+best match the active tasks in TASKS.md and the recently changed files,
+preferring sessions on the current git branch, fitted to --budget.
 
 Subcommands:
   list    List all parsed sessions
   show    Show details of a specific session
-  serve   Start web server for browsing (coming soon)
+  patches Replay the edits of a session as a patch series
+  export  Write sessions as Markdown, HTML or JSON files
+  search  Search session history
+  reason  Search past reasoning by category and outcome
+  stats   Report token usage and estimated cost
+  tools   Report tool calls, failing commands and file use
+  file    List the sessions that touched a file
+  serve   Start a local web server for browsing sessions
+  import  Stage decisions and learnings found in sessions
+  reindex Rebuild the session parse cache
 
 Examples:
   ctx recall list
   ctx recall list --limit 5
   ctx recall show abc123
-  ctx recall show --latest`,
+  ctx recall show --latest
+  ctx recall patches --latest --format mbox | git am
+  ctx recall export --all --format md
+  ctx recall search "how did I handle authentication?"
+  ctx recall reason --outcome failure caching
+  ctx recall stats --by project --since 2026-01-01
+  ctx recall tools --project api
+  ctx recall file internal/drift/detector.go
+  ctx recall serve --open
+  ctx recall import --since 2026-01-01
+  ctx recall reindex
+  ctx recall --auto --budget 2000`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !auto {
+				return cmd.Help()
+			}
+			return runRecallAuto(cmd, dirs, budget)
+		},
 	}
 
+	cmd.Flags().BoolVar(&auto, "auto", false, "Print the history relevant to the current project")
+	cmd.Flags().IntVar(&budget, "budget", 4000, "Token budget for --auto")
+	cmd.Flags().StringArrayVar(&dirs, "dir", nil, "Additional directory of session files to index (repeatable)")
+
 	cmd.AddCommand(recallListCmd())
 	cmd.AddCommand(recallShowCmd())
+	cmd.AddCommand(recallPatchesCmd())
+	cmd.AddCommand(recallExportCmd())
+	cmd.AddCommand(recallSearchCmd())
+	cmd.AddCommand(recallReasonCmd())
+	cmd.AddCommand(recallStatsCmd())
+	cmd.AddCommand(recallToolsCmd())
+	cmd.AddCommand(recallFileCmd())
+	cmd.AddCommand(recallServeCmd())
+	cmd.AddCommand(recallImportCmd())
+	cmd.AddCommand(recallReindexCmd())
 
 	return cmd
 }
@@ -64,11 +123,15 @@ Sessions are sorted by date (newest first) and display:
   - Turn count (user messages)
   - Token usage
 
+--tool accepts the built-in tools (claude-code, codex, aider) and the
+tools of external parsers declared in .contextrc.
+
 Examples:
   ctx recall list
   ctx recall list --limit 5
   ctx recall list --project ctx
-  ctx recall list --tool claude-code`,
+  ctx recall list --tool claude-code
+  ctx recall list --tool codex`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runRecallList(cmd, limit, project, tool)
 		},
@@ -76,7 +139,7 @@ Examples:
 
 	cmd.Flags().IntVarP(&limit, "limit", "n", 20, "Maximum sessions to display")
 	cmd.Flags().StringVarP(&project, "project", "p", "", "Filter by project name")
-	cmd.Flags().StringVarP(&tool, "tool", "t", "", "Filter by tool (e.g., claude-code)")
+	cmd.Flags().StringVarP(&tool, "tool", "t", "", "Filter by tool (claude-code, codex, aider, or an external parser's tool)")
 
 	return cmd
 }
@@ -86,6 +149,7 @@ func recallShowCmd() *cobra.Command {
 	var (
 		latest bool
 		full   bool
+		tree   bool
 	)
 
 	cmd := &cobra.Command{
@@ -100,18 +164,446 @@ The session ID can be:
 
 Use --latest to show the most recent session.
 
+Use --tree to show the conversation as a tree: subagent conversations
+under the tool call that spawned them, and branches abandoned by
+rewinding or editing a prompt under the message they forked from.
+
 Examples:
   ctx recall show abc123
   ctx recall show gleaming-wobbling-sutherland
   ctx recall show --latest
-  ctx recall show --latest --full`,
+  ctx recall show --latest --full
+  ctx recall show --latest --tree`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runRecallShow(cmd, args, latest, full)
+			return runRecallShow(cmd, args, latest, full, tree)
 		},
 	}
 
 	cmd.Flags().BoolVar(&latest, "latest", false, "Show the most recent session")
 	cmd.Flags().BoolVar(&full, "full", false, "Show full message content")
+	cmd.Flags().BoolVar(&tree, "tree", false, "Show the conversation tree with branches and sidechains")
+
+	return cmd
+}
+
+// recallPatchesCmd returns the recall patches subcommand.
+func recallPatchesCmd() *cobra.Command {
+	var (
+		latest bool
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "patches [session-id]",
+		Short: "Replay the edits of a session as a patch series",
+		Long: `Replay the Edit, MultiEdit and Write calls of a session in order and
+print the change of each as a unified diff, under the user prompt that
+led to it. Edits the tool reported as failed are marked; they were not
+made.
+
+The content of a file before an edit is taken from the original the
+tool recorded with the edit, from an earlier read of the whole file, or
+from the session's earlier edits. Without it, the hunks show the
+replaced text without line numbers.
+
+With --format mbox, the edits are written as a mailbox that 'git am'
+applies as one commit per edit, with the prompt as commit message.
+Failed edits and edits without line numbers are left out.
+
+The session is named as for 'ctx recall show'.
+
+Examples:
+  ctx recall patches abc123
+  ctx recall patches --latest | less -R
+  ctx recall patches gleaming-wobbling-sutherland --format mbox > edits.mbox
+  git am edits.mbox`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRecallPatches(cmd, args, latest, format)
+		},
+	}
+
+	cmd.Flags().BoolVar(&latest, "latest", false, "Use the most recent session")
+	cmd.Flags().StringVar(&format, "format", formatDiff, "Output format: diff or mbox")
+
+	return cmd
+}
+
+// recallExportCmd returns the recall export subcommand.
+func recallExportCmd() *cobra.Command {
+	var flags exportFlags
+
+	cmd := &cobra.Command{
+		Use:   "export [session-id...]",
+		Short: "Write sessions as Markdown, HTML or JSON files",
+		Long: `Write sessions as Markdown, HTML or JSON files, one per session, named
+by start date, slug and short ID (e.g. 2026-01-21-gleaming-wobbling-
+sutherland-abc12345.md).
+
+Files are written to .context/sessions/ unless --out is given. The
+output depends only on the session and the flags: times are in UTC and
+tool inputs in key order, so exports can be committed and regenerated
+without spurious changes.
+
+HTML exports are standalone pages with inline styles. JSON exports hold
+the parsed session with its messages.
+
+Sessions are named as for 'ctx recall show'.
+
+Examples:
+  ctx recall export --latest
+  ctx recall export abc123 gleaming --format html --out /tmp/sessions
+  ctx recall export --all --no-thinking --no-tool-results`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRecallExport(cmd, args, flags)
+		},
+	}
+
+	cmd.Flags().BoolVar(&flags.all, "all", false, "Export all sessions")
+	cmd.Flags().BoolVar(&flags.latest, "latest", false, "Export the most recent session")
+	cmd.Flags().StringVar(&flags.format, "format", string(export.FormatMarkdown), "Export format: md, html or json")
+	cmd.Flags().StringVar(&flags.out, "out", "", "Directory to write to (default .context/sessions)")
+	cmd.Flags().BoolVar(&flags.noThinking, "no-thinking", false, "Leave out reasoning blocks")
+	cmd.Flags().BoolVar(&flags.noToolResults, "no-tool-results", false, "Leave out tool results")
+	cmd.Flags().BoolVar(&flags.noSidechains, "no-sidechains", false, "Leave out subagent conversations")
+
+	return cmd
+}
+
+// recallSearchCmd returns the recall search subcommand.
+func recallSearchCmd() *cobra.Command {
+	var (
+		dirs      []string
+		project   string
+		chunkType string
+		limit     int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search session history",
+		Long: `Search session history for a query, ranked by relevance.
+
+Sessions are split into chunks: user questions with the answers that
+follow them (turn_pair), reasoning blocks (thinking), and tool calls
+with their results (tool). Chunks are ranked with BM25 on the words of
+the query; no network or embedding service is used.
+
+The index is kept in the user cache directory and brought up to date
+before each search: only session files that are new or have changed
+since the last search are parsed.
+
+Each result shows a snippet, the session ID and message time, and the
+path of the message on the 'ctx recall serve' web UI.
+
+Examples:
+  ctx recall search "how did I handle authentication?"
+  ctx recall search jwt refresh --project api
+  ctx recall search "go:embed" --type thinking --limit 5`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRecallSearch(cmd, args, dirs, project, chunkType, limit)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&dirs, "dir", nil, "Additional directory of session files to index (repeatable)")
+	cmd.Flags().StringVarP(&project, "project", "p", "", "Filter by project name")
+	cmd.Flags().StringVar(&chunkType, "type", "", "Filter by chunk type (turn_pair, thinking, tool)")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 10, "Maximum results to display")
+
+	return cmd
+}
+
+// recallReasonCmd returns the recall reason subcommand.
+func recallReasonCmd() *cobra.Command {
+	var flags reasonFlags
+
+	cmd := &cobra.Command{
+		Use:   "reason <query>",
+		Short: "Search past reasoning by category and outcome",
+		Long: `Search the thinking blocks of past sessions.
+
+Each thinking block is classified by its reasoning category and by its
+outcome, from the messages that follow it:
+
+Categories:
+  decomposition   Breaking a problem into steps
+  hypothesis      Forming and testing theories
+  pivot           Recognizing a dead end and changing course
+  error_analysis  Working from errors and stack traces
+  other           None of the above
+
+Outcomes:
+  success         Success language or continued progress followed
+  failure         A tool error, failure language or a pivot followed
+  abandoned       The session ended without a resolution
+  unknown         No indication either way
+
+Matching blocks are ranked like 'ctx recall search'. Groups of similar
+reasoning that failed more often than not are listed as anti-patterns,
+with a successful alternative where one exists.
+
+Examples:
+  ctx recall reason "how to debug connection refused"
+  ctx recall reason --category error_analysis database
+  ctx recall reason --outcome failure caching`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRecallReason(cmd, args, flags)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&flags.dirs, "dir", nil, "Additional directory of session files to index (repeatable)")
+	cmd.Flags().StringVarP(&flags.project, "project", "p", "", "Filter by project name")
+	cmd.Flags().StringVar(&flags.category, "category", "", "Filter by reasoning category")
+	cmd.Flags().StringVar(&flags.outcome, "outcome", "", "Filter by outcome (success, failure, abandoned, unknown)")
+	cmd.Flags().IntVarP(&flags.limit, "limit", "n", 10, "Maximum results to display")
+
+	return cmd
+}
+
+// recallStatsCmd returns the recall stats subcommand.
+func recallStatsCmd() *cobra.Command {
+	var flags statsFlags
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Report token usage and estimated cost",
+		Long: `Report token usage and estimated cost of sessions, grouped by day,
+project, model, branch or tool.
+
+Input, output, cache write and cache read tokens are counted apart.
+Costs are estimated from list prices per million tokens, matched to each
+session's primary model by name or longest name prefix. Add or override
+prices under "pricing" in .contextrc:
+
+  pricing:
+    claude-sonnet-4:
+      input: 3
+      output: 15
+      cache_write: 3.75
+      cache_read: 0.3
+
+The report ends with a comparison of the sessions that read the output
+of 'ctx agent' with the others: tokens per turn, cost per session and
+the share of sessions with tool errors.
+
+Sessions are read from ~/.claude/projects/, ~/.codex/sessions/ and any
+directories given with --dir.
+
+Examples:
+  ctx recall stats
+  ctx recall stats --by day --since 2026-01-01
+  ctx recall stats --by model --project api
+  ctx recall stats --by project --format csv > usage.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRecallStats(cmd, flags)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&flags.dirs, "dir", nil, "Additional directory of session files to scan (repeatable)")
+	cmd.Flags().StringVar(&flags.by, "by", string(stats.ByProject), "Group by: day, project, model, branch or tool")
+	cmd.Flags().StringVar(&flags.since, "since", "", "Only count sessions started from this date on (YYYY-MM-DD)")
+	cmd.Flags().StringVarP(&flags.project, "project", "p", "", "Filter by project name")
+	cmd.Flags().StringVarP(&flags.tool, "tool", "t", "", "Filter by tool")
+	cmd.Flags().StringVar(&flags.format, "format", formatText, "Output format: text, json or csv")
+
+	return cmd
+}
+
+// recallToolsCmd returns the recall tools subcommand.
+func recallToolsCmd() *cobra.Command {
+	var flags toolsFlags
+
+	cmd := &cobra.Command{
+		Use:   "tools",
+		Short: "Report tool calls, failing commands and file use",
+		Long: `Report how sessions used their tools: calls and error rates per tool,
+the shell commands that failed most, and the files read or edited most.
+
+Shell commands are normalized to the program and its subcommand, so
+"cd api && go test ./... -run TestRetry" counts as "go test". File
+paths inside a session's working directory are shown relative to it.
+Abandoned branches and subagent conversations are counted too.
+
+A command failing again and again is where agents flail: record what
+it takes to pass with 'ctx add learning'.
+
+Sessions are read from ~/.claude/projects/, ~/.codex/sessions/ and any
+directories given with --dir.
+
+Examples:
+  ctx recall tools
+  ctx recall tools --project api --since 2026-01-01 --until 2026-01-31
+  ctx recall tools --limit 5 --format json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRecallTools(cmd, flags)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&flags.dirs, "dir", nil, "Additional directory of session files to scan (repeatable)")
+	cmd.Flags().StringVar(&flags.since, "since", "", "Only count sessions started from this date on (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flags.until, "until", "", "Only count sessions started up to this date (YYYY-MM-DD)")
+	cmd.Flags().StringVarP(&flags.project, "project", "p", "", "Filter by project name")
+	cmd.Flags().StringVarP(&flags.tool, "tool", "t", "", "Filter by tool")
+	cmd.Flags().IntVarP(&flags.limit, "limit", "n", 10, "Maximum failing commands and files to list; 0 for all")
+	cmd.Flags().StringVar(&flags.format, "format", formatText, "Output format: text or json")
+
+	return cmd
+}
+
+// recallFileCmd returns the recall file subcommand.
+func recallFileCmd() *cobra.Command {
+	var flags fileFlags
+
+	cmd := &cobra.Command{
+		Use:   "file <path>",
+		Short: "List the sessions that touched a file",
+		Long: `List the sessions that read, edited or ran commands on a file, newest
+first: git blame for agent work.
+
+Files are found in the input of Read, Write, Edit, MultiEdit and
+apply_patch calls, and in the paths named by shell commands, resolved
+against the session's working directory. Each call is shown under the
+user prompt that led to it, with the lines an edit removed (-) and
+added (+), or the command that was run. Calls made by subagents or on
+abandoned branches are marked.
+
+Examples:
+  ctx recall file internal/drift/detector.go
+  ctx recall file --edits --limit 3 README.md`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRecallFile(cmd, args[0], flags)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&flags.dirs, "dir", nil, "Additional directory of session files to scan (repeatable)")
+	cmd.Flags().IntVarP(&flags.limit, "limit", "n", 10, "Maximum sessions to list; 0 for all")
+	cmd.Flags().BoolVar(&flags.edits, "edits", false, "Only list edits")
+
+	return cmd
+}
+
+// recallServeCmd returns the recall serve subcommand.
+func recallServeCmd() *cobra.Command {
+	var (
+		dirs []string
+		host string
+		port int
+		open bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Start a local web server for browsing sessions",
+		Long: `Start a local web server for browsing sessions in a browser.
+
+Sessions are read from ~/.claude/projects/, ~/.codex/sessions/ and any
+directories given with --dir. They are parsed once at startup; restart
+the server to pick up new sessions.
+
+Pages:
+  /                    Session list, filterable by project, branch and date
+  /session/<id>        Session with messages, thinking and tool calls
+
+JSON API:
+  /api/sessions        Session list; accepts the same filters as the
+                       index page (project, branch, days, date, q)
+  /api/session/<id>    Full session
+
+The server listens on 127.0.0.1 only unless --host is given.
+
+Examples:
+  ctx recall serve
+  ctx recall serve --open
+  ctx recall serve --dir ./sessions --port 9000`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRecallServe(cmd, dirs, host, port, open)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&dirs, "dir", nil, "Additional directory of session files to scan (repeatable)")
+	cmd.Flags().StringVar(&host, "host", "127.0.0.1", "Address to listen on")
+	cmd.Flags().IntVar(&port, "port", 8080, "Port to listen on")
+	cmd.Flags().BoolVar(&open, "open", false, "Open the session list in a browser")
+
+	return cmd
+}
+
+// recallReindexCmd returns the recall reindex subcommand.
+func recallReindexCmd() *cobra.Command {
+	var dirs []string
+
+	cmd := &cobra.Command{
+		Use:   "reindex",
+		Short: "Rebuild the session parse cache",
+		Long: `Parse all session files again and rebuild the parse cache.
+
+Listing sessions reads their metadata from a cache in the user cache
+directory (~/.cache/ctx/recall-sessions.json on Linux) and only parses
+files that are new or whose size or modification time changed. Messages
+are read from the session file when a session is shown. Reindex after
+upgrading a parser outside ctx, or if the cache seems stale.
+
+Sessions are read from ~/.claude/projects/, ~/.codex/sessions/, project
+roots and any directories given with --dir.
+
+Examples:
+  ctx recall reindex
+  ctx recall reindex --dir ./sessions`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRecallReindex(cmd, dirs)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&dirs, "dir", nil, "Additional directory of session files to scan (repeatable)")
+
+	return cmd
+}
+
+// recallImportCmd returns the recall import subcommand.
+func recallImportCmd() *cobra.Command {
+	var flags importFlags
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Stage decisions and learnings found in sessions",
+		Long: `Find decisions and learnings in AI sessions and stage them for review.
+
+Assistant messages are scanned for phrases such as "decided to",
+"going with", "learned that" and "gotcha:". New candidates are added to
+.context/import/candidates.md; candidates already recorded in
+DECISIONS.md or LEARNINGS.md, or already staged, are skipped.
+
+Review the staging file: mark a candidate [x] to import it or [-] to
+reject it, editing its text if needed. Then run with --apply to add the
+accepted candidates the way 'ctx add' does, with a Source field naming
+the session and time they were found at. Rejected candidates stay in
+the staging file so that they are not staged again.
+
+Sessions are read from ~/.claude/projects/, ~/.codex/sessions/ and any
+directories given with --dir.
+
+Examples:
+  ctx recall import
+  ctx recall import --project ctx --since 2026-01-15
+  ctx recall import --dir ./sessions --dry-run
+  ctx recall import --apply`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRecallImport(cmd, flags)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&flags.dirs, "dir", nil, "Additional directory of session files to scan (repeatable)")
+	cmd.Flags().StringVarP(&flags.project, "project", "p", "", "Filter by project name")
+	cmd.Flags().StringVar(&flags.since, "since", "", "Only scan messages from this date on (YYYY-MM-DD)")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Show what would be staged or imported without writing")
+	cmd.Flags().BoolVar(&flags.apply, "apply", false, "Import the accepted candidates from the staging file")
 
 	return cmd
 }
@@ -0,0 +1,59 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// runRecallReindex handles the recall reindex command.
+//
+// The parse cache is discarded and every session file is parsed again,
+// so that sessions are listed from a fresh cache.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - dirs: Additional directories to scan for session files
+//
+// Returns:
+//   - error: Non-nil if a directory does not exist or the cache cannot
+//     be read or written
+func runRecallReindex(cmd *cobra.Command, dirs []string) error {
+	if err := checkDirs(dirs); err != nil {
+		return err
+	}
+
+	path, err := parser.DefaultCachePath()
+	if err != nil {
+		return fmt.Errorf("failed to locate the parse cache: %w", err)
+	}
+	cache, err := parser.OpenCache(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the parse cache: %w", err)
+	}
+
+	start := time.Now()
+	cache.Clear()
+	_, stats := cache.FindSessions(dirs...)
+	if err := cache.Save(); err != nil {
+		return fmt.Errorf("failed to write the parse cache: %w", err)
+	}
+	warnParseErrors(cmd, stats.Errors)
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cmd.Printf("%s Parsed %s (%s) in %s\n", green("✓"),
+		plural(stats.Files, "session file"), plural(stats.Sessions, "session"),
+		time.Since(start).Round(time.Millisecond))
+	cmd.Printf("  Cache: %s\n", path)
+	return nil
+}
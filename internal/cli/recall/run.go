@@ -7,31 +7,30 @@
 package recall
 
 import (
-	"encoding/json"
 	"fmt"
-	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/recall/export"
 	"github.com/ActiveMemory/ctx/internal/recall/parser"
 )
 
-// lineNumberPattern matches Claude Code's line number prefixes like "     1→"
-var lineNumberPattern = regexp.MustCompile(`(?m)^\s*\d+→`)
-
 // runRecallList handles the recall list command.
 func runRecallList(cmd *cobra.Command, limit int, project, tool string) error {
-	sessions, err := parser.FindSessions()
-	if err != nil {
-		return fmt.Errorf("failed to find sessions: %w", err)
+	if tools := parser.RegisteredTools(); tool != "" && !slices.Contains(tools, tool) {
+		return fmt.Errorf("unknown tool %q: must be one of %s", tool, strings.Join(tools, ", "))
 	}
 
+	sessions := findSessions(cmd)
+
 	if len(sessions) == 0 {
 		fmt.Fprintln(cmd.OutOrStdout(), "No sessions found.")
 		fmt.Fprintln(cmd.OutOrStdout(), "")
-		fmt.Fprintln(cmd.OutOrStdout(), "Sessions are stored in ~/.claude/projects/")
+		fmt.Fprintln(cmd.OutOrStdout(), "Sessions are stored in ~/.claude/projects/ and ~/.codex/sessions/")
 		return nil
 	}
 
@@ -72,7 +71,7 @@ func runRecallList(cmd *cobra.Command, limit int, project, tool string) error {
 	for i, s := range filtered {
 		// Session number and slug
 		header.Fprintf(cmd.OutOrStdout(), "%2d. %s", i+1, s.Slug)
-		dim.Fprintf(cmd.OutOrStdout(), " (%s...)\n", s.ID[:8])
+		dim.Fprintf(cmd.OutOrStdout(), " (%s...)\n", shortID(s.ID, 8))
 
 		// Details
 		fmt.Fprintf(cmd.OutOrStdout(), "    Project: %s", s.Project)
@@ -113,44 +112,20 @@ func runRecallList(cmd *cobra.Command, limit int, project, tool string) error {
 }
 
 // runRecallShow handles the recall show command.
-func runRecallShow(cmd *cobra.Command, args []string, latest, full bool) error {
-	sessions, err := parser.FindSessions()
-	if err != nil {
-		return fmt.Errorf("failed to find sessions: %w", err)
-	}
+func runRecallShow(cmd *cobra.Command, args []string, latest, full, tree bool) error {
+	sessions := findSessions(cmd)
 
 	if len(sessions) == 0 {
 		return fmt.Errorf("no sessions found")
 	}
 
-	var session *parser.Session
+	session, err := findSession(cmd, sessions, args, latest)
+	if err != nil {
+		return err
+	}
 
-	if latest {
-		session = sessions[0]
-	} else if len(args) == 0 {
-		return fmt.Errorf("please provide a session ID or use --latest")
-	} else {
-		query := strings.ToLower(args[0])
-		var matches []*parser.Session
-		for _, s := range sessions {
-			if strings.HasPrefix(strings.ToLower(s.ID), query) ||
-				strings.Contains(strings.ToLower(s.Slug), query) {
-				matches = append(matches, s)
-			}
-		}
-		if len(matches) == 0 {
-			return fmt.Errorf("session not found: %s", args[0])
-		}
-		if len(matches) > 1 {
-			fmt.Fprintf(cmd.ErrOrStderr(), "Multiple sessions match '%s':\n", args[0])
-			for _, m := range matches {
-				fmt.Fprintf(cmd.ErrOrStderr(), "  %s (%s) - %s\n",
-					m.Slug, m.ID[:8], m.StartTime.Format("2006-01-02 15:04"))
-			}
-			fmt.Fprintf(cmd.ErrOrStderr(), "\nUse a more specific ID (e.g., ctx recall show %s)\n", matches[0].ID[:12])
-			return fmt.Errorf("ambiguous query")
-		}
-		session = matches[0]
+	if err := session.Load(); err != nil {
+		return fmt.Errorf("failed to read session %s: %w", session.ID, err)
 	}
 
 	// Print session details
@@ -199,7 +174,12 @@ func runRecallShow(cmd *cobra.Command, args []string, latest, full bool) error {
 	}
 
 	// Messages
-	if full {
+	if tree {
+		header.Fprintf(cmd.OutOrStdout(), "## Conversation Tree\n")
+		fmt.Fprintln(cmd.OutOrStdout())
+		printSessionTree(cmd.OutOrStdout(), session)
+		fmt.Fprintln(cmd.OutOrStdout())
+	} else if full {
 		header.Fprintf(cmd.OutOrStdout(), "## Conversation\n")
 		fmt.Fprintln(cmd.OutOrStdout())
 
@@ -223,7 +203,7 @@ func runRecallShow(cmd *cobra.Command, args []string, latest, full bool) error {
 
 			// Show tool uses with details
 			for _, t := range msg.ToolUses {
-				toolInfo := formatToolUse(t)
+				toolInfo := export.ToolSummary(t)
 				fmt.Fprintf(cmd.OutOrStdout(), "🔧 **%s**\n", toolInfo)
 			}
 
@@ -234,7 +214,7 @@ func runRecallShow(cmd *cobra.Command, args []string, latest, full bool) error {
 				}
 				if tr.Content != "" {
 					// Strip line number prefixes and show content
-					content := stripLineNumbers(tr.Content)
+					content := export.StripLineNumbers(tr.Content)
 					fmt.Fprintf(cmd.OutOrStdout(), "```\n%s\n```\n", content)
 				}
 			}
@@ -270,6 +250,93 @@ func runRecallShow(cmd *cobra.Command, args []string, latest, full bool) error {
 	return nil
 }
 
+// findSession returns the session named by a command's argument: the
+// one whose ID starts with it or whose slug contains it, or the latest
+// session. Sessions matching an ambiguous argument are listed on stderr.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - sessions: Sessions to search, newest first
+//   - args: Command arguments, holding the session ID or slug
+//   - latest: Return the latest session
+//
+// Returns:
+//   - *parser.Session: The session
+//   - error: Non-nil if no argument is given, or no or several sessions
+//     match it
+func findSession(
+	cmd *cobra.Command, sessions []*parser.Session, args []string, latest bool,
+) (*parser.Session, error) {
+	if latest {
+		return sessions[0], nil
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("please provide a session ID or use --latest")
+	}
+
+	query := strings.ToLower(args[0])
+	var matches []*parser.Session
+	for _, s := range sessions {
+		if strings.HasPrefix(strings.ToLower(s.ID), query) ||
+			strings.Contains(strings.ToLower(s.Slug), query) {
+			matches = append(matches, s)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("session not found: %s", args[0])
+	}
+	if len(matches) > 1 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Multiple sessions match '%s':\n", args[0])
+		for _, m := range matches {
+			fmt.Fprintf(cmd.ErrOrStderr(), "  %s (%s) - %s\n",
+				m.Slug, shortID(m.ID, 8), m.StartTime.Format("2006-01-02 15:04"))
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "\nUse a more specific ID (e.g., %s %s)\n",
+			cmd.CommandPath(), shortID(matches[0].ID, 12))
+		return nil, fmt.Errorf("ambiguous query")
+	}
+	return matches[0], nil
+}
+
+// findSessions finds sessions like parser.FindSessions, warning about
+// the files that failed to parse and about parsers in .contextrc that
+// are not run for want of CTX_TRUST_PARSERS.
+//
+// Parameters:
+//   - cmd: Cobra command for warnings
+//   - dirs: Additional directories of session files to scan
+//
+// Returns:
+//   - []*parser.Session: Sessions found, newest first
+func findSessions(cmd *cobra.Command, dirs ...string) []*parser.Session {
+	if len(config.GetRC().Parsers) > 0 && !config.ParsersTrusted() {
+		color.New(color.FgYellow).Fprintln(cmd.ErrOrStderr(),
+			"○ Ignoring the session parsers in .contextrc; set CTX_TRUST_PARSERS=1 to run them")
+	}
+	sessions, errs := parser.FindSessionsWithErrors(dirs...)
+	warnParseErrors(cmd, errs)
+	return sessions
+}
+
+// warnParseErrors prints a warning on stderr for each session file that
+// failed to parse.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - errs: Parse errors, each naming its file
+func warnParseErrors(cmd *cobra.Command, errs []error) {
+	yellow := color.New(color.FgYellow)
+	for _, err := range errs {
+		yellow.Fprintf(cmd.ErrOrStderr(), "⚠ Skipped %v\n", err)
+	}
+}
+
+// shortID returns the first n bytes of a session ID, or the whole ID if
+// it is shorter. External parsers may return IDs of any length.
+func shortID(id string, n int) string {
+	return id[:min(n, len(id))]
+}
+
 // formatDuration formats a duration in a human-readable way.
 func formatDuration(d interface{ Minutes() float64 }) string {
 	mins := d.Minutes()
@@ -297,64 +364,3 @@ func formatTokens(tokens int) string {
 	}
 	return fmt.Sprintf("%.1fM", float64(tokens)/1000000)
 }
-
-// stripLineNumbers removes Claude Code's line number prefixes from content.
-func stripLineNumbers(content string) string {
-	return lineNumberPattern.ReplaceAllString(content, "")
-}
-
-// formatToolUse formats a tool invocation with its key parameters.
-func formatToolUse(t parser.ToolUse) string {
-	// Parse the JSON input to extract meaningful parameters
-	var input map[string]interface{}
-	if err := json.Unmarshal([]byte(t.Input), &input); err != nil {
-		return t.Name
-	}
-
-	// Extract the most relevant parameter based on tool type
-	switch t.Name {
-	case "Read":
-		if path, ok := input["file_path"].(string); ok {
-			return fmt.Sprintf("Read: %s", path)
-		}
-	case "Write":
-		if path, ok := input["file_path"].(string); ok {
-			return fmt.Sprintf("Write: %s", path)
-		}
-	case "Edit":
-		if path, ok := input["file_path"].(string); ok {
-			return fmt.Sprintf("Edit: %s", path)
-		}
-	case "Bash":
-		if cmd, ok := input["command"].(string); ok {
-			// Truncate long commands for readability
-			if len(cmd) > 100 {
-				cmd = cmd[:100] + "..."
-			}
-			return fmt.Sprintf("Bash: %s", cmd)
-		}
-	case "Grep":
-		if pattern, ok := input["pattern"].(string); ok {
-			return fmt.Sprintf("Grep: %s", pattern)
-		}
-	case "Glob":
-		if pattern, ok := input["pattern"].(string); ok {
-			return fmt.Sprintf("Glob: %s", pattern)
-		}
-	case "WebFetch":
-		if url, ok := input["url"].(string); ok {
-			return fmt.Sprintf("WebFetch: %s", url)
-		}
-	case "WebSearch":
-		if query, ok := input["query"].(string); ok {
-			return fmt.Sprintf("WebSearch: %s", query)
-		}
-	case "Task":
-		if desc, ok := input["description"].(string); ok {
-			return fmt.Sprintf("Task: %s", desc)
-		}
-	}
-
-	// Default: just show the tool name
-	return t.Name
-}
@@ -0,0 +1,69 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// TestRecallShortExternalIDs tests listing and looking up sessions whose
+// external parser returns IDs shorter than the displayed prefix.
+func TestRecallShortExternalIDs(t *testing.T) {
+	setupRecallHome(t)
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("CTX_TRUST_PARSERS", "1")
+
+	script := filepath.Join(dir, "acme-parse")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\ncat \"$1\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	sessions := filepath.Join(dir, "sessions")
+	if err := os.Mkdir(sessions, 0755); err != nil {
+		t.Fatal(err)
+	}
+	rc := "parsers:\n" +
+		"  - tool: acme\n" +
+		"    command: " + script + "\n" +
+		"    glob: \"*.acme.json\"\n" +
+		"    dir: " + sessions + "\n"
+	if err := os.WriteFile(filepath.Join(dir, ".contextrc"), []byte(rc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config.ResetRC()
+	t.Cleanup(config.ResetRC)
+
+	for _, id := range []string{"x1", "x2"} {
+		session := `{"id": "` + id + `", "cwd": "/home/test/billing", "messages": [` +
+			`{"role": "user", "text": "Fix the rounding", "timestamp": "2026-01-20T10:00:00Z"}]}`
+		path := filepath.Join(sessions, id+".acme.json")
+		if err := os.WriteFile(path, []byte(session), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := runRecall(t, "list")
+	if err != nil {
+		t.Fatalf("list failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "(x1...)") || !strings.Contains(out, "(x2...)") {
+		t.Errorf("list output missing short IDs:\n%s", out)
+	}
+
+	out, err = runRecall(t, "show", "x")
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("show x: got %v, want ambiguous query error", err)
+	}
+	if !strings.Contains(out, "x1") || !strings.Contains(out, "x2") {
+		t.Errorf("ambiguous matches not listed:\n%s", out)
+	}
+}
@@ -0,0 +1,151 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/recall/search"
+)
+
+// chunkTypes are the chunk types accepted by --type.
+var chunkTypes = []string{search.TypeTurnPair, search.TypeThinking, search.TypeTool}
+
+// runRecallSearch handles the recall search command.
+//
+// The index is brought up to date before searching: new and modified
+// session files are parsed and added, and removed ones are dropped.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - args: Query words
+//   - dirs: Additional directories to index
+//   - project: Only show chunks of projects containing this text
+//   - chunkType: Only show chunks of this type; empty for all
+//   - limit: Maximum number of results
+//
+// Returns:
+//   - error: Non-nil if an argument is invalid or the index cannot be
+//     read, updated or written
+func runRecallSearch(
+	cmd *cobra.Command, args, dirs []string, project, chunkType string,
+	limit int,
+) error {
+	query := strings.Join(args, " ")
+	if len(search.Tokenize(query)) == 0 {
+		return fmt.Errorf("query %q has no searchable words", query)
+	}
+	if chunkType != "" && !slices.Contains(chunkTypes, chunkType) {
+		return fmt.Errorf(
+			"unknown chunk type %q. Valid types: %s",
+			chunkType, strings.Join(chunkTypes, ", "),
+		)
+	}
+	if err := checkDirs(dirs); err != nil {
+		return err
+	}
+
+	idx, stats, err := updateIndex(dirs)
+	if err != nil {
+		return err
+	}
+	warnParseErrors(cmd, stats.Errors)
+	if stats.Changed() {
+		color.New(color.FgHiBlack).Fprintf(cmd.OutOrStdout(),
+			"Indexed %d new, %d changed, %d removed files (%s)\n",
+			stats.Added, stats.Updated, stats.Removed, plural(idx.Len(), "chunk"))
+	}
+
+	results := idx.Search(query, search.Options{
+		Project: project,
+		Type:    chunkType,
+		Limit:   limit,
+	})
+	if len(results) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No results for %q.\n", query)
+		return nil
+	}
+
+	header := color.New(color.Bold)
+	dim := color.New(color.FgHiBlack)
+	fmt.Fprintf(cmd.OutOrStdout(), "Top %s for %q\n\n", plural(len(results), "result"), query)
+	for i, r := range results {
+		c := r.Chunk
+		name := c.Slug
+		if name == "" {
+			name = c.SessionID
+		}
+		header.Fprintf(cmd.OutOrStdout(), "%2d. %s", i+1, name)
+		fmt.Fprintf(cmd.OutOrStdout(), "  %s", strings.ReplaceAll(c.Type, "_", " "))
+		if c.Project != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s", c.Project)
+		}
+		dim.Fprintf(cmd.OutOrStdout(), "  (%.2f)\n", r.Score)
+		fmt.Fprintf(cmd.OutOrStdout(), "    %s\n", r.Snippet)
+		dim.Fprintf(cmd.OutOrStdout(), "    %s @ %s  /session/%s#msg-%d\n",
+			c.SessionID, c.Timestamp.Local().Format(time.RFC3339), c.SessionID, c.Message)
+		fmt.Fprintln(cmd.OutOrStdout())
+	}
+
+	return nil
+}
+
+// updateIndex opens the search index and brings it up to date with the
+// session files, saving it if it changed.
+//
+// Parameters:
+//   - dirs: Additional directories of session files to index
+//
+// Returns:
+//   - *search.Index: Current index
+//   - search.UpdateStats: Files added, updated and removed
+//   - error: Non-nil if the index cannot be read, updated or written
+func updateIndex(dirs []string) (*search.Index, search.UpdateStats, error) {
+	var stats search.UpdateStats
+	path, err := search.DefaultPath()
+	if err != nil {
+		return nil, stats, fmt.Errorf("failed to locate the search index: %w", err)
+	}
+	idx, err := search.Open(path)
+	if err != nil {
+		return nil, stats, fmt.Errorf("failed to read the search index: %w", err)
+	}
+	stats, err = idx.Update(search.DefaultRoots(dirs...))
+	if err != nil {
+		return nil, stats, fmt.Errorf("failed to update the search index: %w", err)
+	}
+	if stats.Changed() {
+		if err := idx.Save(); err != nil {
+			return nil, stats, fmt.Errorf("failed to write the search index: %w", err)
+		}
+	}
+	return idx, stats, nil
+}
+
+// checkDirs verifies that the session directories given as arguments
+// exist.
+//
+// Parameters:
+//   - dirs: Directories to check
+//
+// Returns:
+//   - error: Non-nil naming the first path that is not a directory
+func checkDirs(dirs []string) error {
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return fmt.Errorf("not a directory: %s", dir)
+		}
+	}
+	return nil
+}
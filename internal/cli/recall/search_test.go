@@ -0,0 +1,91 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/recall/search"
+)
+
+// TestRecallSearch tests searching sessions from the command line.
+func TestRecallSearch(t *testing.T) {
+	setupRecallHome(t)
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "s.jsonl"), []byte(importSessions), 0644); err != nil {
+		t.Fatalf("failed to write sessions: %v", err)
+	}
+
+	run := func(args ...string) (string, error) {
+		return runRecall(t, append([]string{"search", "--dir", dir}, args...)...)
+	}
+
+	out, err := run("schema", "migrations")
+	if err != nil {
+		t.Fatalf("search failed: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"Indexed 1 new", "Top 1 result", "quiet-running-hopper",
+		"goose for schema migrations", "/session/sess-import#msg-0",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	// The second search uses the saved index
+	out, err = run("sqlite", "--type", "thinking")
+	if err != nil {
+		t.Fatalf("search failed: %v\n%s", err, out)
+	}
+	if strings.Contains(out, "Indexed") || !strings.Contains(out, "sqlite driver") {
+		t.Errorf("second search output:\n%s", out)
+	}
+
+	if _, err := run("sqlite", "--type", "code"); err == nil {
+		t.Error("unknown --type was accepted")
+	}
+}
+
+// TestRenderHistory tests that the history section fits its budget,
+// dropping recent sessions before related chunks.
+func TestRenderHistory(t *testing.T) {
+	at := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+	res := &search.AutoResult{
+		Recent: []search.Result{{
+			Chunk:   &search.Chunk{Type: search.TypeTurnPair, Slug: "old-session", Timestamp: at},
+			Snippet: strings.Repeat("a long opening question ", 10),
+		}},
+		Related: []search.Result{{
+			Chunk:   &search.Chunk{Type: search.TypeThinking, SessionID: "0a1b2c", Timestamp: at},
+			Snippet: "the retry loop ignored the context deadline",
+		}},
+	}
+
+	out := renderHistory(res, 1000)
+	for _, want := range []string{
+		"## Relevant History\n\n### Recent",
+		"- 2026-01-20 old-session: a long opening question",
+		"...\n",
+		"### Related\n- 2026-01-20 0a1b2c (thinking): the retry loop ignored the context deadline\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	out = renderHistory(res, 40)
+	if strings.Contains(out, "old-session") || !strings.Contains(out, "0a1b2c") ||
+		!strings.Contains(out, "_1 item omitted to fit the budget._") {
+		t.Errorf("budgeted output:\n%s", out)
+	}
+}
@@ -0,0 +1,118 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// runRecallServe handles the recall serve command.
+//
+// Sessions are parsed once at startup from the default session
+// directories and the given directories, then served until Ctrl-C.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - dirs: Additional directories to scan for session files
+//   - host: Address to listen on
+//   - port: Port to listen on; 0 picks a free port
+//   - open: If true, open the index page in the default browser
+//
+// Returns:
+//   - error: Non-nil if a directory is invalid or the server fails
+func runRecallServe(
+	cmd *cobra.Command, dirs []string, host string, port int, open bool,
+) error {
+	if err := checkDirs(dirs); err != nil {
+		return err
+	}
+
+	sessions := findSessions(cmd, dirs...)
+	srv, err := newServer(sessions)
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	url := "http://" + ln.Addr().String()
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cmd.Printf("%s Serving %d sessions at %s\n", green("✓"), len(sessions), url)
+	cmd.Println("Press Ctrl-C to stop")
+
+	if open {
+		if err := openBrowser(url); err != nil {
+			cmd.Printf("%s Could not open a browser: %v\n",
+				color.YellowString("○"), err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(
+		cmd.Context(), os.Interrupt, syscall.SIGTERM,
+	)
+	defer stop()
+
+	httpServer := &http.Server{
+		Handler:           srv.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errCh := make(chan error, 1)
+	go func() { errCh <- httpServer.Serve(ln) }()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil &&
+		!errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to stop server: %w", err)
+	}
+	cmd.Println()
+	cmd.Println("Server stopped")
+	return nil
+}
+
+// openBrowser opens a URL in the default browser.
+//
+// Parameters:
+//   - url: URL to open
+//
+// Returns:
+//   - error: Non-nil if the browser cannot be started
+func openBrowser(url string) error {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		c = exec.Command("open", url)
+	case "windows":
+		c = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		c = exec.Command("xdg-open", url)
+	}
+	return c.Start()
+}
@@ -0,0 +1,222 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// testSessions is a Claude Code session file with two sessions in
+// different projects and branches.
+const testSessions = `{"uuid":"m1","sessionId":"sess-alpha","slug":"brave-sailing-mercury","type":"user","timestamp":"2026-01-20T10:00:00Z","cwd":"/home/test/alpha","gitBranch":"main","version":"2.1.0","message":{"role":"user","content":[{"type":"text","text":"List the <files>"}]}}
+{"uuid":"m2","parentUuid":"m1","sessionId":"sess-alpha","slug":"brave-sailing-mercury","type":"assistant","timestamp":"2026-01-20T10:00:10Z","cwd":"/home/test/alpha","gitBranch":"main","version":"2.1.0","message":{"role":"assistant","content":[{"type":"thinking","thinking":"Use ls"},{"type":"text","text":"Listing:\n` + "```" + `\nls -la\n` + "```" + `"},{"type":"tool_use","id":"tool1","name":"Bash","input":{"command":"ls -la"}}],"usage":{"input_tokens":1200,"output_tokens":34}}}
+{"uuid":"m3","parentUuid":"m2","sessionId":"sess-alpha","slug":"brave-sailing-mercury","type":"user","timestamp":"2026-01-20T10:00:11Z","cwd":"/home/test/alpha","gitBranch":"main","version":"2.1.0","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"tool1","content":"a.txt\nb.txt","is_error":true}]}}
+{"uuid":"b1","sessionId":"sess-beta","slug":"async-roaming-allen","type":"user","timestamp":"2026-01-25T09:00:00Z","cwd":"/home/test/beta","gitBranch":"feature/auth","version":"2.1.0","message":{"role":"user","content":[{"type":"text","text":"Implement JWT validation"}]}}
+`
+
+// newTestServer returns a server for the test sessions, with the clock
+// set to 2026-01-26.
+func newTestServer(t *testing.T) http.Handler {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sessions.jsonl")
+	if err := os.WriteFile(path, []byte(testSessions), 0644); err != nil {
+		t.Fatalf("failed to write sessions: %v", err)
+	}
+	sessions, err := parser.ScanDirectory(dir)
+	if err != nil {
+		t.Fatalf("failed to parse sessions: %v", err)
+	}
+
+	srv, err := newServer(sessions)
+	if err != nil {
+		t.Fatalf("newServer failed: %v", err)
+	}
+	srv.now = func() time.Time {
+		return time.Date(2026, 1, 26, 0, 0, 0, 0, time.UTC)
+	}
+	return srv.handler()
+}
+
+// get performs a GET request against a handler.
+func get(t *testing.T, h http.Handler, url string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+	return rec
+}
+
+// TestServeIndex tests the session list page and its filters.
+func TestServeIndex(t *testing.T) {
+	h := newTestServer(t)
+
+	tests := []struct {
+		url    string
+		want   []string
+		absent []string
+	}{
+		{
+			url:  "/",
+			want: []string{"brave-sailing-mercury", "async-roaming-allen", "2 sessions", "2 projects"},
+		},
+		{
+			url:    "/?project=alpha",
+			want:   []string{"brave-sailing-mercury", "1 session •"},
+			absent: []string{"/session/sess-beta"},
+		},
+		{
+			url:    "/?branch=feature%2Fauth",
+			want:   []string{"async-roaming-allen"},
+			absent: []string{"/session/sess-alpha"},
+		},
+		{
+			url:    "/?days=3",
+			want:   []string{"async-roaming-allen"},
+			absent: []string{"/session/sess-alpha"},
+		},
+		{
+			url:    "/?date=2026-01-20",
+			want:   []string{"brave-sailing-mercury"},
+			absent: []string{"/session/sess-beta"},
+		},
+		{
+			url:    "/?q=jwt",
+			want:   []string{"async-roaming-allen"},
+			absent: []string{"/session/sess-alpha"},
+		},
+		{
+			url:  "/?project=none",
+			want: []string{"No sessions match the filters."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			rec := get(t, h, tt.url)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200", rec.Code)
+			}
+			body := rec.Body.String()
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("page missing %q", want)
+				}
+			}
+			for _, absent := range tt.absent {
+				if strings.Contains(body, absent) {
+					t.Errorf("page should not contain %q", absent)
+				}
+			}
+		})
+	}
+}
+
+// TestServeSession tests the session detail page.
+func TestServeSession(t *testing.T) {
+	h := newTestServer(t)
+
+	rec := get(t, h, "/session/sess-alpha")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		"List the &lt;files&gt;",
+		"<summary>Thinking</summary>",
+		"<pre><code>ls -la</code></pre>",
+		"🔧 Bash: ls -la",
+		"❌ Error: Bash",
+		"a.txt\nb.txt",
+		"1,200",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("session page missing %q", want)
+		}
+	}
+
+	if rec := get(t, h, "/session/brave-sailing-mercury"); rec.Code != http.StatusOK {
+		t.Errorf("lookup by slug: status = %d, want 200", rec.Code)
+	}
+	if rec := get(t, h, "/session/sess-"); rec.Code != http.StatusNotFound {
+		t.Errorf("ambiguous prefix: status = %d, want 404", rec.Code)
+	}
+	if rec := get(t, h, "/nope"); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown page: status = %d, want 404", rec.Code)
+	}
+}
+
+// TestServeLookup tests that an exact ID wins over prefix and slug
+// matches listed before it.
+func TestServeLookup(t *testing.T) {
+	srv, err := newServer([]*parser.Session{
+		{ID: "abc123", Slug: "first"},
+		{ID: "abc456", Slug: "abc"},
+		{ID: "abc", Slug: "third"},
+	})
+	if err != nil {
+		t.Fatalf("newServer failed: %v", err)
+	}
+
+	if got := srv.lookup("abc"); got == nil || got.Slug != "third" {
+		t.Errorf("lookup(abc) = %+v, want the session with ID abc", got)
+	}
+	if got := srv.lookup("abc4"); got == nil || got.ID != "abc456" {
+		t.Errorf("lookup(abc4) = %+v, want abc456", got)
+	}
+	if got := srv.lookup("ab"); got != nil {
+		t.Errorf("lookup(ab) = %+v, want nil for an ambiguous prefix", got)
+	}
+}
+
+// TestServeAPI tests the JSON API.
+func TestServeAPI(t *testing.T) {
+	h := newTestServer(t)
+
+	rec := get(t, h, "/api/sessions?project=beta")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	var summaries []sessionSummary
+	if err := json.Unmarshal(rec.Body.Bytes(), &summaries); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(summaries) != 1 || summaries[0].ID != "sess-beta" ||
+		summaries[0].Branch != "feature/auth" {
+		t.Errorf("unexpected summaries: %+v", summaries)
+	}
+
+	rec = get(t, h, "/api/sessions?project=none")
+	if strings.TrimSpace(rec.Body.String()) != "[]" {
+		t.Errorf("empty list should be [], got %s", rec.Body.String())
+	}
+
+	rec = get(t, h, "/api/session/sess-alpha")
+	var session parser.Session
+	if err := json.Unmarshal(rec.Body.Bytes(), &session); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(session.Messages) != 3 || session.TotalTokensIn != 1200 {
+		t.Errorf("unexpected session: %d messages, %d tokens in",
+			len(session.Messages), session.TotalTokensIn)
+	}
+
+	rec = get(t, h, "/api/session/missing")
+	if rec.Code != http.StatusNotFound ||
+		rec.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("missing session: status %d, type %q",
+			rec.Code, rec.Header().Get("Content-Type"))
+	}
+}
@@ -0,0 +1,503 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/recall/export"
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+// previewLength is the length of the first-message preview in session
+// summaries.
+const previewLength = 120
+
+// sessionSummary is a session as listed on the index page and by
+// GET /api/sessions.
+type sessionSummary struct {
+	ID           string        `json:"id"`
+	Slug         string        `json:"slug,omitempty"`
+	Tool         string        `json:"tool"`
+	Project      string        `json:"project,omitempty"`
+	Branch       string        `json:"branch,omitempty"`
+	StartTime    time.Time     `json:"start_time"`
+	Duration     time.Duration `json:"duration"`
+	TurnCount    int           `json:"turn_count"`
+	TokensIn     int           `json:"tokens_in"`
+	TokensOut    int           `json:"tokens_out"`
+	Tokens       int           `json:"tokens"`
+	FirstMessage string        `json:"first_message,omitempty"`
+}
+
+// sessionFilter selects sessions by query parameters.
+//
+// Fields:
+//   - Project: Project name, matched exactly ("project")
+//   - Branch: Git branch, matched exactly ("branch")
+//   - Days: Only sessions started in the last n days; 0 for all ("days")
+//   - Date: Only sessions started on this day, YYYY-MM-DD ("date")
+//   - Query: Case-insensitive text in the slug, ID or first message ("q")
+type sessionFilter struct {
+	Project string
+	Branch  string
+	Days    int
+	Date    string
+	Query   string
+}
+
+// dayOption is an entry of the date range selector.
+type dayOption struct {
+	Days  int
+	Label string
+}
+
+// dayOptions are the date ranges offered on the index page.
+var dayOptions = []dayOption{
+	{0, "Any time"},
+	{1, "Last 24 hours"},
+	{7, "Last 7 days"},
+	{30, "Last 30 days"},
+	{90, "Last 90 days"},
+}
+
+// indexPage is the data of the index page.
+type indexPage struct {
+	Filter       sessionFilter
+	Filtered     bool
+	Sessions     []sessionSummary
+	Total        int
+	Tokens       int
+	ProjectCount int
+	Projects     []string
+	Branches     []string
+	DayOptions   []dayOption
+}
+
+// sessionPage is the data of a session detail page.
+type sessionPage struct {
+	Session  *parser.Session
+	Messages []messageView
+	Tools    []toolCount
+}
+
+// messageView is a message with its tool results matched to the tool
+// calls that produced them.
+type messageView struct {
+	parser.Message
+	Index       int
+	Results     []resultView
+	ResultsOnly bool
+}
+
+// resultView is a tool result with the name of its tool call.
+type resultView struct {
+	parser.ToolResult
+	Name string
+}
+
+// toolCount is the number of calls of a tool in a session.
+type toolCount struct {
+	Name  string
+	Count int
+}
+
+// server serves a fixed set of sessions over HTTP.
+type server struct {
+	sessions []*parser.Session
+	index    *template.Template
+	detail   *template.Template
+	now      func() time.Time
+}
+
+// newServer prepares a server for the given sessions, which must be
+// sorted newest first.
+//
+// Parameters:
+//   - sessions: Sessions to serve
+//
+// Returns:
+//   - *server: Server ready to handle requests
+//   - error: Non-nil if the embedded templates cannot be parsed
+func newServer(sessions []*parser.Session) (*server, error) {
+	funcs := template.FuncMap{
+		"tokens":           formatTokens,
+		"duration":         formatDuration,
+		"number":           formatNumber,
+		"toolSummary":      export.ToolSummary,
+		"stripLineNumbers": export.StripLineNumbers,
+		"prettyJSON":       export.PrettyJSON,
+		"text":             export.TextHTML,
+		"plural":           plural,
+	}
+	parse := func(page string) (*template.Template, error) {
+		return template.New("").Funcs(funcs).ParseFS(
+			templateFS, "templates/layout.html", "templates/"+page,
+		)
+	}
+
+	index, err := parse("index.html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse templates: %w", err)
+	}
+	detail, err := parse("session.html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse templates: %w", err)
+	}
+
+	return &server{
+		sessions: sessions,
+		index:    index,
+		detail:   detail,
+		now:      time.Now,
+	}, nil
+}
+
+// handler returns the HTTP handler serving the pages and the JSON API.
+//
+// Returns:
+//   - http.Handler: Handler for GET /, /session/{id}, /api/sessions and
+//     /api/session/{id}
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+	mux.HandleFunc("GET /session/{id}", s.handleSession)
+	mux.HandleFunc("GET /api/sessions", s.handleAPISessions)
+	mux.HandleFunc("GET /api/session/{id}", s.handleAPISession)
+	return mux
+}
+
+// handleIndex renders the session list.
+func (s *server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	f := parseFilter(r)
+	page := indexPage{
+		Filter:     f,
+		Filtered:   f != sessionFilter{},
+		Total:      len(s.sessions),
+		DayOptions: dayOptions,
+	}
+
+	projects := make(map[string]bool)
+	branches := make(map[string]bool)
+	for _, sess := range s.sessions {
+		if sess.Project != "" {
+			projects[sess.Project] = true
+		}
+		if sess.GitBranch != "" {
+			branches[sess.GitBranch] = true
+		}
+	}
+	page.Projects = sortedKeys(projects)
+	page.Branches = sortedKeys(branches)
+
+	shown := make(map[string]bool)
+	for _, sess := range s.filter(f) {
+		summary := summarize(sess)
+		page.Sessions = append(page.Sessions, summary)
+		page.Tokens += summary.Tokens
+		shown[sess.Project] = true
+	}
+	page.ProjectCount = len(shown)
+
+	s.render(w, s.index, page)
+}
+
+// handleSession renders a session with all its messages.
+func (s *server) handleSession(w http.ResponseWriter, r *http.Request) {
+	sess := s.lookup(r.PathValue("id"))
+	if sess == nil {
+		http.NotFound(w, r)
+		return
+	}
+	full, err := s.load(sess)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.render(w, s.detail, newSessionPage(full))
+}
+
+// handleAPISessions writes the filtered session list as JSON.
+func (s *server) handleAPISessions(w http.ResponseWriter, r *http.Request) {
+	summaries := []sessionSummary{}
+	for _, sess := range s.filter(parseFilter(r)) {
+		summaries = append(summaries, summarize(sess))
+	}
+	writeJSON(w, http.StatusOK, summaries)
+}
+
+// handleAPISession writes a full session as JSON.
+func (s *server) handleAPISession(w http.ResponseWriter, r *http.Request) {
+	sess := s.lookup(r.PathValue("id"))
+	if sess == nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{
+			"error": "session not found",
+		})
+		return
+	}
+	full, err := s.load(sess)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+	writeJSON(w, http.StatusOK, full)
+}
+
+// render executes a page template, reporting failures as server errors.
+func (s *server) render(w http.ResponseWriter, t *template.Template, data any) {
+	var sb strings.Builder
+	if err := t.ExecuteTemplate(&sb, "layout", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(sb.String()))
+}
+
+// lookup finds a session by ID, unique ID prefix, or slug.
+//
+// An exact ID wins over sessions whose ID starts with it or whose slug
+// equals it.
+//
+// Parameters:
+//   - id: Session ID, ID prefix, or slug
+//
+// Returns:
+//   - *parser.Session: Matching session; nil if none or several match
+func (s *server) lookup(id string) *parser.Session {
+	for _, sess := range s.sessions {
+		if sess.ID == id {
+			return sess
+		}
+	}
+
+	var match *parser.Session
+	for _, sess := range s.sessions {
+		if strings.HasPrefix(sess.ID, id) || sess.Slug == id {
+			if match != nil {
+				return nil
+			}
+			match = sess
+		}
+	}
+	return match
+}
+
+// load returns a session with its messages.
+//
+// Sessions listed from the parse cache are loaded into a copy, so that
+// the shared list is never written while other requests read it.
+//
+// Parameters:
+//   - sess: Session to load
+//
+// Returns:
+//   - *parser.Session: Session with its messages
+//   - error: Non-nil if the session file cannot be parsed again
+func (s *server) load(sess *parser.Session) (*parser.Session, error) {
+	full := *sess
+	if err := full.Load(); err != nil {
+		return nil, err
+	}
+	return &full, nil
+}
+
+// filter returns the sessions matching a filter, newest first.
+//
+// Parameters:
+//   - f: Filter to apply
+//
+// Returns:
+//   - []*parser.Session: Matching sessions
+func (s *server) filter(f sessionFilter) []*parser.Session {
+	var cutoff time.Time
+	if f.Days > 0 {
+		cutoff = s.now().AddDate(0, 0, -f.Days)
+	}
+	query := strings.ToLower(f.Query)
+
+	var matches []*parser.Session
+	for _, sess := range s.sessions {
+		switch {
+		case f.Project != "" && sess.Project != f.Project:
+		case f.Branch != "" && sess.GitBranch != f.Branch:
+		case !cutoff.IsZero() && sess.StartTime.Before(cutoff):
+		case f.Date != "" && sess.StartTime.Local().Format("2006-01-02") != f.Date:
+		case query != "" &&
+			!strings.Contains(strings.ToLower(sess.Slug), query) &&
+			!strings.HasPrefix(strings.ToLower(sess.ID), query) &&
+			!strings.Contains(strings.ToLower(sess.FirstUserMsg), query):
+		default:
+			matches = append(matches, sess)
+		}
+	}
+	return matches
+}
+
+// parseFilter reads a session filter from query parameters. Invalid
+// values are ignored.
+//
+// Parameters:
+//   - r: Request to read
+//
+// Returns:
+//   - sessionFilter: Filter from the "project", "branch", "days", "date"
+//     and "q" parameters
+func parseFilter(r *http.Request) sessionFilter {
+	q := r.URL.Query()
+	f := sessionFilter{
+		Project: q.Get("project"),
+		Branch:  q.Get("branch"),
+		Query:   strings.TrimSpace(q.Get("q")),
+	}
+	if days, err := strconv.Atoi(q.Get("days")); err == nil && days > 0 {
+		f.Days = days
+	}
+	if _, err := time.Parse("2006-01-02", q.Get("date")); err == nil {
+		f.Date = q.Get("date")
+	}
+	return f
+}
+
+// summarize returns the summary of a session.
+//
+// Parameters:
+//   - s: Session to summarize
+//
+// Returns:
+//   - sessionSummary: Listing data of the session
+func summarize(s *parser.Session) sessionSummary {
+	preview := s.FirstUserMsg
+	if runes := []rune(preview); len(runes) > previewLength {
+		preview = string(runes[:previewLength]) + "..."
+	}
+	return sessionSummary{
+		ID:           s.ID,
+		Slug:         s.Slug,
+		Tool:         s.Tool,
+		Project:      s.Project,
+		Branch:       s.GitBranch,
+		StartTime:    s.StartTime,
+		Duration:     s.Duration,
+		TurnCount:    s.TurnCount,
+		TokensIn:     s.TotalTokensIn,
+		TokensOut:    s.TotalTokensOut,
+		Tokens:       s.TotalTokens,
+		FirstMessage: preview,
+	}
+}
+
+// newSessionPage prepares the messages and tool statistics of a session
+// for its detail page.
+//
+// Parameters:
+//   - s: Session to show
+//
+// Returns:
+//   - sessionPage: Detail page data
+func newSessionPage(s *parser.Session) sessionPage {
+	names := make(map[string]string)
+	counts := make(map[string]int)
+	for _, t := range s.AllToolUses() {
+		names[t.ID] = t.Name
+		counts[t.Name]++
+	}
+
+	page := sessionPage{Session: s}
+	for i, msg := range s.Messages {
+		view := messageView{Message: msg, Index: i + 1}
+		for _, tr := range msg.ToolResults {
+			view.Results = append(view.Results, resultView{
+				ToolResult: tr, Name: names[tr.ToolUseID],
+			})
+		}
+		view.ResultsOnly = msg.Text == "" && len(view.Results) > 0
+		if msg.Text == "" && msg.Thinking == "" &&
+			len(msg.ToolUses) == 0 && len(view.Results) == 0 {
+			continue
+		}
+		page.Messages = append(page.Messages, view)
+	}
+
+	for name, n := range counts {
+		page.Tools = append(page.Tools, toolCount{Name: name, Count: n})
+	}
+	sort.Slice(page.Tools, func(i, j int) bool {
+		if page.Tools[i].Count != page.Tools[j].Count {
+			return page.Tools[i].Count > page.Tools[j].Count
+		}
+		return page.Tools[i].Name < page.Tools[j].Name
+	})
+
+	return page
+}
+
+// formatNumber formats an integer with thousands separators.
+//
+// Parameters:
+//   - n: Number to format
+//
+// Returns:
+//   - string: E.g. "44,061"
+func formatNumber(n int) string {
+	s := strconv.Itoa(n)
+	if n < 0 {
+		return "-" + formatNumber(-n)
+	}
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// plural formats a count with a noun, adding an "s" unless the count
+// is one.
+//
+// Parameters:
+//   - n: Count
+//   - noun: Singular noun
+//
+// Returns:
+//   - string: E.g. "1 session" or "3 sessions"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(n) + " " + noun + "s"
+}
+
+// sortedKeys returns the keys of a set in sorted order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeJSON writes a value as an indented JSON response.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
@@ -0,0 +1,188 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+	"github.com/ActiveMemory/ctx/internal/recall/extract"
+)
+
+// Labels and notes recording where a staged candidate came from.
+const (
+	labelSession = "session"
+	labelAt      = "at"
+	noteExcerpt  = "Excerpt"
+)
+
+// stageSections maps candidate kinds to their staging file headings, in
+// file order.
+var stageSections = []struct {
+	kind    extract.Kind
+	heading string
+}{
+	{extract.KindDecision, "Decisions"},
+	{extract.KindLearning, "Learnings"},
+}
+
+// stageHeader opens the staging file.
+const stageHeader = `# Import Candidates
+
+<!--
+Candidates found in AI sessions by 'ctx recall import'.
+
+Mark a candidate [x] to import it or [-] to reject it, editing its text
+if needed, then run 'ctx recall import --apply'. Imported candidates are
+removed from this file; rejected ones are kept so that they are not
+staged again.
+-->
+`
+
+// staged is a candidate in the staging file.
+//
+// Fields:
+//   - kind: Decision or learning
+//   - state: Review state; done is accepted, skipped is rejected
+//   - text: Candidate text, as edited by the reviewer
+//   - session: ID of the session the candidate was found in
+//   - at: RFC 3339 timestamp of the message it was found in
+//   - excerpt: Line of the message it was found in
+type staged struct {
+	kind    extract.Kind
+	state   context.TaskState
+	text    string
+	session string
+	at      string
+	excerpt string
+}
+
+// stagePath returns the path of the staging file.
+//
+// Returns:
+//   - string: Path of candidates.md in the import directory
+func stagePath() string {
+	return config.ContextPath(config.DirImport, config.FileImportCandidates)
+}
+
+// readStage reads the staging file.
+//
+// The file is a checklist parsed like TASKS.md: the heading above each
+// checkbox gives its kind and its labels give its source. Checkboxes
+// under other headings are ignored.
+//
+// Parameters:
+//   - path: Path of the staging file
+//
+// Returns:
+//   - []staged: Staged candidates in file order; nil if the file is missing
+//   - error: Non-nil if the file exists but cannot be read
+func readStage(path string) ([]staged, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var items []staged
+	doc := context.ParseDocument(config.FilenameTask, content)
+	for _, t := range doc.Tasks() {
+		if t.Parent != nil {
+			continue
+		}
+		var kind extract.Kind
+		for _, s := range stageSections {
+			if strings.EqualFold(t.Section, s.heading) {
+				kind = s.kind
+			}
+		}
+		if kind == "" || t.Title() == "" {
+			continue
+		}
+		session, _ := t.Label(labelSession)
+		at, _ := t.Label(labelAt)
+		excerpt, _ := t.Note(noteExcerpt)
+		items = append(items, staged{
+			kind:    kind,
+			state:   t.State(),
+			text:    t.Title(),
+			session: session,
+			at:      at,
+			excerpt: excerpt,
+		})
+	}
+	return items, nil
+}
+
+// writeStage writes the staging file, grouping candidates by kind.
+//
+// Parameters:
+//   - path: Path of the staging file; its directory is created if needed
+//   - items: Candidates to write
+//
+// Returns:
+//   - error: Non-nil if the file cannot be written
+func writeStage(path string, items []staged) error {
+	var sb strings.Builder
+	sb.WriteString(stageHeader)
+	for _, s := range stageSections {
+		sb.WriteString("\n## " + s.heading + "\n")
+		first := true
+		for _, it := range items {
+			if it.kind != s.kind {
+				continue
+			}
+			if first {
+				sb.WriteString("\n")
+				first = false
+			}
+			sb.WriteString(formatStaged(it))
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(sb.String()), 0644)
+}
+
+// formatStaged formats a candidate as a checkbox with its source labels
+// and an excerpt note.
+//
+// Parameters:
+//   - it: Candidate to format
+//
+// Returns:
+//   - string: Checkbox line and note, with trailing newline
+func formatStaged(it staged) string {
+	mark := " "
+	switch it.state {
+	case context.TaskDone:
+		mark = "x"
+	case context.TaskSkipped:
+		mark = "-"
+	}
+
+	line := fmt.Sprintf("- [%s] %s", mark, it.text)
+	if it.session != "" {
+		line += " #" + labelSession + ":" + it.session
+	}
+	if it.at != "" {
+		line += " #" + labelAt + ":" + it.at
+	}
+	line += "\n"
+	if it.excerpt != "" {
+		line += "  " + noteExcerpt + ": " + it.excerpt + "\n"
+	}
+	return line
+}
@@ -0,0 +1,258 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+	"github.com/ActiveMemory/ctx/internal/recall/stats"
+)
+
+// Output formats of the recall stats command.
+const (
+	formatText = "text"
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+// statsFlags holds the flag values of the recall stats command.
+//
+// Fields:
+//   - dirs: Additional directories to scan for session files
+//   - by: Dimension to group sessions by
+//   - since: Only count sessions started on or after this date
+//   - project: Only count sessions whose project contains this text
+//   - tool: Only count sessions of this tool
+//   - format: Output format: text, json or csv
+type statsFlags struct {
+	dirs    []string
+	by      string
+	since   string
+	project string
+	tool    string
+	format  string
+}
+
+// runRecallStats handles the recall stats command.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - flags: All flag values from the command
+//
+// Returns:
+//   - error: Non-nil if a flag or directory is invalid or sessions
+//     cannot be read
+func runRecallStats(cmd *cobra.Command, flags statsFlags) error {
+	by := stats.Dimension(flags.by)
+	if !slices.Contains(stats.Dimensions, by) {
+		return fmt.Errorf("unknown dimension %q. Valid dimensions: %s",
+			flags.by, joinValues(stats.Dimensions))
+	}
+	if !slices.Contains([]string{formatText, formatJSON, formatCSV}, flags.format) {
+		return fmt.Errorf("unknown format %q. Valid formats: text, json, csv", flags.format)
+	}
+	since, err := parseDateFlag("since", flags.since)
+	if err != nil {
+		return err
+	}
+	if err := checkDirs(flags.dirs); err != nil {
+		return err
+	}
+
+	sessions := findSessions(cmd, flags.dirs...)
+	selected := selectSessions(sessions, statsFilter{
+		since: since, project: flags.project, tool: flags.tool,
+	})
+
+	report := stats.Aggregate(selected, by, stats.LoadPricing())
+	switch flags.format {
+	case formatJSON:
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case formatCSV:
+		return writeStatsCSV(cmd, report)
+	}
+	printStats(cmd, report)
+	return nil
+}
+
+// statsFilter selects the sessions counted by recall stats and recall
+// tools.
+//
+// Fields:
+//   - since: Only sessions started on or after this time; zero for no limit
+//   - until: Only sessions started before this time; zero for no limit
+//   - project: Only sessions whose project contains this text
+//   - tool: Only sessions of this tool
+type statsFilter struct {
+	since   time.Time
+	until   time.Time
+	project string
+	tool    string
+}
+
+// selectSessions returns the sessions matching a filter, in order.
+//
+// Parameters:
+//   - sessions: Sessions to filter
+//   - f: Filter to apply
+//
+// Returns:
+//   - []*parser.Session: Matching sessions
+func selectSessions(sessions []*parser.Session, f statsFilter) []*parser.Session {
+	project := strings.ToLower(f.project)
+	var selected []*parser.Session
+	for _, s := range sessions {
+		switch {
+		case !f.since.IsZero() && s.StartTime.Before(f.since):
+		case !f.until.IsZero() && !s.StartTime.Before(f.until):
+		case project != "" && !strings.Contains(strings.ToLower(s.Project), project):
+		case f.tool != "" && s.Tool != f.tool:
+		default:
+			selected = append(selected, s)
+		}
+	}
+	return selected
+}
+
+// parseDateFlag parses the value of a date flag.
+//
+// Parameters:
+//   - name: Flag name, for the error message
+//   - value: Flag value in YYYY-MM-DD format; empty for none
+//
+// Returns:
+//   - time.Time: Start of the day in local time; zero if value is empty
+//   - error: Non-nil if the value is not a date
+func parseDateFlag(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s date %q, expected YYYY-MM-DD", name, value)
+	}
+	return t, nil
+}
+
+// writeStatsCSV writes the groups of a report as CSV, one row per group.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - report: Report to write
+//
+// Returns:
+//   - error: Non-nil if writing fails
+func writeStatsCSV(cmd *cobra.Command, report stats.Report) error {
+	w := csv.NewWriter(cmd.OutOrStdout())
+	_ = w.Write([]string{
+		string(report.By), "sessions", "turns", "input_tokens", "output_tokens",
+		"cache_write_tokens", "cache_read_tokens", "total_tokens", "cost_usd",
+	})
+	for _, g := range report.Groups {
+		_ = w.Write([]string{
+			g.Key, strconv.Itoa(g.Sessions), strconv.Itoa(g.Turns),
+			strconv.Itoa(g.Input), strconv.Itoa(g.Output),
+			strconv.Itoa(g.CacheWrite), strconv.Itoa(g.CacheRead),
+			strconv.Itoa(g.Total), strconv.FormatFloat(g.Cost, 'f', 4, 64),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// printStats prints a report as a table, followed by the comparison of
+// sessions with and without a ctx agent context packet.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - report: Report to print
+func printStats(cmd *cobra.Command, report stats.Report) {
+	if report.Total.Sessions == 0 {
+		cmd.Println("No sessions found.")
+		return
+	}
+
+	header := color.New(color.Bold)
+	dim := color.New(color.FgHiBlack)
+
+	width := max(len("TOTAL"), len(report.By))
+	for _, g := range report.Groups {
+		width = max(width, len(g.Key))
+	}
+	row := func(key string, u stats.Usage) {
+		cmd.Printf("%-*s  %8d  %6d  %7s  %7s  %11s  %10s  %7s  %9s\n",
+			width, key, u.Sessions, u.Turns,
+			formatTokens(u.Input), formatTokens(u.Output),
+			formatTokens(u.CacheWrite), formatTokens(u.CacheRead),
+			formatTokens(u.Total), formatCost(u.Cost))
+	}
+
+	header.Fprintf(cmd.OutOrStdout(), "%-*s  %8s  %6s  %7s  %7s  %11s  %10s  %7s  %9s\n",
+		width, strings.ToUpper(string(report.By)), "SESSIONS", "TURNS",
+		"INPUT", "OUTPUT", "CACHE WRITE", "CACHE READ", "TOTAL", "COST")
+	for _, g := range report.Groups {
+		row(g.Key, g.Usage)
+	}
+	if len(report.Groups) > 1 {
+		row("TOTAL", report.Total)
+	}
+	cmd.Println()
+
+	header.Fprintln(cmd.OutOrStdout(), "ctx agent context packet")
+	cmd.Printf("%-8s  %8s  %11s  %12s  %11s\n",
+		"", "SESSIONS", "TOKENS/TURN", "COST/SESSION", "WITH ERRORS")
+	for _, side := range []struct {
+		name string
+		u    stats.Usage
+	}{
+		{"with", report.ContextPacket.With},
+		{"without", report.ContextPacket.Without},
+	} {
+		if side.u.Sessions == 0 {
+			cmd.Printf("%-8s  %8d  %11s  %12s  %11s\n", side.name, 0, "-", "-", "-")
+			continue
+		}
+		perTurn := "-"
+		if side.u.Turns > 0 {
+			perTurn = formatTokens(side.u.Total / side.u.Turns)
+		}
+		cmd.Printf("%-8s  %8d  %11s  %12s  %10d%%\n", side.name, side.u.Sessions,
+			perTurn, formatCost(side.u.Cost/float64(side.u.Sessions)),
+			side.u.Errors*100/side.u.Sessions)
+	}
+	cmd.Println()
+
+	dim.Fprintln(cmd.OutOrStdout(), "Costs are estimates from list prices per million tokens.")
+	if len(report.UnpricedModels) > 0 {
+		dim.Fprintf(cmd.OutOrStdout(),
+			"%s not costed, no price for: %s. Add prices under \"pricing\" in .contextrc.\n",
+			plural(report.Total.Unpriced, "session"), strings.Join(report.UnpricedModels, ", "))
+	}
+}
+
+// formatCost formats an amount in USD, e.g. "$12.34".
+//
+// Parameters:
+//   - usd: Amount in USD
+//
+// Returns:
+//   - string: Formatted amount
+func formatCost(usd float64) string {
+	return fmt.Sprintf("$%.2f", usd)
+}
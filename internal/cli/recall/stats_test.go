@@ -0,0 +1,69 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRecallStats tests grouping token usage and the output formats.
+func TestRecallStats(t *testing.T) {
+	setupRecallHome(t)
+
+	user := func(uuid, session, cwd, day string) claudeLine {
+		return claudeLine{
+			uuid: uuid, session: session, cwd: cwd, typ: "user",
+			at: "2026-01-" + day + "T09:59:00Z", content: `"Go"`,
+		}
+	}
+	reply := func(uuid, session, cwd, day, usage string) claudeLine {
+		return claudeLine{
+			uuid: uuid, session: session, cwd: cwd, typ: "assistant",
+			at:      "2026-01-" + day + "T10:00:00Z",
+			content: `[{"type":"text","text":"Done"}]`,
+			message: `"model":"claude-sonnet-4-5","usage":` + usage,
+		}
+	}
+	dir := t.TempDir()
+	writeClaudeSession(t, dir,
+		user("u1", "s1", "/src/api", "10"),
+		reply("a1", "s1", "/src/api", "10", `{"input_tokens":1000000,"output_tokens":0}`),
+		user("u2", "s2", "/src/web", "12"),
+		reply("a2", "s2", "/src/web", "12", `{"input_tokens":0,"output_tokens":0,"cache_read_input_tokens":2000000}`),
+	)
+
+	run := func(args ...string) (string, error) {
+		return runRecall(t, append([]string{"stats", "--dir", dir}, args...)...)
+	}
+
+	out, err := run("--format", "csv")
+	if err != nil {
+		t.Fatalf("stats failed: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"project,sessions,turns,input_tokens,output_tokens,cache_write_tokens,cache_read_tokens,total_tokens,cost_usd",
+		"web,1,1,0,0,0,2000000,2000000,0.6000",
+		"api,1,1,1000000,0,0,0,1000000,3.0000",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("csv output missing %q:\n%s", want, out)
+		}
+	}
+
+	out, err = run("--by", "day", "--since", "2026-01-11")
+	if err != nil {
+		t.Fatalf("stats failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "2026-01-12") || strings.Contains(out, "2026-01-10") {
+		t.Errorf("--since 2026-01-11 should only report 2026-01-12:\n%s", out)
+	}
+
+	if _, err := run("--by", "week"); err == nil || !strings.Contains(err.Error(), "unknown dimension") {
+		t.Errorf("--by week: got %v, want unknown dimension error", err)
+	}
+}
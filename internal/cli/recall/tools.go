@@ -0,0 +1,166 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+	"github.com/ActiveMemory/ctx/internal/recall/stats"
+)
+
+// repeatedFailures is the number of failures of a command from which
+// recall tools suggests recording a learning.
+const repeatedFailures = 3
+
+// toolsFlags holds the flag values of the recall tools command.
+//
+// Fields:
+//   - dirs: Additional directories to scan for session files
+//   - since: Only count sessions started on or after this date
+//   - until: Only count sessions started on or before this date
+//   - project: Only count sessions whose project contains this text
+//   - tool: Only count sessions of this tool
+//   - limit: Maximum number of failing commands and files listed
+//   - format: Output format: text or json
+type toolsFlags struct {
+	dirs    []string
+	since   string
+	until   string
+	project string
+	tool    string
+	limit   int
+	format  string
+}
+
+// runRecallTools handles the recall tools command.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - flags: All flag values from the command
+//
+// Returns:
+//   - error: Non-nil if a flag or directory is invalid or the output
+//     cannot be written
+func runRecallTools(cmd *cobra.Command, flags toolsFlags) error {
+	if !slices.Contains([]string{formatText, formatJSON}, flags.format) {
+		return fmt.Errorf("unknown format %q. Valid formats: text, json", flags.format)
+	}
+	since, err := parseDateFlag("since", flags.since)
+	if err != nil {
+		return err
+	}
+	until, err := parseDateFlag("until", flags.until)
+	if err != nil {
+		return err
+	}
+	if !until.IsZero() {
+		// Include the whole day
+		until = until.AddDate(0, 0, 1)
+	}
+	if err := checkDirs(flags.dirs); err != nil {
+		return err
+	}
+
+	sessions := findSessions(cmd, flags.dirs...)
+	selected := selectSessions(sessions, statsFilter{
+		since: since, until: until, project: flags.project, tool: flags.tool,
+	})
+
+	// Tool calls are in the messages, which the parse cache leaves out
+	var loaded []*parser.Session
+	for _, s := range selected {
+		if err := s.Load(); err != nil {
+			color.New(color.FgYellow).Fprintf(cmd.ErrOrStderr(),
+				"○ Skipped session %s: %v\n", s.ID, err)
+			continue
+		}
+		loaded = append(loaded, s)
+	}
+
+	report := stats.Tools(loaded)
+	if flags.limit > 0 {
+		report.Commands = report.Commands[:min(flags.limit, len(report.Commands))]
+		report.Files = report.Files[:min(flags.limit, len(report.Files))]
+	}
+
+	if flags.format == formatJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	printTools(cmd, report)
+	return nil
+}
+
+// printTools prints the tool calls, failing commands and files of a
+// report as tables.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - report: Report to print
+func printTools(cmd *cobra.Command, report stats.ToolReport) {
+	if len(report.Tools) == 0 {
+		cmd.Printf("No tool calls found in %s.\n", plural(report.Sessions, "session"))
+		return
+	}
+
+	header := color.New(color.Bold)
+	dim := color.New(color.FgHiBlack)
+	out := cmd.OutOrStdout()
+
+	cmd.Printf("Tool calls in %s\n\n", plural(report.Sessions, "session"))
+
+	width := len("TOOL")
+	for _, t := range report.Tools {
+		width = max(width, len(t.Name))
+	}
+	header.Fprintf(out, "%-*s  %7s  %6s  %10s\n", width, "TOOL", "CALLS", "ERRORS", "ERROR RATE")
+	for _, t := range report.Tools {
+		cmd.Printf("%-*s  %7d  %6d  %9.1f%%\n", width, t.Name, t.Calls, t.Errors,
+			float64(t.Errors)*100/float64(t.Calls))
+	}
+	cmd.Println()
+
+	if len(report.Commands) > 0 {
+		width = len("FAILING COMMAND")
+		for _, c := range report.Commands {
+			width = max(width, len(c.Command))
+		}
+		header.Fprintf(out, "%-*s  %8s  %6s  %8s\n", width, "FAILING COMMAND", "FAILURES", "RUNS", "SESSIONS")
+		for _, c := range report.Commands {
+			cmd.Printf("%-*s  %8d  %6d  %8d\n", width, c.Command, c.Failures, c.Runs, c.Sessions)
+			dim.Fprintf(out, "  last failure: %s\n", oneLine(c.Example, 100))
+		}
+		cmd.Println()
+	}
+
+	if len(report.Files) > 0 {
+		width = len("FILE")
+		for _, f := range report.Files {
+			width = max(width, len(f.Path))
+		}
+		header.Fprintf(out, "%-*s  %6s  %6s  %8s\n", width, "FILE", "READS", "EDITS", "SESSIONS")
+		for _, f := range report.Files {
+			cmd.Printf("%-*s  %6d  %6d  %8d\n", width, f.Path, f.Reads, f.Edits, f.Sessions)
+		}
+		cmd.Println()
+	}
+
+	for _, c := range report.Commands {
+		if c.Failures >= repeatedFailures {
+			dim.Fprintf(out, "'%s' failed %d times. Record what it takes to pass with:\n", c.Command, c.Failures)
+			dim.Fprintf(out, "  ctx add learning \"...\"\n")
+			break
+		}
+	}
+}
@@ -0,0 +1,58 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestRecallTools tests reporting failing commands and files.
+func TestRecallTools(t *testing.T) {
+	setupRecallHome(t)
+
+	var lines []claudeLine
+	for i := range 3 {
+		lines = append(lines,
+			claudeLine{
+				uuid: fmt.Sprintf("a%d", i), typ: "assistant", cwd: "/src/api",
+				at:      fmt.Sprintf("2026-01-10T10:00:%02dZ", i*2),
+				content: fmt.Sprintf(`[{"type":"tool_use","id":"t%d","name":"Bash","input":{"command":"go test ./... -run TestRetry%d"}}]`, i, i),
+			},
+			claudeLine{
+				uuid: fmt.Sprintf("u%d", i), typ: "user", cwd: "/src/api",
+				at:      fmt.Sprintf("2026-01-10T10:00:%02dZ", i*2+1),
+				content: fmt.Sprintf(`[{"type":"tool_result","tool_use_id":"t%d","content":"FAIL","is_error":true}]`, i),
+			},
+		)
+	}
+	lines = append(lines, claudeLine{
+		uuid: "a9", typ: "assistant", cwd: "/src/api", at: "2026-01-10T10:00:09Z",
+		content: `[{"type":"tool_use","id":"t9","name":"Edit","input":{"file_path":"/src/api/retry.go"}}]`,
+	})
+	dir := t.TempDir()
+	writeClaudeSession(t, dir, lines...)
+
+	out, err := runRecall(t, "tools", "--dir", dir)
+	if err != nil {
+		t.Fatalf("tools failed: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"Tool calls in 1 session",
+		"Bash",
+		"go test",
+		"last failure: go test ./... -run TestRetry2",
+		"retry.go",
+		"'go test' failed 3 times",
+		"ctx add learning",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
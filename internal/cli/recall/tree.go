@@ -0,0 +1,144 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/ActiveMemory/ctx/internal/recall/export"
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// treePreviewLen is the length of the message previews in the tree view.
+const treePreviewLen = 80
+
+// printSessionTree prints the conversation tree of a session.
+//
+// The main branch is listed in order, one line per message. Subagent
+// conversations are indented under the tool call that spawned them, and
+// abandoned branches under the message they forked from.
+//
+// Parameters:
+//   - w: Writer for output
+//   - session: Session to print
+func printSessionTree(w io.Writer, session *parser.Session) {
+	forks := make(map[string][]parser.Branch)
+	for _, b := range session.Branches {
+		forks[b.ParentID] = append(forks[b.ParentID], b)
+	}
+
+	// Branches with no fork message start before the main branch
+	for _, b := range forks[""] {
+		printBranch(w, b, forks, 0)
+	}
+	printTreeMessages(w, session.Messages, forks, 0)
+
+	for _, b := range session.Sidechains {
+		dim := color.New(color.FgHiBlack)
+		dim.Fprintf(w, "↳ sidechain (%d messages)\n", len(b.Messages))
+		printTreeMessages(w, b.Messages, forks, 1)
+	}
+}
+
+// printTreeMessages prints messages of the tree at an indentation depth,
+// each followed by its sidechains and the branches that fork from it.
+//
+// Parameters:
+//   - w: Writer for output
+//   - msgs: Messages to print, in order
+//   - forks: Abandoned branches keyed by the ID of their fork message
+//   - depth: Indentation depth
+func printTreeMessages(w io.Writer, msgs []parser.Message, forks map[string][]parser.Branch, depth int) {
+	indent := strings.Repeat("  ", depth)
+	dim := color.New(color.FgHiBlack)
+	for _, msg := range msgs {
+		role := color.New(color.FgGreen).Sprint("Assistant")
+		if msg.IsUser() {
+			role = color.New(color.FgCyan).Sprint("User")
+		}
+		fmt.Fprintf(w, "%s- %s: %s ", indent, role, treePreview(msg))
+		dim.Fprintf(w, "(%s)\n", msg.Timestamp.Format("15:04:05"))
+
+		for _, t := range msg.ToolUses {
+			if t.Sidechain == nil {
+				continue
+			}
+			fmt.Fprintf(w, "%s  ↳ %s ", indent, export.ToolSummary(t))
+			dim.Fprintf(w, "sidechain (%d messages)\n", len(t.Sidechain))
+			printTreeMessages(w, t.Sidechain, forks, depth+2)
+		}
+
+		if msg.ID == "" {
+			continue
+		}
+		for _, b := range forks[msg.ID] {
+			printBranch(w, b, forks, depth+1)
+		}
+	}
+}
+
+// printBranch prints an abandoned branch at an indentation depth.
+//
+// Parameters:
+//   - w: Writer for output
+//   - b: Branch to print
+//   - forks: Abandoned branches keyed by the ID of their fork message
+//   - depth: Indentation depth
+func printBranch(w io.Writer, b parser.Branch, forks map[string][]parser.Branch, depth int) {
+	color.New(color.FgYellow).Fprintf(w, "%s⎇ abandoned branch (%d messages)\n",
+		strings.Repeat("  ", depth), len(b.Messages))
+	printTreeMessages(w, b.Messages, forks, depth+1)
+}
+
+// treePreview returns a one-line preview of a message: its text, or the
+// tool calls and results it holds when it has none.
+//
+// Parameters:
+//   - msg: Message to preview
+//
+// Returns:
+//   - string: Preview of at most treePreviewLen bytes plus an ellipsis
+func treePreview(msg parser.Message) string {
+	text := msg.Text
+	if strings.TrimSpace(text) == "" {
+		var names []string
+		for _, t := range msg.ToolUses {
+			names = append(names, t.Name)
+		}
+		for _, tr := range msg.ToolResults {
+			if tr.IsError {
+				names = append(names, "error")
+			} else {
+				names = append(names, "result")
+			}
+		}
+		if len(names) > 0 {
+			text = "[" + strings.Join(names, ", ") + "]"
+		}
+	}
+	return oneLine(text, treePreviewLen)
+}
+
+// oneLine joins the lines of a text and truncates it.
+//
+// Parameters:
+//   - text: Text to shorten
+//   - maxLen: Maximum length in bytes, before the ellipsis
+//
+// Returns:
+//   - string: Text on one line, with "..." appended if truncated
+func oneLine(text string, maxLen int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) > maxLen {
+		text = text[:maxLen] + "..."
+	}
+	return text
+}
@@ -0,0 +1,65 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package recall
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// TestPrintSessionTree tests that sidechains and abandoned branches are
+// indented under the messages they belong to.
+func TestPrintSessionTree(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	t.Cleanup(func() { color.NoColor = noColor })
+
+	session := &parser.Session{
+		Messages: []parser.Message{
+			{ID: "u1", Role: "user", Text: "Who calls Parse?"},
+			{ID: "a1", Role: "assistant", ToolUses: []parser.ToolUse{{
+				Name:  "Task",
+				Input: `{"description":"Find callers"}`,
+				Sidechain: []parser.Message{
+					{ID: "s1", Role: "user", Text: "Find the callers\nof Parse"},
+				},
+			}}},
+			{ID: "u2", Role: "user", Text: "Thanks"},
+		},
+		Branches: []parser.Branch{{
+			ParentID: "a1",
+			Messages: []parser.Message{{ID: "b1", Role: "user", Text: "Never mind"}},
+		}},
+	}
+
+	var out bytes.Buffer
+	printSessionTree(&out, session)
+
+	want := []string{
+		"- User: Who calls Parse?",
+		"- Assistant: [Task]",
+		"  ↳ Task: Find callers sidechain (1 messages)",
+		"    - User: Find the callers of Parse",
+		"  ⎇ abandoned branch (1 messages)",
+		"    - User: Never mind",
+		"- User: Thanks",
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), out.String())
+	}
+	for i, w := range want {
+		if !strings.HasPrefix(lines[i], w) {
+			t.Errorf("line %d = %q, want prefix %q", i+1, lines[i], w)
+		}
+	}
+}
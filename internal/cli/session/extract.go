@@ -7,11 +7,8 @@
 package session
 
 import (
-	"bufio"
-	"encoding/json"
-	"fmt"
-	"os"
-	"regexp"
+	"github.com/ActiveMemory/ctx/internal/recall/extract"
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
 )
 
 // extractInsights parses a JSONL transcript and extracts potential decisions
@@ -27,133 +24,57 @@ import (
 // Returns:
 //   - []string: Extracted decision insights
 //   - []string: Extracted learning insights
-//   - error: Non-nil if file cannot be opened or read
+//   - error: Non-nil if file cannot be read or holds no conversation
 func extractInsights(path string) ([]string, []string, error) {
-	file, err := os.Open(path)
+	sessions, err := parseTranscript(path)
 	if err != nil {
 		return nil, nil, err
 	}
-	defer func(file *os.File) {
-		err := file.Close()
-		if err != nil {
-			_ = fmt.Errorf("error closing file: %v", err)
-		}
-	}(file)
 
 	var decisions []string
 	var learnings []string
-
-	// Patterns for detecting decisions
-	decisionPatterns := []*regexp.Regexp{
-		regexp.MustCompile(`(?i)decided to\s+(.{20,100})`),
-		regexp.MustCompile(`(?i)decision:\s*(.{20,100})`),
-		regexp.MustCompile(`(?i)we('ll| will) use\s+(.{10,80})`),
-		regexp.MustCompile(`(?i)going with\s+(.{10,80})`),
-		regexp.MustCompile(`(?i)chose\s+(.{10,80})\s+(over|instead)`),
-	}
-
-	// Patterns for detecting learnings
-	learningPatterns := []*regexp.Regexp{
-		regexp.MustCompile(`(?i)learned that\s+(.{20,100})`),
-		regexp.MustCompile(`(?i)gotcha:\s*(.{20,100})`),
-		regexp.MustCompile(`(?i)lesson:\s*(.{20,100})`),
-		regexp.MustCompile(`(?i)TIL:?\s*(.{20,100})`),
-		regexp.MustCompile(`(?i)turns out\s+(.{20,100})`),
-		regexp.MustCompile(`(?i)important to (note|remember):\s*(.{20,100})`),
-	}
-
-	scanner := bufio.NewScanner(file)
-	buf := make([]byte, 0, 64*1024)
-	scanner.Buffer(buf, 10*1024*1024)
-
 	seen := make(map[string]bool) // Deduplicate
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
-
-		var entry transcriptEntry
-		if err := json.Unmarshal([]byte(line), &entry); err != nil {
-			continue
-		}
-
-		// Only look at assistant messages
-		if entry.Type != "assistant" {
-			continue
-		}
-
-		// Extract text content
-		texts := extractTextContent(entry)
-
-		for _, text := range texts {
-			// Check for decisions
-			for _, pattern := range decisionPatterns {
-				matches := pattern.FindAllStringSubmatch(text, -1)
-				for _, match := range matches {
-					if len(match) > 1 {
-						insight := cleanInsight(match[1])
-						if insight != "" && !seen[insight] {
-							seen[insight] = true
-							decisions = append(decisions, insight)
-						}
-					}
-				}
+	for _, s := range sessions {
+		for _, msg := range s.AllMessages() {
+			// Only look at assistant messages
+			if msg.IsUser() {
+				continue
 			}
 
-			// Check for learnings
-			for _, pattern := range learningPatterns {
-				matches := pattern.FindAllStringSubmatch(text, -1)
-				for _, match := range matches {
-					if len(match) > 1 {
-						insight := cleanInsight(match[len(match)-1])
-						if insight != "" && !seen[insight] {
-							seen[insight] = true
-							learnings = append(learnings, insight)
-						}
+			for _, text := range extractTextContent(msg) {
+				for _, insight := range extract.Text(text) {
+					if seen[insight.Text] {
+						continue
+					}
+					seen[insight.Text] = true
+					if insight.Kind == extract.KindDecision {
+						decisions = append(decisions, insight.Text)
+					} else {
+						learnings = append(learnings, insight.Text)
 					}
 				}
 			}
 		}
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, nil, err
-	}
-
 	return decisions, learnings, nil
 }
 
-// extractTextContent extracts all text content from a transcript entry.
-//
-// Handles both string content and array content (with text and thinking blocks).
+// extractTextContent extracts the text and thinking of a message.
 //
 // Parameters:
-//   - entry: Transcript entry to extract text from
+//   - msg: Message to extract text from
 //
 // Returns:
-//   - []string: All text content found in the entry
-func extractTextContent(entry transcriptEntry) []string {
+//   - []string: The non-empty text and thinking of the message
+func extractTextContent(msg parser.Message) []string {
 	var texts []string
-
-	switch content := entry.Message.Content.(type) {
-	case string:
-		texts = append(texts, content)
-	case []interface{}:
-		for _, block := range content {
-			blockMap, ok := block.(map[string]interface{})
-			if !ok {
-				continue
-			}
-			if text, ok := blockMap["text"].(string); ok {
-				texts = append(texts, text)
-			}
-			if thinking, ok := blockMap["thinking"].(string); ok {
-				texts = append(texts, thinking)
-			}
-		}
+	if msg.Text != "" {
+		texts = append(texts, msg.Text)
+	}
+	if msg.Thinking != "" {
+		texts = append(texts, msg.Thinking)
 	}
-
 	return texts
 }
@@ -7,12 +7,12 @@
 package session
 
 import (
-	"bufio"
-	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/recall/export"
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
 )
 
 // parseIndex attempts to parse a string as a positive integer index.
@@ -35,72 +35,45 @@ func parseIndex(s string) (int, error) {
 	return idx, nil
 }
 
-// parseJsonlTranscript parses a .jsonl file and returns formatted Markdown.
+// parseTranscript reads the sessions in a Claude Code JSONL transcript.
+//
+// Parameters:
+//   - path: Path to the JSONL transcript file
 //
-// Reads a Claude Code JSONL transcript and converts it to readable Markdown
-// with message headers, timestamps, and formatted content blocks.
+// Returns:
+//   - []*parser.Session: Sessions in the transcript, oldest first
+//   - error: Non-nil if the file cannot be read or holds no conversation
+func parseTranscript(path string) ([]*parser.Session, error) {
+	sessions, err := parser.NewClaudeCodeParser().ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, fmt.Errorf("no conversation found in %s", path)
+	}
+	return sessions, nil
+}
+
+// renderTranscript renders the sessions in a Claude Code JSONL transcript
+// as Markdown, with the same renderer as 'ctx recall export'.
 //
 // Parameters:
 //   - path: Path to the JSONL transcript file
 //
 // Returns:
 //   - string: Markdown-formatted transcript
-//   - error: Non-nil if the file cannot be opened or read
-func parseJsonlTranscript(path string) (string, error) {
-	file, err := os.Open(path)
+//   - error: Non-nil if the file cannot be read or holds no conversation
+func renderTranscript(path string) (string, error) {
+	sessions, err := parseTranscript(path)
 	if err != nil {
 		return "", err
 	}
-	defer func(file *os.File) {
-		err := file.Close()
-		if err != nil {
-			_ = fmt.Errorf("failed to close file: %w", err)
-		}
-	}(file)
-
-	var sb strings.Builder
-	sb.WriteString("# Conversation Transcript\n\n")
-	sb.WriteString(fmt.Sprintf("**Source**: %s\n\n", filepath.Base(path)))
-	sb.WriteString("---\n\n")
-
-	scanner := bufio.NewScanner(file)
-	// Increase buffer size for large lines
-	buf := make([]byte, 0, 64*1024)
-	scanner.Buffer(buf, 10*1024*1024) // 10MB max line size
-
-	messageCount := 0
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
-
-		var entry transcriptEntry
-		if err := json.Unmarshal([]byte(line), &entry); err != nil {
-			// Skip unparseable lines
-			continue
-		}
-
-		// Skip non-message entries
-		if entry.Type != "user" && entry.Type != "assistant" {
-			continue
-		}
-
-		messageCount++
-		formatted := formatTranscriptEntry(entry)
-		if formatted != "" {
-			sb.WriteString(formatted)
-			sb.WriteString("\n---\n\n")
-		}
-	}
 
-	if err := scanner.Err(); err != nil {
-		return "", fmt.Errorf("error reading file: %w", err)
+	var parts []string
+	for _, s := range sessions {
+		parts = append(parts, export.Markdown(s, export.All))
 	}
-
-	sb.WriteString(fmt.Sprintf("*Total messages: %d*\n", messageCount))
-
-	return sb.String(), nil
+	return strings.Join(parts, "\n"), nil
 }
 
 // parseSessionFile extracts metadata from a session file.
@@ -9,7 +9,6 @@ package session
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -34,7 +33,7 @@ import (
 func readContextSection(
 	filename, startHeader, endHeader string,
 ) (string, error) {
-	filePath := filepath.Join(config.DirContext, filename)
+	filePath := config.ContextPath(filename)
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return "", err
@@ -71,7 +70,7 @@ func readContextSection(
 //   - string: Formatted list of recent decision titles, or empty if none found
 //   - error: Non-nil if DECISIONS.md cannot be read
 func readRecentDecisions() (string, error) {
-	filePath := filepath.Join(config.DirContext, config.FilenameDecision)
+	filePath := config.ContextPath(config.FilenameDecision)
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return "", err
@@ -120,7 +119,7 @@ func readRecentDecisions() (string, error) {
 //   - string: Formatted list of recent learnings, or empty if none found
 //   - error: Non-nil if LEARNINGS.md cannot be read
 func readRecentLearnings() (string, error) {
-	filePath := filepath.Join(config.DirContext, config.FilenameLearning)
+	filePath := config.ContextPath(config.FilenameLearning)
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return "", err
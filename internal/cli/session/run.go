@@ -130,7 +130,7 @@ func runSessionParse(
 	}
 
 	// Parse the jsonl file
-	content, err := parseJsonlTranscript(inputPath)
+	content, err := renderTranscript(inputPath)
 	if err != nil {
 		return fmt.Errorf("failed to parse transcript: %w", err)
 	}
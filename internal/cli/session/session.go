@@ -7,8 +7,6 @@
 package session
 
 import (
-	"path/filepath"
-
 	"github.com/spf13/cobra"
 
 	"github.com/ActiveMemory/ctx/internal/config"
@@ -16,7 +14,7 @@ import (
 
 // sessionsDirPath returns the path to the sessions directory.
 func sessionsDirPath() string {
-	return filepath.Join(config.DirContext, config.DirSessions)
+	return config.ContextPath(config.DirSessions)
 }
 
 // Cmd returns the session command with subcommands.
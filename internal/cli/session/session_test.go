@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/ActiveMemory/ctx/internal/cli/initialize"
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
 )
 
 // TestTruncate tests the truncate helper function.
@@ -76,92 +77,38 @@ func TestParseIndex(t *testing.T) {
 	}
 }
 
-// TestCleanInsight tests the cleanInsight helper function.
-func TestCleanInsight(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected string
-	}{
-		{"simple text", "simple text"},
-		{"  trimmed  ", "trimmed"},
-		{"ends with period.", "ends with period"},
-		{"ends with comma,", "ends with comma"},
-		{"ends with multiple...", "ends with multiple"},
-		{"", ""},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			result := cleanInsight(tt.input)
-			if result != tt.expected {
-				t.Errorf("cleanInsight(%q) = %q, want %q", tt.input, result, tt.expected)
-			}
-		})
-	}
-}
-
 // TestExtractTextContent tests the extractTextContent helper function.
 func TestExtractTextContent(t *testing.T) {
 	tests := []struct {
 		name     string
-		entry    transcriptEntry
+		msg      parser.Message
 		expected []string
 	}{
 		{
-			name: "string content",
-			entry: transcriptEntry{
-				Message: transcriptMsg{
-					Content: "Hello world",
-				},
-			},
+			name:     "text",
+			msg:      parser.Message{Role: "assistant", Text: "Hello world"},
 			expected: []string{"Hello world"},
 		},
 		{
-			name: "array content with text",
-			entry: transcriptEntry{
-				Message: transcriptMsg{
-					Content: []interface{}{
-						map[string]interface{}{
-							"type": "text",
-							"text": "First text",
-						},
-						map[string]interface{}{
-							"type": "text",
-							"text": "Second text",
-						},
-					},
-				},
-			},
-			expected: []string{"First text", "Second text"},
+			name:     "text and thinking",
+			msg:      parser.Message{Role: "assistant", Text: "First text\nSecond text", Thinking: "Some thinking"},
+			expected: []string{"First text\nSecond text", "Some thinking"},
 		},
 		{
-			name: "array content with thinking",
-			entry: transcriptEntry{
-				Message: transcriptMsg{
-					Content: []interface{}{
-						map[string]interface{}{
-							"type":     "thinking",
-							"thinking": "Some thinking",
-						},
-					},
-				},
-			},
+			name:     "thinking",
+			msg:      parser.Message{Role: "assistant", Thinking: "Some thinking"},
 			expected: []string{"Some thinking"},
 		},
 		{
-			name: "empty content",
-			entry: transcriptEntry{
-				Message: transcriptMsg{
-					Content: nil,
-				},
-			},
+			name:     "empty content",
+			msg:      parser.Message{Role: "assistant"},
 			expected: nil,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := extractTextContent(tt.entry)
+			result := extractTextContent(tt.msg)
 			if len(result) != len(tt.expected) {
 				t.Errorf("extractTextContent() returned %d items, want %d", len(result), len(tt.expected))
 				return
@@ -333,7 +280,11 @@ func TestSessionParseWithOutput(t *testing.T) {
 	}
 
 	// Verify output file was created
-	if _, err := os.Stat(outputPath); os.IsNotExist(err) {
-		t.Error("output file was not created")
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("output file was not created: %v", err)
+	}
+	if !strings.Contains(string(data), "## User\n\n*2025-01-21 10:00:00 UTC*\n\nHello\n") {
+		t.Errorf("output does not hold the message:\n%s", data)
 	}
 }
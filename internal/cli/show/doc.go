@@ -0,0 +1,20 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+// Package show implements the "ctx show" command for printing a context
+// entry by its stable ID.
+//
+// Decisions, learnings, and tasks added through "ctx add" or "ctx watch"
+// carry IDs such as D-014, L-032, and T-107. The command prints the entry
+// with its location, resolves the [[ID]] references it contains, and lists
+// the entries that reference it. Archived entries are found too.
+//
+// # File Organization
+//
+//   - show.go: Command definition
+//   - run.go: Entry lookup
+//   - out.go: Output formatting
+package show
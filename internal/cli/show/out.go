@@ -0,0 +1,101 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package show
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// titled is implemented by entries that have a one-line title.
+type titled interface {
+	Title() string
+}
+
+// printEntry writes an entry with its location, outgoing references,
+// and backlinks.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - idx: Index used to resolve references
+//   - entry: Entry to print
+func printEntry(
+	cmd *cobra.Command, idx *context.Index, entry context.IndexEntry,
+) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	span := entry.Entry.Span()
+	location := fmt.Sprintf("%s:%d", entry.File, span.StartLine)
+	if span.EndLine > span.StartLine {
+		location += fmt.Sprintf("-%d", span.EndLine)
+	}
+	if entry.Archived {
+		location += " " + yellow("(archived)")
+	}
+	cmd.Printf("%s  %s\n\n", cyan(entry.ID), location)
+	cmd.Println(strings.TrimRight(entry.Entry.String(), "\r\n"))
+
+	var refs []string
+	seen := make(map[string]bool)
+	for _, id := range context.References(entry.Entry.String()) {
+		if !seen[id] {
+			seen[id] = true
+			refs = append(refs, id)
+		}
+	}
+	if len(refs) > 0 {
+		cmd.Println()
+		cmd.Println("References:")
+		for _, id := range refs {
+			cmd.Printf("  → %s\n", describeRef(idx, id))
+		}
+	}
+
+	if backlinks := idx.Backlinks(entry.ID); len(backlinks) > 0 {
+		cmd.Println()
+		cmd.Println("Referenced by:")
+		for _, ref := range backlinks {
+			if ref.From == "" {
+				cmd.Printf("  ← %s:%d\n", ref.File, ref.Line)
+				continue
+			}
+			cmd.Printf(
+				"  ← %s (%s:%d)\n", describeRef(idx, ref.From), ref.File, ref.Line,
+			)
+		}
+	}
+}
+
+// describeRef formats a referenced ID with the title of its entry.
+//
+// Parameters:
+//   - idx: Index used to resolve the ID
+//   - id: Normalized entry ID
+//
+// Returns:
+//   - string: "ID Title", marked as archived or unknown where applicable
+func describeRef(idx *context.Index, id string) string {
+	entry, ok := idx.Lookup(id)
+	if !ok {
+		return id + " (unknown)"
+	}
+
+	desc := id
+	if t, ok := entry.Entry.(titled); ok && t.Title() != "" {
+		desc += " " + t.Title()
+	}
+	if entry.Archived {
+		desc += " (archived)"
+	}
+	return desc
+}
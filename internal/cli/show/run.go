@@ -0,0 +1,51 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package show
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// runShow executes the show command logic.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - args: Command arguments; args[0] is the entry ID
+//
+// Returns:
+//   - error: Non-nil if the context directory is missing, the argument is
+//     not an ID, or no entry has the ID
+func runShow(cmd *cobra.Command, args []string) error {
+	if !context.Exists("") {
+		return fmt.Errorf("no .context/ directory found. Run 'ctx init' first")
+	}
+
+	id, ok := context.NormalizeID(args[0])
+	if !ok {
+		return fmt.Errorf(
+			"invalid ID %q: expected D-, L-, or T- followed by a number",
+			args[0],
+		)
+	}
+
+	idx, err := context.LoadIndex("")
+	if err != nil {
+		return fmt.Errorf("failed to read context: %w", err)
+	}
+
+	entry, ok := idx.Lookup(id)
+	if !ok {
+		return fmt.Errorf("no entry with ID %s", id)
+	}
+
+	printEntry(cmd, idx, entry)
+	return nil
+}
@@ -0,0 +1,38 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package show
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Cmd returns the "ctx show" command for printing an entry by ID.
+//
+// Returns:
+//   - *cobra.Command: Configured show command
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Print a context entry by ID",
+		Long: `Print a decision, learning, or task by its stable ID.
+
+IDs are assigned when entries are added through "ctx add" or "ctx watch":
+D-014 for decisions, L-032 for learnings, T-107 for tasks. Context files
+can reference entries as [[D-014]].
+
+The output shows where the entry lives, the entries it references, and
+the entries that reference it. Archived entries are shown as well.
+
+Examples:
+  ctx show D-014
+  ctx show t-7`,
+		Args: cobra.ExactArgs(1),
+		RunE: runShow,
+	}
+
+	return cmd
+}
@@ -0,0 +1,124 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package show
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/cli/add"
+	"github.com/ActiveMemory/ctx/internal/cli/initialize"
+)
+
+// TestShowCommand tests printing entries by ID.
+func TestShowCommand(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cli-show-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	adds := [][]string{
+		{"decision", "Use SQLite", "--context", "Need storage",
+			"--rationale", "Simple", "--consequences", "See [[L-001]]"},
+		{"learning", "WAL mode matters", "--context", "Locks",
+			"--lesson", "Enable WAL", "--application", "Always"},
+		{"task", "Migrate to [[D-001]]"},
+	}
+	for _, args := range adds {
+		addCmd := add.Cmd()
+		addCmd.SetArgs(args)
+		if err := addCmd.Execute(); err != nil {
+			t.Fatalf("add %v failed: %v", args, err)
+		}
+	}
+
+	archiveDir := filepath.Join(".context", "archive")
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		t.Fatalf("failed to create archive dir: %v", err)
+	}
+	if err := os.WriteFile(
+		filepath.Join(archiveDir, "tasks-2026-01-01.md"),
+		[]byte("# Archived Tasks - 2026-01-01\n\n- [x] Pick a database #id:T-000\n"),
+		0644,
+	); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		id   string
+		want []string
+	}{
+		{
+			name: "decision with references and backlinks",
+			id:   "d-1",
+			want: []string{
+				"D-001  DECISIONS.md:",
+				"## [", "] Use SQLite",
+				"References:\n  → L-001 WAL mode matters",
+				"Referenced by:\n  ← T-001 Migrate to [[D-001]] (TASKS.md:",
+			},
+		},
+		{
+			name: "learning",
+			id:   "L-001",
+			want: []string{"**Lesson**: Enable WAL", "← D-001 Use SQLite"},
+		},
+		{
+			name: "archived task",
+			id:   "T-0",
+			want: []string{
+				"T-000  archive/tasks-2026-01-01.md:3",
+				"(archived)",
+				"- [x] Pick a database",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := Cmd()
+			cmd.SetOut(&out)
+			cmd.SetArgs([]string{tt.id})
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("show %s failed: %v", tt.id, err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+
+	for _, id := range []string{"D-042", "not-an-id"} {
+		cmd := Cmd()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{id})
+		if err := cmd.Execute(); err == nil {
+			t.Errorf("show %s should fail", id)
+		}
+	}
+}
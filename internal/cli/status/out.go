@@ -8,6 +8,7 @@ package status
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/ActiveMemory/ctx/internal/context"
@@ -35,6 +36,7 @@ func outputStatusJSON(
 		TotalTokens: ctx.TotalTokens,
 		TotalSize:   ctx.TotalSize,
 		Files:       make([]FileStatus, 0, len(ctx.Files)),
+		Tasks:       getTaskProgress(ctx),
 	}
 
 	for _, f := range ctx.Files {
@@ -60,8 +62,9 @@ func outputStatusJSON(
 // outputStatusText writes context status as formatted text to the command output.
 //
 // Displays a summary including file count, token estimate, file list with
-// status indicators, and recent activity. When verbose is true, includes
-// token counts, file sizes, and content previews for each file.
+// status indicators, subtask progress of open parent tasks, and recent
+// activity. When verbose is true, includes token counts, file sizes, and
+// content previews for each file.
 //
 // Parameters:
 //   - cmd: Cobra command for output stream
@@ -123,6 +126,24 @@ func outputStatusText(
 		}
 	}
 
+	// Subtask progress of open parent tasks
+	if progress := getTaskProgress(ctx); len(progress) > 0 {
+		cmd.Println()
+		cmd.Println("Task Progress:")
+		for _, p := range progress {
+			indicator := yellow("○")
+			if p.State == string(context.TaskDone) {
+				indicator = green("✓")
+			}
+			name := p.Title
+			if p.ID != "" {
+				name = p.ID + " " + name
+			}
+			cmd.Printf("  %s%s %s (%d/%d subtasks)\n",
+				strings.Repeat("  ", p.Depth), indicator, name, p.Done, p.Total)
+		}
+	}
+
 	// Recent activity
 	cmd.Println()
 	cmd.Println("Recent Activity:")
@@ -7,7 +7,10 @@
 package status
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/ActiveMemory/ctx/internal/cli/initialize"
@@ -71,3 +74,63 @@ func TestStatusJSONOutput(t *testing.T) {
 		t.Fatalf("status --json failed: %v", err)
 	}
 }
+
+// TestStatusTaskProgress tests the subtask progress of parent tasks.
+func TestStatusTaskProgress(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cli-status-progress-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	tasks := "# Tasks\n\n" +
+		"- [ ] Feature #id:T-001\n" +
+		"  - [x] Design\n  - [-] Spike\n  - [ ] Build\n" +
+		"- [x] Finished\n  - [x] Part\n" +
+		"- [ ] Leaf\n"
+	if err := os.WriteFile(".context/TASKS.md", []byte(tasks), 0644); err != nil {
+		t.Fatalf("failed to write TASKS.md: %v", err)
+	}
+
+	var out bytes.Buffer
+	statusCmd := Cmd()
+	statusCmd.SetOut(&out)
+	statusCmd.SetArgs([]string{"--json"})
+	if err := statusCmd.Execute(); err != nil {
+		t.Fatalf("status --json failed: %v", err)
+	}
+
+	var output Output
+	if err := json.Unmarshal(out.Bytes(), &output); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	want := []TaskProgress{
+		{ID: "T-001", Title: "Feature", State: "pending", Done: 2, Total: 3},
+	}
+	if len(output.Tasks) != len(want) || output.Tasks[0] != want[0] {
+		t.Errorf("task_progress = %+v, want %+v", output.Tasks, want)
+	}
+
+	out.Reset()
+	statusCmd = Cmd()
+	statusCmd.SetOut(&out)
+	statusCmd.SetArgs([]string{})
+	if err := statusCmd.Execute(); err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "T-001 Feature (2/3 subtasks)") {
+		t.Errorf("status output missing progress:\n%s", out.String())
+	}
+}
@@ -0,0 +1,50 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// getTaskProgress returns the subtask progress of every task in TASKS.md
+// that has subtasks and is not yet complete.
+//
+// Parameters:
+//   - ctx: Loaded context containing the files
+//
+// Returns:
+//   - []TaskProgress: Progress per parent task in file order; nil if
+//     there is no TASKS.md or no open parent task
+func getTaskProgress(ctx *context.Context) []TaskProgress {
+	var progress []TaskProgress
+	for _, f := range ctx.Files {
+		if f.Name != config.FilenameTask {
+			continue
+		}
+		doc := context.ParseDocument(f.Name, f.Content)
+		for _, t := range doc.Tasks() {
+			if len(t.Children) == 0 || t.IsComplete() {
+				continue
+			}
+			closed, total := t.Progress()
+			depth := 0
+			for p := t.Parent; p != nil; p = p.Parent {
+				depth++
+			}
+			progress = append(progress, TaskProgress{
+				ID:    t.ID(),
+				Title: t.Title(),
+				State: string(t.State()),
+				Done:  closed,
+				Total: total,
+				Depth: depth,
+			})
+		}
+	}
+	return progress
+}
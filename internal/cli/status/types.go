@@ -14,12 +14,14 @@ package status
 //   - TotalTokens: Estimated total token count across all files
 //   - TotalSize: Total size in bytes across all files
 //   - Files: Individual file status entries
+//   - Tasks: Subtask progress of open parent tasks in TASKS.md
 type Output struct {
-	ContextDir  string       `json:"context_dir"`
-	TotalFiles  int          `json:"total_files"`
-	TotalTokens int          `json:"total_tokens"`
-	TotalSize   int64        `json:"total_size"`
-	Files       []FileStatus `json:"files"`
+	ContextDir  string         `json:"context_dir"`
+	TotalFiles  int            `json:"total_files"`
+	TotalTokens int            `json:"total_tokens"`
+	TotalSize   int64          `json:"total_size"`
+	Files       []FileStatus   `json:"files"`
+	Tasks       []TaskProgress `json:"task_progress,omitempty"`
 }
 
 // FileStatus represents a single file's status in JSON output.
@@ -41,3 +43,21 @@ type FileStatus struct {
 	ModTime string   `json:"mod_time"`
 	Preview []string `json:"preview,omitempty"`
 }
+
+// TaskProgress reports how far the subtasks of a parent task are done.
+//
+// Fields:
+//   - ID: Stable task ID, if the task has one
+//   - Title: Task text without labels
+//   - State: State of the parent task itself
+//   - Done: Number of subtasks, at any depth, that are done or skipped
+//   - Total: Number of subtasks at any depth
+//   - Depth: Nesting level of the parent; 0 for top-level tasks
+type TaskProgress struct {
+	ID    string `json:"id,omitempty"`
+	Title string `json:"title"`
+	State string `json:"state"`
+	Done  int    `json:"done"`
+	Total int    `json:"total"`
+	Depth int    `json:"depth"`
+}
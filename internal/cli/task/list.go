@@ -0,0 +1,218 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// stateAll is the list --state value that includes every task.
+const stateAll = "all"
+
+// checkboxes maps task states to the checkbox shown by "ctx tasks list".
+var checkboxes = map[context.TaskState]string{
+	context.TaskPending: "[ ]",
+	context.TaskDone:    "[x]",
+	context.TaskSkipped: "[-]",
+}
+
+// runTaskList executes the list subcommand logic.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - filters: Filters selecting the tasks to list
+//   - jsonOutput: If true, print JSON instead of text
+//
+// Returns:
+//   - error: Non-nil if TASKS.md cannot be read, a filter is invalid,
+//     or JSON encoding fails
+func runTaskList(
+	cmd *cobra.Command, filters listFilters, jsonOutput bool,
+) error {
+	doc, err := loadTasks()
+	if err != nil {
+		return err
+	}
+
+	tasks, err := filterTasks(doc.Tasks(), filters)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		items := make([]taskItem, 0, len(tasks))
+		for _, t := range tasks {
+			items = append(items, newTaskItem(t))
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	}
+
+	printTaskList(cmd, tasks)
+	return nil
+}
+
+// filterTasks selects the tasks that pass all filters.
+//
+// Parameters:
+//   - tasks: Tasks in file order
+//   - filters: State, label, and phase filters
+//
+// Returns:
+//   - []*context.Task: Matching tasks in file order
+//   - error: Non-nil if the state filter is not a known state
+func filterTasks(
+	tasks []*context.Task, filters listFilters,
+) ([]*context.Task, error) {
+	state := strings.ToLower(filters.state)
+	if _, ok := checkboxes[context.TaskState(state)]; !ok && state != stateAll {
+		return nil, fmt.Errorf(
+			"invalid state %q. Valid states: pending, done, skipped, all",
+			filters.state,
+		)
+	}
+
+	var matched []*context.Task
+	for _, t := range tasks {
+		if state != stateAll && t.State() != context.TaskState(state) {
+			continue
+		}
+		if filters.phase != "" && !strings.Contains(
+			strings.ToLower(t.Phase), strings.ToLower(filters.phase),
+		) {
+			continue
+		}
+		if !hasLabels(t, filters.labels) {
+			continue
+		}
+		matched = append(matched, t)
+	}
+	return matched, nil
+}
+
+// hasLabels reports whether a task carries all the given labels.
+//
+// Parameters:
+//   - t: Task to check
+//   - labels: Labels as "name" (any value) or "name:value"; a leading
+//     "#" is ignored
+//
+// Returns:
+//   - bool: True if every label is present with the given value
+func hasLabels(t *context.Task, labels []string) bool {
+	for _, l := range labels {
+		name, value, withValue := strings.Cut(strings.TrimPrefix(l, "#"), ":")
+		got, ok := t.Label(name)
+		if !ok || (withValue && !strings.EqualFold(got, value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// newTaskItem converts a task to its JSON form.
+//
+// Parameters:
+//   - t: Task to convert
+//
+// Returns:
+//   - taskItem: JSON representation of the task
+func newTaskItem(t *context.Task) taskItem {
+	item := taskItem{
+		ID:      t.ID(),
+		Title:   t.Title(),
+		State:   string(t.State()),
+		Phase:   t.Phase,
+		Section: t.Section,
+		After:   t.Dependencies(),
+		Line:    t.Span().StartLine,
+	}
+	for _, l := range t.Labels() {
+		if item.Labels == nil {
+			item.Labels = make(map[string]string)
+		}
+		item.Labels[l.Name] = l.Value
+	}
+	for _, name := range []string{context.NoteBlocked, context.NoteSkipped} {
+		if text, ok := t.Note(name); ok {
+			if item.Notes == nil {
+				item.Notes = make(map[string]string)
+			}
+			item.Notes[name] = text
+		}
+	}
+	if t.Parent != nil {
+		item.Parent = t.Parent.ID()
+		item.Depth = taskDepth(t)
+	}
+	return item
+}
+
+// taskDepth returns the nesting level of a task; 0 for top-level tasks.
+//
+// Parameters:
+//   - t: Task to measure
+//
+// Returns:
+//   - int: Number of ancestors
+func taskDepth(t *context.Task) int {
+	depth := 0
+	for p := t.Parent; p != nil; p = p.Parent {
+		depth++
+	}
+	return depth
+}
+
+// printTaskList writes tasks grouped under their phase or section.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - tasks: Tasks to print in file order
+func printTaskList(cmd *cobra.Command, tasks []*context.Task) {
+	if len(tasks) == 0 {
+		cmd.Println("No matching tasks.")
+		return
+	}
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	var group string
+	for i, t := range tasks {
+		heading := t.Phase
+		if heading == "" {
+			heading = t.Section
+		}
+		if i == 0 || heading != group {
+			if i > 0 {
+				cmd.Println()
+			}
+			if heading != "" {
+				cmd.Println(cyan(heading))
+			}
+			group = heading
+		}
+
+		indent := strings.Repeat("  ", taskDepth(t)+1)
+
+		cmd.Printf("%s%s %s\n", indent, checkboxes[t.State()],
+			strings.TrimSpace(t.Text()))
+		for _, name := range []string{context.NoteBlocked, context.NoteSkipped} {
+			if text, ok := t.Note(name); ok {
+				cmd.Printf("%s    %s\n", indent, yellow(name+": "+text))
+			}
+		}
+	}
+}
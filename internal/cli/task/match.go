@@ -0,0 +1,90 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// FindTask looks up a task in TASKS.md by ID, number, or text.
+//
+// An ID (e.g., "T-107" or "t-107") matches a task in any state. A number
+// selects the n-th pending task, counting subtasks, as listed by
+// "ctx status". Any other query is matched case-insensitively against
+// the text of pending tasks and must match exactly one.
+//
+// Parameters:
+//   - doc: Parsed TASKS.md
+//   - query: Task ID, number, or search text
+//
+// Returns:
+//   - *context.Task: Matching task
+//   - error: Non-nil if no task or more than one task matches
+func FindTask(doc *context.Document, query string) (*context.Task, error) {
+	if id, ok := context.NormalizeID(query); ok {
+		for _, task := range doc.Tasks() {
+			if taskID, _ := context.NormalizeID(task.ID()); taskID == id {
+				return task, nil
+			}
+		}
+		return nil, fmt.Errorf(
+			"task %s not found. Use 'ctx status' to see tasks", id,
+		)
+	}
+
+	taskNumber, err := strconv.Atoi(query)
+	isNumber := err == nil
+
+	currentTaskNum := 0
+	var matched *context.Task
+
+	for _, task := range doc.Tasks() {
+		if task.State() != context.TaskPending {
+			continue
+		}
+		currentTaskNum++
+
+		// Match by number
+		if isNumber {
+			if currentTaskNum == taskNumber {
+				return task, nil
+			}
+			continue
+		}
+
+		// Match by text (case-insensitive partial match)
+		if strings.Contains(
+			strings.ToLower(task.Text()), strings.ToLower(query),
+		) {
+			if matched != nil {
+				// Multiple matches - be more specific
+				return nil, fmt.Errorf(
+					"multiple tasks match %q. Be more specific or use task number",
+					query,
+				)
+			}
+			matched = task
+		}
+	}
+
+	if matched == nil {
+		if isNumber {
+			return nil, fmt.Errorf(
+				"task #%d not found. Use 'ctx status' to see tasks", taskNumber,
+			)
+		}
+		return nil, fmt.Errorf(
+			"no task matching %q found. Use 'ctx status' to see tasks", query,
+		)
+	}
+
+	return matched, nil
+}
@@ -0,0 +1,212 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// priorityRanks orders #priority values for "ctx tasks next"; lower
+// ranks are picked first. Tasks without a known priority rank as medium.
+var priorityRanks = map[string]int{
+	"high":   0,
+	"medium": 1,
+	"low":    2,
+}
+
+// runTaskNext executes the next subcommand logic.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - jsonOutput: If true, print the task as JSON ("null" if none)
+//
+// Returns:
+//   - error: Non-nil if the context files cannot be read or JSON
+//     encoding fails
+func runTaskNext(cmd *cobra.Command, jsonOutput bool) error {
+	doc, err := loadTasks()
+	if err != nil {
+		return err
+	}
+	idx, err := context.LoadIndex("")
+	if err != nil {
+		return err
+	}
+
+	next, waiting := nextTask(doc, idx)
+
+	if jsonOutput {
+		var item *taskItem
+		if next != nil {
+			i := newTaskItem(next)
+			item = &i
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(item)
+	}
+
+	if next == nil {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		cmd.Printf("%s No task is ready.\n", yellow("○"))
+		if waiting > 0 {
+			cmd.Printf(
+				"  %d pending task(s) are blocked, in progress, "+
+					"or waiting on dependencies.\n", waiting,
+			)
+		}
+		return nil
+	}
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	if next.ID() != "" {
+		cmd.Printf("%s %s\n", cyan(next.ID()), next.Title())
+	} else {
+		cmd.Println(next.Title())
+	}
+	heading := next.Phase
+	if heading == "" {
+		heading = next.Section
+	}
+	if heading != "" {
+		cmd.Printf("  in %s\n", heading)
+	}
+	if deps := next.Dependencies(); len(deps) > 0 {
+		cmd.Printf("  after %s\n", strings.Join(deps, ", "))
+	}
+	return nil
+}
+
+// nextTask picks the task to work on next.
+//
+// A task is ready when it is pending, not #in-progress, and has no
+// pending subtasks; neither it nor any of its parents may be #blocked or
+// depend on a task that is not done. Ready tasks are ranked by the order
+// of their phase (or section, outside phases) in TASKS.md, then by
+// priority, inherited from the nearest parent that sets one, then by
+// position in the file.
+//
+// Parameters:
+//   - doc: Parsed TASKS.md
+//   - idx: Index used to resolve dependencies, including archived tasks
+//
+// Returns:
+//   - *context.Task: Task to work on next, or nil if none is ready
+//   - int: Number of pending tasks that are not ready
+func nextTask(
+	doc *context.Document, idx *context.Index,
+) (*context.Task, int) {
+	groups := make(map[string]int)
+	var (
+		best      *context.Task
+		bestGroup int
+		bestRank  int
+		waiting   int
+	)
+
+	for _, t := range doc.Tasks() {
+		group := t.Phase
+		if group == "" {
+			group = "\x00" + t.Section
+		}
+		if _, ok := groups[group]; !ok {
+			groups[group] = len(groups)
+		}
+
+		if t.State() != context.TaskPending {
+			continue
+		}
+		if !isReady(t, idx) {
+			waiting++
+			continue
+		}
+
+		g, rank := groups[group], priorityRank(t)
+		if best == nil || g < bestGroup || (g == bestGroup && rank < bestRank) {
+			best, bestGroup, bestRank = t, g, rank
+		}
+	}
+
+	if best != nil {
+		waiting = 0
+	}
+	return best, waiting
+}
+
+// isReady reports whether a pending task can be picked up.
+//
+// Parameters:
+//   - t: Pending task to check
+//   - idx: Index used to resolve dependencies
+//
+// Returns:
+//   - bool: True if the task is not in progress, has no pending
+//     subtasks, and neither it nor its parents are blocked or waiting
+func isReady(t *context.Task, idx *context.Index) bool {
+	if t.HasLabel(context.LabelInProgress) {
+		return false
+	}
+	for _, sub := range t.Walk()[1:] {
+		if sub.State() == context.TaskPending {
+			return false
+		}
+	}
+	for a := t; a != nil; a = a.Parent {
+		if a.HasLabel(context.LabelBlocked) {
+			return false
+		}
+		for _, dep := range a.Dependencies() {
+			if !isDone(dep, idx) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isDone reports whether the task with the given ID is done, either
+// checked off in TASKS.md or archived as completed.
+//
+// Parameters:
+//   - id: Task ID
+//   - idx: Index to look the task up in
+//
+// Returns:
+//   - bool: False if the task is pending, skipped, or does not exist
+func isDone(id string, idx *context.Index) bool {
+	e, ok := idx.Lookup(id)
+	if !ok {
+		return false
+	}
+	t, ok := e.Entry.(*context.Task)
+	return ok && t.State() == context.TaskDone
+}
+
+// priorityRank returns the rank of a task's priority, inherited from the
+// nearest parent when the task has none.
+//
+// Parameters:
+//   - t: Task to rank
+//
+// Returns:
+//   - int: Rank from priorityRanks; medium if no priority is set
+func priorityRank(t *context.Task) int {
+	for a := t; a != nil; a = a.Parent {
+		if p, ok := a.Label(context.LabelPriority); ok {
+			if rank, known := priorityRanks[strings.ToLower(p)]; known {
+				return rank
+			}
+		}
+	}
+	return priorityRanks["medium"]
+}
@@ -7,105 +7,89 @@
 package task
 
 import (
-	"bufio"
-	"regexp"
 	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
 )
 
 // separateTasks parses TASKS.md and separates completed from pending tasks.
 //
-// The function scans TASKS.md line by line, identifying task items by their
-// checkbox markers ([x] for completed, [ ] for pending). It preserves phase
-// headers (### Phase ...) in the archived content for traceability.
-//
-// Subtasks (indented task items) follow their parent task:
-//   - Subtasks of completed tasks are archived with the parent
-//   - Subtasks of pending tasks remain with the parent
+// Tasks are archived as whole subtrees: a top-level task moves to the
+// archive, with its subtasks, only when it is done and none of its
+// subtasks is still pending. Completed subtasks of an open task stay with
+// their parent, and a completed task with open subtasks stays in place.
+// Phase headers (### Phase ...) are repeated in the archived content for
+// traceability.
 //
 // Parameters:
 //   - content: Full content of TASKS.md as a string
 //
 // Returns:
-//   - remaining: Content with only pending tasks (to write back to TASKS.md)
+//   - remaining: Content without the archived tasks (to write back to
+//     TASKS.md)
 //   - archived: Content with completed tasks and their phase headers
-//   - stats: Counts of completed and pending tasks processed
+//   - stats: Counts of archived, pending, and held-back tasks
 func separateTasks(content string) (string, string, taskStats) {
-	var remaining strings.Builder
-	var archived strings.Builder
 	var stats taskStats
+	doc := context.ParseDocument(config.FilenameTask, []byte(content))
 
-	// Track the current phase header
-	var currentPhase string
-	var phaseHasArchivedTasks bool
-	var phaseArchiveBuffer strings.Builder
-
-	completedPattern := regexp.MustCompile(`^\s*-\s*\[x\]`)
-	pendingPattern := regexp.MustCompile(`^\s*-\s*\[\s*\]`)
-	phasePattern := regexp.MustCompile(`^###\s+Phase`)
-	subTaskPattern := regexp.MustCompile(`^\s{2,}-\s*\[`)
-
-	scanner := bufio.NewScanner(strings.NewReader(content))
-	var inCompletedTask bool
-
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		// Check for phase headers
-		if phasePattern.MatchString(line) {
-			// Flush previous phase's archived tasks
-			if phaseHasArchivedTasks {
-				archived.WriteString(currentPhase + "\n")
-				archived.WriteString(phaseArchiveBuffer.String())
-				archived.WriteString("\n")
-			}
-
-			currentPhase = line
-			phaseHasArchivedTasks = false
-			phaseArchiveBuffer.Reset()
-			remaining.WriteString(line + "\n")
-			inCompletedTask = false
+	var (
+		archive []*context.Task
+		phases  []string
+		byPhase = make(map[string][]*context.Task)
+	)
+	for _, e := range doc.Entries() {
+		t, ok := e.(*context.Task)
+		if !ok {
 			continue
 		}
-
-		// Check for completed tasks
-		if completedPattern.MatchString(line) {
-			stats.completed++
-			phaseHasArchivedTasks = true
-			phaseArchiveBuffer.WriteString(line + "\n")
-			inCompletedTask = true
-			continue
+		for _, sub := range t.Walk() {
+			if sub.State() == context.TaskPending {
+				stats.pending++
+			}
 		}
-
-		// Check for pending tasks
-		if pendingPattern.MatchString(line) {
-			stats.pending++
-			remaining.WriteString(line + "\n")
-			inCompletedTask = false
+		if !t.IsComplete() {
+			if t.State() == context.TaskDone {
+				stats.held++
+			}
 			continue
 		}
 
-		// Handle subtasks (indented task items)
-		if subTaskPattern.MatchString(line) {
-			if inCompletedTask {
-				// Subtask of a completed task - archive it
-				phaseArchiveBuffer.WriteString(line + "\n")
-			} else {
-				// Subtask of a pending task - keep it
-				remaining.WriteString(line + "\n")
+		for _, sub := range t.Walk() {
+			if sub.State() == context.TaskDone {
+				stats.completed++
 			}
-			continue
 		}
+		archive = append(archive, t)
+		if _, seen := byPhase[t.Phase]; !seen {
+			phases = append(phases, t.Phase)
+		}
+		byPhase[t.Phase] = append(byPhase[t.Phase], t)
+	}
 
-		// Non-task lines go to the remaining
-		remaining.WriteString(line + "\n")
-		inCompletedTask = false
+	if len(archive) == 0 {
+		return content, "", stats
 	}
+	// The tasks are top-level entries of doc, so removal cannot fail
+	_ = doc.RemoveTasks(archive...)
 
-	// Flush final phase's archived tasks
-	if phaseHasArchivedTasks {
-		archived.WriteString(currentPhase + "\n")
-		archived.WriteString(phaseArchiveBuffer.String())
+	var archived strings.Builder
+	for i, phase := range phases {
+		if i > 0 {
+			archived.WriteString("\n")
+		}
+		if phase != "" {
+			archived.WriteString("### " + phase + "\n")
+		}
+		for _, t := range byPhase[phase] {
+			text := t.String()
+			archived.WriteString(text)
+			if !strings.HasSuffix(text, "\n") {
+				archived.WriteString("\n")
+			}
+		}
 	}
 
-	return remaining.String(), archived.String(), stats
+	return doc.String(), archived.String(), stats
 }
@@ -82,8 +82,9 @@ func runTasksSnapshot(cmd *cobra.Command, args []string) error {
 // runTaskArchive executes the archive subcommand logic.
 //
 // Moves completed tasks (marked with [x]) from TASKS.md to a timestamped
-// archive file. Pending tasks ([ ]) remain in TASKS.md. If an archive file
-// for the current date already exists, completed tasks are appended to it.
+// archive file. Pending tasks ([ ]) remain in TASKS.md, and so do
+// completed tasks with open subtasks. If an archive file for the current
+// date already exists, completed tasks are appended to it.
 //
 // Parameters:
 //   - cmd: Cobra command (unused, for interface compliance)
@@ -113,6 +114,7 @@ func runTaskArchive(cmd *cobra.Command, dryRun bool) error {
 
 	if stats.completed == 0 {
 		fmt.Println("No completed tasks to archive.")
+		printHeldTasks(stats)
 		return nil
 	}
 
@@ -123,6 +125,7 @@ func runTaskArchive(cmd *cobra.Command, dryRun bool) error {
 			"Would archive %d completed tasks (keeping %d pending)\n",
 			stats.completed, stats.pending,
 		)
+		printHeldTasks(stats)
 		fmt.Println()
 		fmt.Println("Archived content preview:")
 		fmt.Println("---")
@@ -174,6 +177,23 @@ func runTaskArchive(cmd *cobra.Command, dryRun bool) error {
 		archiveFilePath,
 	)
 	fmt.Printf("  %d pending tasks remain in TASKS.md\n", stats.pending)
+	printHeldTasks(stats)
 
 	return nil
 }
+
+// printHeldTasks reports completed tasks that were not archived because
+// they still have open subtasks.
+//
+// Parameters:
+//   - stats: Counts from separateTasks
+func printHeldTasks(stats taskStats) {
+	if stats.held == 0 {
+		return
+	}
+	yellow := color.New(color.FgYellow).SprintFunc()
+	fmt.Printf(
+		"%s %d completed tasks kept in TASKS.md: they have open subtasks\n",
+		yellow("○"), stats.held,
+	)
+}
@@ -4,24 +4,37 @@
 //   \    Copyright 2026-present Context contributors.
 //                 SPDX-License-Identifier: Apache-2.0
 
-// Package task implements the "ctx tasks" command for managing task archival
-// and snapshots.
+// Package task implements the "ctx tasks" command for working with tasks,
+// their archival, and snapshots.
 //
 // The task package provides subcommands to:
+//   - list: Show tasks filtered by state, label, and phase
+//   - next: Pick the task to work on next
+//   - start, block, unblock, skip: Move a task through its workflow
+//   - priority: Set or clear a task's priority label
+//   - move: Place an unphased task into a phase
 //   - archive: Move completed tasks to timestamped archive files
 //   - snapshot: Create point-in-time copies of TASKS.md
 //
+// Workflow commands edit TASKS.md in place and respect its structure
+// rules: tasks never move out of their Phase.
+//
 // Archive files preserve phase structure for traceability, while snapshots
 // copy the entire file as-is without modification.
 package task
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/context"
 )
 
 // Cmd returns the tasks command with subcommands.
 //
 // The tasks command provides utilities for managing the task lifecycle:
+//   - list: Show tasks with filters
+//   - next: Pick the next ready task
+//   - start, block, unblock, skip, priority, move: Update a task in place
 //   - archive: Move completed tasks out of TASKS.md
 //   - snapshot: Create point-in-time backup without modification
 //
@@ -29,19 +42,42 @@ import (
 //   - *cobra.Command: Configured tasks command with subcommands
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "tasks",
-		Short: "Manage task archival and snapshots",
-		Long: `Manage task archival and snapshots.
+		Use:     "tasks",
+		Aliases: []string{"task"},
+		Short:   "Manage tasks, task archival, and snapshots",
+		Long: `Manage tasks in TASKS.md, their archival, and snapshots.
+
+Tasks are selected by ID (e.g., T-107), by number as shown by 'ctx status',
+or by a unique part of their text. Workflow commands edit the task in
+place with inline labels (#in-progress, #blocked, #priority:high) and
+notes ("Blocked: ...", "Skipped: ..."); tasks never move out of their
+Phase. Tasks declare dependencies with #after:T-012 labels.
 
 Tasks can be archived to move completed items out of TASKS.md while
 preserving them for historical reference. Snapshots create point-in-time
 copies without modifying the original.
 
 Subcommands:
+  list      List tasks filtered by state, label, and phase
+  next      Show the highest-priority task that is ready to start
+  start     Mark a task #in-progress
+  block     Mark a task #blocked, with an optional reason
+  unblock   Remove the #blocked label and reason
+  skip      Mark a task skipped [-] with a reason
+  priority  Set or clear a task's #priority label
+  move      Move an unphased task into a phase
   archive   Move completed tasks to timestamped archive file
   snapshot  Create point-in-time snapshot of TASKS.md`,
 	}
 
+	cmd.AddCommand(listCmd())
+	cmd.AddCommand(nextCmd())
+	cmd.AddCommand(startCmd())
+	cmd.AddCommand(blockCmd())
+	cmd.AddCommand(unblockCmd())
+	cmd.AddCommand(skipCmd())
+	cmd.AddCommand(priorityCmd())
+	cmd.AddCommand(moveCmd())
 	cmd.AddCommand(archiveCmd())
 	cmd.AddCommand(snapshotCmd())
 
@@ -52,7 +88,8 @@ Subcommands:
 //
 // The archive command moves completed tasks (marked with [x]) from TASKS.md
 // to a timestamped archive file in .context/archive/. Pending tasks ([ ])
-// remain in TASKS.md.
+// remain in TASKS.md, and a task is only archived with all of its
+// subtasks closed.
 //
 // Flags:
 //   - --dry-run: Preview changes without modifying files
@@ -71,7 +108,9 @@ Archive files are stored in .context/archive/ with timestamped names:
   .context/archive/tasks-YYYY-MM-DD.md
 
 The archive preserves Phase structure for traceability. Completed tasks
-(marked with [x]) are moved; pending tasks ([ ]) remain in TASKS.md.
+(marked with [x]) are moved with their subtasks; pending tasks ([ ])
+remain in TASKS.md. A completed task with open subtasks stays until
+they are done, and completed subtasks stay with an open parent.
 
 Use --dry-run to preview changes without modifying files.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -116,3 +155,249 @@ Unlike archive, snapshot copies the entire file as-is.`,
 
 	return cmd
 }
+
+// listCmd returns the tasks list subcommand.
+//
+// Flags:
+//   - --state: Task state to show (pending, done, skipped, all)
+//   - --label: Required label, as "name" or "name:value"; repeatable
+//   - --phase: Only tasks whose phase heading contains this text
+//   - --json: Output as JSON
+//
+// Returns:
+//   - *cobra.Command: Configured list subcommand
+func listCmd() *cobra.Command {
+	var (
+		filters    listFilters
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List tasks filtered by state, label, and phase",
+		Long: `List tasks from TASKS.md, grouped by phase or section.
+
+By default only pending tasks are shown. Filters combine: a task is
+listed only if it passes all of them.
+
+Examples:
+  ctx tasks list
+  ctx tasks list --state all --phase "Phase 2"
+  ctx tasks list --label in-progress
+  ctx tasks list --label priority:high --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskList(cmd, filters, jsonOutput)
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&filters.state, "state", string(context.TaskPending),
+		"Task state to show: pending, done, skipped, or all",
+	)
+	cmd.Flags().StringArrayVar(
+		&filters.labels, "label", nil,
+		"Only tasks with this label (name or name:value); repeatable",
+	)
+	cmd.Flags().StringVar(
+		&filters.phase, "phase", "",
+		"Only tasks in phases whose heading contains this text",
+	)
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+// nextCmd returns the tasks next subcommand.
+//
+// Flags:
+//   - --json: Output as JSON
+//
+// Returns:
+//   - *cobra.Command: Configured next subcommand
+func nextCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "next",
+		Short: "Show the highest-priority task that is ready to start",
+		Long: `Show the pending task to work on next.
+
+A task is ready when it is not #in-progress or #blocked, has no pending
+subtasks, and every task named in its #after labels is done (checked off
+or archived). Dependencies and #blocked labels of a parent task apply to
+its subtasks.
+
+Ready tasks are picked in phase order: earlier phases (or sections,
+outside phases) in TASKS.md come first. Within a phase, #priority:high
+beats medium beats low; tasks without a priority rank as medium. Ties
+go to the task that comes first in the file.
+
+Declare dependencies with one or more IDs:
+  - [ ] Wire up the importer #id:T-014 #after:T-012,T-013
+
+With --json, the task is printed as a JSON object, or null if no task is
+ready, for use in loop prompts.
+
+Examples:
+  ctx tasks next
+  ctx tasks next --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskNext(cmd, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+// startCmd returns the tasks start subcommand.
+//
+// Returns:
+//   - *cobra.Command: Configured start subcommand
+func startCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "start <task>",
+		Short: "Mark a task #in-progress",
+		Long: `Mark a pending task as being worked on by adding #in-progress.
+
+The task stays where it is. Blocked tasks must be unblocked first.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskUpdate(cmd, args[0], startTask)
+		},
+	}
+}
+
+// blockCmd returns the tasks block subcommand.
+//
+// Flags:
+//   - --reason: Why the task is blocked
+//
+// Returns:
+//   - *cobra.Command: Configured block subcommand
+func blockCmd() *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:   "block <task>",
+		Short: "Mark a task #blocked",
+		Long: `Mark a pending task as blocked by adding #blocked.
+
+The #in-progress label is removed. With --reason, the reason is recorded
+in a "Blocked:" note below the task.
+
+Example:
+  ctx tasks block T-107 --reason "Waiting for API credentials"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskUpdate(cmd, args[0], blockTask(reason))
+		},
+	}
+
+	cmd.Flags().StringVar(&reason, "reason", "", "Why the task is blocked")
+
+	return cmd
+}
+
+// unblockCmd returns the tasks unblock subcommand.
+//
+// Returns:
+//   - *cobra.Command: Configured unblock subcommand
+func unblockCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unblock <task>",
+		Short: "Remove the #blocked label and reason",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskUpdate(cmd, args[0], unblockTask)
+		},
+	}
+}
+
+// skipCmd returns the tasks skip subcommand.
+//
+// Flags:
+//   - --reason: Why the task is skipped (required)
+//
+// Returns:
+//   - *cobra.Command: Configured skip subcommand
+func skipCmd() *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:   "skip <task> --reason <reason>",
+		Short: "Mark a task skipped [-] with a reason",
+		Long: `Mark a pending task as skipped by changing "- [ ]" to "- [-]".
+
+Skipped tasks always carry a reason, recorded in a "Skipped:" note below
+the task. Workflow labels (#in-progress, #blocked) are removed.
+
+Example:
+  ctx tasks skip T-107 --reason "Superseded by the new importer"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskUpdate(cmd, args[0], skipTask(reason))
+		},
+	}
+
+	cmd.Flags().StringVar(&reason, "reason", "", "Why the task is skipped")
+	_ = cmd.MarkFlagRequired("reason")
+
+	return cmd
+}
+
+// priorityCmd returns the tasks priority subcommand.
+//
+// Returns:
+//   - *cobra.Command: Configured priority subcommand
+func priorityCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "priority <task> <high|medium|low|none>",
+		Short: "Set or clear a task's #priority label",
+		Long: `Set a task's #priority label, or remove it with "none".
+
+Examples:
+  ctx tasks priority T-107 high
+  ctx tasks priority "flaky test" none`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskUpdate(cmd, args[0], prioritizeTask(args[1]))
+		},
+	}
+}
+
+// moveCmd returns the tasks move subcommand.
+//
+// Flags:
+//   - --phase: Target phase heading, or an unambiguous part of it (required)
+//
+// Returns:
+//   - *cobra.Command: Configured move subcommand
+func moveCmd() *cobra.Command {
+	var phase string
+
+	cmd := &cobra.Command{
+		Use:   "move <task> --phase <phase>",
+		Short: "Move an unphased task into a phase",
+		Long: `Move a top-level task, with its subtasks, to the end of a phase.
+
+Tasks never move out of their Phase: only tasks outside any phase, such
+as those added under "Next Up", can be moved. Subtasks move with their
+parent.
+
+Example:
+  ctx tasks move T-107 --phase "Phase 2"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskUpdate(cmd, args[0], moveTask(phase))
+		},
+	}
+
+	cmd.Flags().StringVar(&phase, "phase", "", "Target phase heading")
+	_ = cmd.MarkFlagRequired("phase")
+
+	return cmd
+}
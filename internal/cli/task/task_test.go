@@ -7,6 +7,8 @@
 package task
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"strings"
 	"testing"
@@ -41,6 +43,24 @@ func TestSeparateTasks(t *testing.T) {
 			expectedCompleted: 0,
 			expectedPending:   2,
 		},
+		{
+			name:              "completed subtask of pending parent stays",
+			input:             "# Tasks\n\n- [ ] Parent\n  - [x] Child\n",
+			expectedCompleted: 0,
+			expectedPending:   1,
+		},
+		{
+			name:              "completed parent with open subtask stays",
+			input:             "# Tasks\n\n- [x] Parent\n  - [ ] Child\n- [x] Done\n",
+			expectedCompleted: 1,
+			expectedPending:   1,
+		},
+		{
+			name:              "complete subtree is archived",
+			input:             "# Tasks\n\n- [x] Parent\n  - [x] Child\n  - [-] Skipped\n",
+			expectedCompleted: 2,
+			expectedPending:   0,
+		},
 		{
 			name:              "no tasks",
 			input:             "# Tasks\n\nNo tasks here.\n",
@@ -62,6 +82,32 @@ func TestSeparateTasks(t *testing.T) {
 	}
 }
 
+// TestSeparateTasksSubtrees tests that archiving keeps task trees intact.
+func TestSeparateTasksSubtrees(t *testing.T) {
+	input := "# Tasks\n\n### Phase 1\n" +
+		"- [x] Open parent\n  - [ ] Open child\n" +
+		"- [x] Done parent\n  - [x] Done child\n\n" +
+		"### Phase 2\n" +
+		"- [ ] Pending parent\n  - [x] Done child of pending\n"
+
+	remaining, archived, stats := separateTasks(input)
+
+	wantRemaining := "# Tasks\n\n### Phase 1\n" +
+		"- [x] Open parent\n  - [ ] Open child\n\n" +
+		"### Phase 2\n" +
+		"- [ ] Pending parent\n  - [x] Done child of pending\n"
+	if remaining != wantRemaining {
+		t.Errorf("remaining =\n%s\nwant:\n%s", remaining, wantRemaining)
+	}
+	wantArchived := "### Phase 1\n- [x] Done parent\n  - [x] Done child\n"
+	if archived != wantArchived {
+		t.Errorf("archived =\n%s\nwant:\n%s", archived, wantArchived)
+	}
+	if stats.held != 1 {
+		t.Errorf("held = %d, want 1", stats.held)
+	}
+}
+
 // TestTasksCommands tests the tasks subcommands.
 func TestTasksCommands(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "cli-tasks-test-*")
@@ -124,3 +170,240 @@ func TestTasksCommands(t *testing.T) {
 		}
 	})
 }
+
+// TestTaskWorkflow tests the task workflow subcommands.
+func TestTaskWorkflow(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cli-tasks-workflow-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	tasks := "# Tasks\n\n" +
+		"### Phase 1: Setup\n\n- [ ] Configure CI #id:T-001\n\n" +
+		"### Phase 2: Build\n\n- [ ] Write parser #id:T-002\n\n" +
+		"## Next Up\n\n- [ ] Flaky test #id:T-003\n" +
+		"- [ ] Dead code #id:T-004\n"
+	if err := os.WriteFile(".context/TASKS.md", []byte(tasks), 0644); err != nil {
+		t.Fatalf("failed to write TASKS.md: %v", err)
+	}
+
+	run := func(args ...string) error {
+		cmd := Cmd()
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+	read := func() string {
+		data, err := os.ReadFile(".context/TASKS.md")
+		if err != nil {
+			t.Fatalf("failed to read TASKS.md: %v", err)
+		}
+		return string(data)
+	}
+
+	steps := [][]string{
+		{"start", "T-001"},
+		{"block", "T-001", "--reason", "Waiting for credentials"},
+		{"skip", "dead code", "--reason", "Removed upstream"},
+		{"priority", "T-002", "high"},
+		{"move", "T-003", "--phase", "Phase 2"},
+	}
+	for _, args := range steps {
+		if err := run(args...); err != nil {
+			t.Fatalf("tasks %v failed: %v", args, err)
+		}
+	}
+
+	want := "# Tasks\n\n" +
+		"### Phase 1: Setup\n\n- [ ] Configure CI #id:T-001 #blocked\n" +
+		"  Blocked: Waiting for credentials\n\n" +
+		"### Phase 2: Build\n\n- [ ] Write parser #id:T-002 #priority:high\n" +
+		"- [ ] Flaky test #id:T-003\n\n" +
+		"## Next Up\n\n- [-] Dead code #id:T-004\n  Skipped: Removed upstream\n"
+	if got := read(); got != want {
+		t.Fatalf("TASKS.md after workflow:\n%s\nwant:\n%s", got, want)
+	}
+
+	if err := run("start", "T-001"); err == nil {
+		t.Error("starting a blocked task should fail")
+	}
+	if err := run("move", "T-003", "--phase", "Phase 1"); err == nil {
+		t.Error("moving a task out of its phase should fail")
+	}
+	if err := run("priority", "T-002", "urgent"); err == nil {
+		t.Error("unknown priority should fail")
+	}
+
+	if err := run("unblock", "T-001"); err != nil {
+		t.Fatalf("tasks unblock failed: %v", err)
+	}
+	if got := read(); strings.Contains(got, "#blocked") ||
+		strings.Contains(got, "Blocked:") {
+		t.Errorf("unblock left block markers:\n%s", got)
+	}
+
+	t.Run("list", func(t *testing.T) {
+		cmd := Cmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{
+			"list", "--state", "all", "--phase", "Phase 2", "--json",
+		})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("tasks list failed: %v", err)
+		}
+
+		var items []taskItem
+		if err := json.Unmarshal(out.Bytes(), &items); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, out.String())
+		}
+		if len(items) != 2 || items[0].ID != "T-002" || items[1].ID != "T-003" {
+			t.Fatalf("list --phase returned %+v", items)
+		}
+		if items[0].Labels["priority"] != "high" {
+			t.Errorf("labels = %v, want priority:high", items[0].Labels)
+		}
+
+		cmd = Cmd()
+		out.Reset()
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"list", "--state", "skipped"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("tasks list failed: %v", err)
+		}
+		if !strings.Contains(out.String(), "Dead code") ||
+			!strings.Contains(out.String(), "Skipped: Removed upstream") ||
+			strings.Contains(out.String(), "Write parser") {
+			t.Errorf("list --state skipped output:\n%s", out.String())
+		}
+	})
+}
+
+// TestNextTask tests how "ctx tasks next" picks a task.
+func TestNextTask(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cli-tasks-next-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	next := func() *taskItem {
+		cmd := Cmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"next", "--json"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("tasks next failed: %v", err)
+		}
+		var item *taskItem
+		if err := json.Unmarshal(out.Bytes(), &item); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, out.String())
+		}
+		return item
+	}
+
+	tests := []struct {
+		name  string
+		tasks string
+		want  string
+	}{
+		{
+			name: "earlier phase wins over priority",
+			tasks: "### Phase 1\n- [ ] Low #id:T-001 #priority:low\n" +
+				"### Phase 2\n- [ ] High #id:T-002 #priority:high\n",
+			want: "T-001",
+		},
+		{
+			name: "priority within a phase",
+			tasks: "### Phase 1\n- [ ] Plain #id:T-001\n" +
+				"- [ ] Urgent #id:T-002 #priority:high\n" +
+				"- [ ] Later #id:T-003 #priority:low\n",
+			want: "T-002",
+		},
+		{
+			name: "skips blocked, in-progress, and waiting tasks",
+			tasks: "### Phase 1\n- [ ] Blocked #id:T-001 #blocked\n" +
+				"- [ ] Busy #id:T-002 #in-progress\n" +
+				"- [ ] Waiting #id:T-003 #after:T-005\n" +
+				"- [ ] Done first #id:T-004 #after:T-006\n" +
+				"- [ ] Pending dep #id:T-005\n" +
+				"- [x] Finished #id:T-006\n",
+			want: "T-004",
+		},
+		{
+			name: "dependency on archived task is met",
+			tasks: "### Phase 1\n- [ ] Follow-up #id:T-002 #after:T-001\n" +
+				"- [ ] Other #id:T-003\n",
+			want: "T-002",
+		},
+		{
+			name: "subtasks before their parent and inherit its dependencies",
+			tasks: "### Phase 1\n- [ ] Parent #id:T-001 #after:T-009\n" +
+				"  - [ ] Child #id:T-002\n" +
+				"- [ ] Feature #id:T-003\n" +
+				"  - [ ] Part #id:T-004\n",
+			want: "T-004",
+		},
+		{
+			name:  "nothing ready",
+			tasks: "### Phase 1\n- [ ] Blocked #id:T-001 #blocked\n",
+			want:  "",
+		},
+	}
+
+	archive := "# Archived Tasks\n\n- [x] Setup #id:T-001\n"
+	if err := os.MkdirAll(".context/archive", 0755); err != nil {
+		t.Fatalf("failed to create archive dir: %v", err)
+	}
+	if err := os.WriteFile(
+		".context/archive/tasks-2026-01-01.md", []byte(archive), 0644,
+	); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "# Tasks\n\n" + tt.tasks
+			if err := os.WriteFile(
+				".context/TASKS.md", []byte(content), 0644,
+			); err != nil {
+				t.Fatalf("failed to write TASKS.md: %v", err)
+			}
+
+			item := next()
+			switch {
+			case tt.want == "" && item != nil:
+				t.Errorf("next = %s, want none", item.ID)
+			case tt.want != "" && item == nil:
+				t.Errorf("next = none, want %s", tt.want)
+			case item != nil && item.ID != tt.want:
+				t.Errorf("next = %s, want %s", item.ID, tt.want)
+			}
+		})
+	}
+}
@@ -12,9 +12,54 @@ package task
 // an archive operation.
 //
 // Fields:
-//   - completed: Number of tasks marked with [x]
-//   - pending: Number of tasks marked with [ ]
+//   - completed: Number of tasks marked with [x] that were archived,
+//     subtasks included
+//   - pending: Number of tasks marked with [ ], subtasks included
+//   - held: Number of completed top-level tasks kept because they have
+//     open subtasks
 type taskStats struct {
 	completed int
 	pending   int
+	held      int
+}
+
+// listFilters holds the filter flags of "ctx tasks list".
+//
+// Fields:
+//   - state: Task state to include (pending, done, skipped, or all)
+//   - labels: Labels the task must carry, as "name" or "name:value"
+//   - phase: Text the task's phase heading must contain
+type listFilters struct {
+	state  string
+	labels []string
+	phase  string
+}
+
+// taskItem is a task as reported by "ctx tasks list --json" and
+// "ctx tasks next --json".
+//
+// Fields:
+//   - ID: Stable task ID, if the task has one
+//   - Title: Task text without labels
+//   - State: pending, done, or skipped
+//   - Phase: Enclosing phase heading, if any
+//   - Section: Nearest enclosing heading
+//   - Labels: Label names mapped to their values ("" for bare labels)
+//   - Notes: Note names mapped to their text, e.g. "Blocked"
+//   - After: IDs of the tasks this task depends on
+//   - Parent: ID of the parent task, for subtasks with an identified parent
+//   - Depth: Nesting level; 0 for top-level tasks
+//   - Line: Line of the task in TASKS.md
+type taskItem struct {
+	ID      string            `json:"id,omitempty"`
+	Title   string            `json:"title"`
+	State   string            `json:"state"`
+	Phase   string            `json:"phase,omitempty"`
+	Section string            `json:"section,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Notes   map[string]string `json:"notes,omitempty"`
+	After   []string          `json:"after,omitempty"`
+	Parent  string            `json:"parent,omitempty"`
+	Depth   int               `json:"depth"`
+	Line    int               `json:"line"`
 }
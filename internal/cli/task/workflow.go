@@ -0,0 +1,310 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// priorityLevels lists the values accepted by "ctx tasks priority".
+var priorityLevels = []string{"high", "medium", "low"}
+
+// loadTasks reads and parses TASKS.md.
+//
+// Returns:
+//   - *context.Document: Parsed TASKS.md
+//   - error: Non-nil if the context directory or the file is missing
+func loadTasks() (*context.Document, error) {
+	if !context.Exists("") {
+		return nil, fmt.Errorf(
+			"no .context/ directory found. Run 'ctx init' first",
+		)
+	}
+	doc, err := context.LoadDocument("", config.FilenameTask)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TASKS.md: %w", err)
+	}
+	return doc, nil
+}
+
+// runTaskUpdate finds a task, applies an update, and writes TASKS.md.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - query: Task ID, number, or search text
+//   - update: Function that edits the task and returns a confirmation
+//     message, or an error if the update does not apply
+//
+// Returns:
+//   - error: Non-nil if the task is not found, the update fails, or the
+//     file cannot be written
+func runTaskUpdate(
+	cmd *cobra.Command, query string,
+	update func(doc *context.Document, t *context.Task) (string, error),
+) error {
+	doc, err := loadTasks()
+	if err != nil {
+		return err
+	}
+
+	t, err := FindTask(doc, query)
+	if err != nil {
+		return err
+	}
+
+	msg, err := update(doc, t)
+	if err != nil {
+		return err
+	}
+
+	if err := doc.Save(); err != nil {
+		return fmt.Errorf("failed to write TASKS.md: %w", err)
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cmd.Printf("%s %s\n", green("✓"), msg)
+	return nil
+}
+
+// requirePending returns an error unless the task is pending.
+//
+// Parameters:
+//   - t: Task to check
+//
+// Returns:
+//   - error: Non-nil if the task is done or skipped
+func requirePending(t *context.Task) error {
+	if state := t.State(); state != context.TaskPending {
+		return fmt.Errorf("task %s is %s", taskName(t), state)
+	}
+	return nil
+}
+
+// startTask labels a pending, unblocked task #in-progress.
+//
+// Parameters:
+//   - _: Unused document
+//   - t: Task to start
+//
+// Returns:
+//   - string: Confirmation message
+//   - error: Non-nil if the task is not pending or is blocked
+func startTask(_ *context.Document, t *context.Task) (string, error) {
+	if err := requirePending(t); err != nil {
+		return "", err
+	}
+	if t.HasLabel(context.LabelBlocked) {
+		return "", fmt.Errorf(
+			"task %s is blocked. Run 'ctx tasks unblock' first", taskName(t),
+		)
+	}
+	t.SetLabel(context.LabelInProgress, "")
+	return "Started: " + t.Title(), nil
+}
+
+// blockTask returns a function that labels a pending task #blocked.
+//
+// The task stops being #in-progress. A reason, if given, is recorded in
+// a "Blocked:" note below the task.
+//
+// Parameters:
+//   - reason: Why the task is blocked; may be empty
+//
+// Returns:
+//   - func: Update for runTaskUpdate
+func blockTask(
+	reason string,
+) func(*context.Document, *context.Task) (string, error) {
+	return func(_ *context.Document, t *context.Task) (string, error) {
+		if err := requirePending(t); err != nil {
+			return "", err
+		}
+		t.RemoveLabel(context.LabelInProgress)
+		t.SetLabel(context.LabelBlocked, "")
+		if reason != "" {
+			t.SetNote(context.NoteBlocked, reason)
+		}
+		return "Blocked: " + t.Title(), nil
+	}
+}
+
+// unblockTask removes the #blocked label and the "Blocked:" note.
+//
+// Parameters:
+//   - _: Unused document
+//   - t: Task to unblock
+//
+// Returns:
+//   - string: Confirmation message
+//   - error: Non-nil if the task is not blocked
+func unblockTask(_ *context.Document, t *context.Task) (string, error) {
+	if !t.HasLabel(context.LabelBlocked) {
+		return "", fmt.Errorf("task %s is not blocked", taskName(t))
+	}
+	t.RemoveLabel(context.LabelBlocked)
+	t.RemoveNote(context.NoteBlocked)
+	return "Unblocked: " + t.Title(), nil
+}
+
+// skipTask returns a function that marks a pending task as skipped.
+//
+// The checkbox becomes "[-]", workflow labels and the "Blocked:" note are
+// dropped, and the reason is recorded in a "Skipped:" note.
+//
+// Parameters:
+//   - reason: Why the task is skipped
+//
+// Returns:
+//   - func: Update for runTaskUpdate
+func skipTask(
+	reason string,
+) func(*context.Document, *context.Task) (string, error) {
+	return func(_ *context.Document, t *context.Task) (string, error) {
+		if err := requirePending(t); err != nil {
+			return "", err
+		}
+		t.SetState(context.TaskSkipped)
+		t.RemoveLabel(context.LabelInProgress)
+		t.RemoveLabel(context.LabelBlocked)
+		t.RemoveNote(context.NoteBlocked)
+		t.SetNote(context.NoteSkipped, reason)
+		return "Skipped: " + t.Title(), nil
+	}
+}
+
+// prioritizeTask returns a function that sets the #priority label.
+//
+// Parameters:
+//   - level: One of priorityLevels, or "none" to remove the label
+//
+// Returns:
+//   - func: Update for runTaskUpdate
+func prioritizeTask(
+	level string,
+) func(*context.Document, *context.Task) (string, error) {
+	return func(_ *context.Document, t *context.Task) (string, error) {
+		level = strings.ToLower(level)
+		if level == "none" {
+			t.RemoveLabel(context.LabelPriority)
+			return "Cleared priority: " + t.Title(), nil
+		}
+		if !slices.Contains(priorityLevels, level) {
+			return "", fmt.Errorf(
+				"invalid priority %q. Valid levels: %s, none",
+				level, strings.Join(priorityLevels, ", "),
+			)
+		}
+		t.SetLabel(context.LabelPriority, level)
+		return fmt.Sprintf("Priority %s: %s", level, t.Title()), nil
+	}
+}
+
+// moveTask returns a function that moves a task into a phase.
+//
+// Tasks never move out of their Phase: only tasks outside any phase
+// (e.g., under "Next Up") can be moved, and subtasks move with their
+// parent.
+//
+// Parameters:
+//   - phase: Phase heading text, or an unambiguous part of it
+//
+// Returns:
+//   - func: Update for runTaskUpdate
+func moveTask(
+	phase string,
+) func(*context.Document, *context.Task) (string, error) {
+	return func(doc *context.Document, t *context.Task) (string, error) {
+		target, err := FindPhase(doc, phase)
+		if err != nil {
+			return "", err
+		}
+		if t.Parent != nil {
+			return "", fmt.Errorf(
+				"task %s is a subtask; subtasks move with their parent",
+				taskName(t),
+			)
+		}
+		if t.Phase == target {
+			return "", fmt.Errorf(
+				"task %s is already in %q", taskName(t), target,
+			)
+		}
+		if t.Phase != "" {
+			return "", fmt.Errorf(
+				"task %s belongs to %q; tasks never move out of their Phase",
+				taskName(t), t.Phase,
+			)
+		}
+
+		title := t.Title()
+		if err := doc.MoveTask(t, target); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Moved to %s: %s", target, title), nil
+	}
+}
+
+// FindPhase resolves a phase name against the phase headings of TASKS.md.
+//
+// An exact (case-insensitive) match wins; otherwise the name must be part
+// of exactly one phase heading.
+//
+// Parameters:
+//   - doc: Parsed TASKS.md
+//   - name: Phase heading text or part of it
+//
+// Returns:
+//   - string: Full phase heading text
+//   - error: Non-nil if no phase or more than one phase matches
+func FindPhase(doc *context.Document, name string) (string, error) {
+	phases := doc.Phases()
+	for _, p := range phases {
+		if strings.EqualFold(p, name) {
+			return p, nil
+		}
+	}
+
+	var matches []string
+	for _, p := range phases {
+		if strings.Contains(strings.ToLower(p), strings.ToLower(name)) {
+			matches = append(matches, p)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no phase matching %q in TASKS.md", name)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf(
+			"multiple phases match %q: %s", name, strings.Join(matches, "; "),
+		)
+	}
+}
+
+// taskName returns a short name for a task in messages: its ID if it has
+// one, its quoted title otherwise.
+//
+// Parameters:
+//   - t: Task to name
+//
+// Returns:
+//   - string: ID or quoted title
+func taskName(t *context.Task) string {
+	if t.ID() != "" {
+		return t.ID()
+	}
+	return fmt.Sprintf("%q", t.Title())
+}
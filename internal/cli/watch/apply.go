@@ -72,7 +72,7 @@ func applyCompleteUpdate(content string) error {
 	return runCompleteSilent(args)
 }
 
-// applyUpdate routes a context update to the appropriate handler.
+// ApplyUpdate routes a context update to the appropriate handler.
 //
 // Dispatches based on update type to add entries to context files
 // or mark tasks complete.
@@ -82,7 +82,7 @@ func applyCompleteUpdate(content string) error {
 //
 // Returns:
 //   - error: Non-nil if type is unknown or the handler fails
-func applyUpdate(update ContextUpdate) error {
+func ApplyUpdate(update ContextUpdate) error {
 	switch update.Type {
 	case config.UpdateTypeTask:
 		return applyTaskUpdate(update.Content)
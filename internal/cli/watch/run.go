@@ -10,8 +10,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
-	"regexp"
 	"strings"
 
 	"github.com/fatih/color"
@@ -95,26 +93,31 @@ func runAddSilent(args []string) error {
 		return fmt.Errorf("unknown type %q", fileType)
 	}
 
-	filePath := filepath.Join(config.DirContext, fileName)
+	filePath := config.ContextPath(fileName)
 
 	existing, err := os.ReadFile(filePath)
 	if err != nil {
 		return err
 	}
 
+	id, err := add.EntryID(fileType)
+	if err != nil {
+		return err
+	}
+
 	var entry string
 	switch fileType {
 	case config.UpdateTypeDecision, config.UpdateTypeDecisions:
 		// Watch command receives simple content from AI XML tags,
 		// use placeholders for ADR fields (CLI requires full format)
-		entry = add.FormatDecision(content,
+		entry = add.FormatDecision(id, content,
 			"[Context from watch - please update]",
 			"[Rationale from watch - please update]",
 			"[Consequences from watch - please update]")
 	case config.UpdateTypeTask, config.UpdateTypeTasks:
-		entry = add.FormatTask(content, "")
+		entry = add.FormatTask(content, "", id)
 	case config.UpdateTypeLearning, config.UpdateTypeLearnings:
-		entry = add.FormatLearning(content,
+		entry = add.FormatLearning(id, content,
 			"[Context from watch - please update]",
 			"[Lesson from watch - please update]",
 			"[Application from watch - please update]")
@@ -133,8 +136,9 @@ func runAddSilent(args []string) error {
 // and marks it as done by changing [ ] to [x].
 //
 // Parameters:
-//   - args: Slice where args[0] is the search query to match against
-//     task descriptions (case-insensitive substring match)
+//   - args: Slice where args[0] is a task ID (e.g., "T-107") or the
+//     search query to match against task descriptions (case-insensitive
+//     substring match)
 //
 // Returns:
 //   - error: Non-nil if args is empty, no matching task is found,
@@ -145,34 +149,30 @@ func runCompleteSilent(args []string) error {
 	}
 
 	query := args[0]
-	filePath := filepath.Join(config.DirContext, config.FilenameTask)
-
-	content, err := os.ReadFile(filePath)
+	doc, err := context.LoadDocument("", config.FilenameTask)
 	if err != nil {
 		return err
 	}
 
-	lines := strings.Split(string(content), "\n")
-	taskPattern := regexp.MustCompile(`^(\s*)-\s*\[\s*]\s*(.+)$`)
-
-	matchedLine := -1
-	for i, line := range lines {
-		matches := taskPattern.FindStringSubmatch(line)
-		if matches != nil {
-			taskText := matches[2]
-			if strings.Contains(strings.ToLower(taskText), strings.ToLower(query)) {
-				matchedLine = i
-				break
-			}
+	id, byID := context.NormalizeID(query)
+	for _, task := range doc.Tasks() {
+		if task.State() != context.TaskPending {
+			continue
+		}
+		var match bool
+		if byID {
+			taskID, _ := context.NormalizeID(task.ID())
+			match = taskID == id
+		} else {
+			match = strings.Contains(
+				strings.ToLower(task.Text()), strings.ToLower(query),
+			)
+		}
+		if match {
+			task.SetState(context.TaskDone)
+			return doc.Save()
 		}
 	}
 
-	if matchedLine == -1 {
-		return fmt.Errorf("no task matching %q found", query)
-	}
-
-	lines[matchedLine] = taskPattern.ReplaceAllString(
-		lines[matchedLine], "$1- [x] $2",
-	)
-	return os.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0644)
+	return fmt.Errorf("no task matching %q found", query)
 }
@@ -19,6 +19,31 @@ import (
 	"github.com/ActiveMemory/ctx/internal/config"
 )
 
+// updatePattern matches <context-update type="...">content</context-update>
+// tags.
+var updatePattern = regexp.MustCompile(
+	`<context-update\s+type="([^"]+)"[^>]*>([^<]+)</context-update>`,
+)
+
+// ParseUpdates extracts the context updates from a line of AI output.
+//
+// Parameters:
+//   - line: Text to scan for <context-update> tags
+//
+// Returns:
+//   - []ContextUpdate: Updates in order of appearance, with the type
+//     lowercased and the content trimmed
+func ParseUpdates(line string) []ContextUpdate {
+	var updates []ContextUpdate
+	for _, match := range updatePattern.FindAllStringSubmatch(line, -1) {
+		updates = append(updates, ContextUpdate{
+			Type:    strings.ToLower(match[1]),
+			Content: strings.TrimSpace(match[2]),
+		})
+	}
+	return updates
+}
+
 // processStream reads from a stream and applies context updates.
 //
 // Scans input line-by-line looking for <context-update> XML tags.
@@ -38,11 +63,6 @@ func processStream(cmd *cobra.Command, reader io.Reader) error {
 	buf := make([]byte, 0, 64*1024)
 	scanner.Buffer(buf, 1024*1024)
 
-	// Pattern to match context-update tags
-	updatePattern := regexp.MustCompile(
-		`<context-update\s+type="([^"]+)"[^>]*>([^<]+)</context-update>`,
-	)
-
 	green := color.New(color.FgGreen).SprintFunc()
 	yellow := color.New(color.FgYellow).SprintFunc()
 	cyan := color.New(color.FgCyan).SprintFunc()
@@ -55,43 +75,35 @@ func processStream(cmd *cobra.Command, reader io.Reader) error {
 		line := scanner.Text()
 
 		// Check for context-update commands
-		matches := updatePattern.FindAllStringSubmatch(line, -1)
-		for _, match := range matches {
-			if len(match) >= 3 {
-				update := ContextUpdate{
-					Type:    strings.ToLower(match[1]),
-					Content: strings.TrimSpace(match[2]),
-				}
-
-				if watchDryRun {
+		for _, update := range ParseUpdates(line) {
+			if watchDryRun {
+				cmd.Printf(
+					"%s Would apply: [%s] %s\n", yellow("○"),
+					update.Type, update.Content,
+				)
+			} else {
+				err := ApplyUpdate(update)
+				if err != nil {
 					cmd.Printf(
-						"%s Would apply: [%s] %s\n", yellow("○"),
-						update.Type, update.Content,
+						"%s Failed to apply [%s]: %v\n", color.RedString("✗"),
+						update.Type, err,
 					)
 				} else {
-					err := applyUpdate(update)
-					if err != nil {
-						cmd.Printf(
-							"%s Failed to apply [%s]: %v\n", color.RedString("✗"),
-							update.Type, err,
-						)
-					} else {
-						cmd.Printf(
-							"%s Applied: [%s] %s\n", green("✓"), update.Type, update.Content,
-						)
-						updateCount++
-						appliedUpdates = append(appliedUpdates, update)
+					cmd.Printf(
+						"%s Applied: [%s] %s\n", green("✓"), update.Type, update.Content,
+					)
+					updateCount++
+					appliedUpdates = append(appliedUpdates, update)
 
-						// Auto-save every N updates
-						if watchAutoSave && updateCount%config.WatchAutoSaveInterval == 0 {
-							if err := watchAutoSaveSession(appliedUpdates); err != nil {
-								cmd.Printf("%s Auto-save failed: %v\n", yellow("⚠"), err)
-							} else {
-								cmd.Printf(
-									"%s Auto-saved session after %d updates\n", cyan("📸"),
-									updateCount,
-								)
-							}
+					// Auto-save every N updates
+					if watchAutoSave && updateCount%config.WatchAutoSaveInterval == 0 {
+						if err := watchAutoSaveSession(appliedUpdates); err != nil {
+							cmd.Printf("%s Auto-save failed: %v\n", yellow("⚠"), err)
+						} else {
+							cmd.Printf(
+								"%s Auto-saved session after %d updates\n", cyan("📸"),
+								updateCount,
+							)
 						}
 					}
 				}
@@ -18,7 +18,7 @@ import (
 	"github.com/ActiveMemory/ctx/internal/config"
 )
 
-// TestApplyUpdate tests the applyUpdate function routing.
+// TestApplyUpdate tests the ApplyUpdate function routing.
 func TestApplyUpdate(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "watch-apply-test-*")
 	if err != nil {
@@ -79,7 +79,7 @@ func TestApplyUpdate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := applyUpdate(tt.update)
+			err := ApplyUpdate(tt.update)
 
 			if tt.expectError {
 				if err == nil {
@@ -89,7 +89,7 @@ func TestApplyUpdate(t *testing.T) {
 			}
 
 			if err != nil {
-				t.Fatalf("applyUpdate failed: %v", err)
+				t.Fatalf("ApplyUpdate failed: %v", err)
 			}
 
 			// Verify content was added
@@ -141,8 +141,8 @@ func TestApplyCompleteUpdate(t *testing.T) {
 
 	// Complete the task
 	update := ContextUpdate{Type: config.UpdateTypeComplete, Content: "authentication"}
-	if err := applyUpdate(update); err != nil {
-		t.Fatalf("applyUpdate failed: %v", err)
+	if err := ApplyUpdate(update); err != nil {
+		t.Fatalf("ApplyUpdate failed: %v", err)
 	}
 
 	// Verify task was marked complete
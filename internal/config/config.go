@@ -7,10 +7,13 @@ const (
 	DirClaude              = ".claude"
 	DirClaudeHooks         = ".claude/hooks"
 	DirContext             = ".context"
+	DirImport              = "import"
+	DirLoops               = "loops"
 	DirSessions            = "sessions"
 	FileAutoSave           = "auto-save-session.sh"
 	FileBlockNonPathScript = "block-non-path-ctx.sh"
 	FileClaudeMd           = "CLAUDE.md"
+	FileImportCandidates   = "candidates.md"
 	FileSettings           = ".claude/settings.local.json"
 )
 
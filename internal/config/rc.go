@@ -8,6 +8,7 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"strconv"
 	"sync"
 
@@ -16,11 +17,40 @@ import (
 
 // RC represents the configuration from .contextrc file.
 type RC struct {
-	ContextDir       string   `yaml:"context_dir"`
-	TokenBudget      int      `yaml:"token_budget"`
-	PriorityOrder    []string `yaml:"priority_order"`
-	AutoArchive      bool     `yaml:"auto_archive"`
-	ArchiveAfterDays int      `yaml:"archive_after_days"`
+	ContextDir       string                `yaml:"context_dir"`
+	TokenBudget      int                   `yaml:"token_budget"`
+	PriorityOrder    []string              `yaml:"priority_order"`
+	AutoArchive      bool                  `yaml:"auto_archive"`
+	ArchiveAfterDays int                   `yaml:"archive_after_days"`
+	Parsers          []ParserConfig        `yaml:"parsers"`
+	Pricing          map[string]ModelPrice `yaml:"pricing"`
+}
+
+// ParserConfig declares an external session parser for the recall system.
+//
+// The command is run with the path of a session file as its last
+// argument and prints the sessions found in it as JSON.
+type ParserConfig struct {
+	// Tool is the tool identifier of the sessions, e.g. "acme-agent".
+	Tool    string `yaml:"tool"`
+	Command string `yaml:"command"`
+	// Glob selects the files the parser handles, matched against the
+	// file name, e.g. "*.acme.json".
+	Glob string `yaml:"glob"`
+	// Dir is an optional directory searched for session files, like
+	// ~/.claude/projects for Claude Code.
+	Dir string `yaml:"dir"`
+}
+
+// ModelPrice is the price of a model's tokens in USD per million tokens,
+// used by "ctx recall stats" to estimate costs.
+type ModelPrice struct {
+	Input  float64 `yaml:"input" json:"input"`
+	Output float64 `yaml:"output" json:"output"`
+	// CacheWrite and CacheRead price the tokens written to and read from
+	// the prompt cache.
+	CacheWrite float64 `yaml:"cache_write" json:"cache_write"`
+	CacheRead  float64 `yaml:"cache_read" json:"cache_read"`
 }
 
 // DefaultTokenBudget is the default token budget when not configured.
@@ -88,6 +118,21 @@ func GetContextDir() string {
 	return GetRC().ContextDir
 }
 
+// ContextPath returns a path inside the configured context directory.
+//
+// All commands that read or write context files should build their paths
+// with this function so that CTX_DIR, .contextrc and the CLI override are
+// honored consistently.
+//
+// Parameters:
+//   - elem: Path elements relative to the context directory
+//
+// Returns:
+//   - string: The joined path, e.g. ".context/TASKS.md"
+func ContextPath(elem ...string) string {
+	return filepath.Join(append([]string{GetContextDir()}, elem...)...)
+}
+
 // GetTokenBudget returns the configured default token budget.
 // Priority: env var > .contextrc > default.
 func GetTokenBudget() int {
@@ -110,6 +155,31 @@ func GetArchiveAfterDays() int {
 	return GetRC().ArchiveAfterDays
 }
 
+// GetParsers returns the external session parsers declared in .contextrc,
+// or none unless ParsersTrusted.
+func GetParsers() []ParserConfig {
+	if !ParsersTrusted() {
+		return nil
+	}
+	return GetRC().Parsers
+}
+
+// ParsersTrusted reports whether the external session parsers declared in
+// .contextrc may be run, which CTX_TRUST_PARSERS=1 allows.
+//
+// A .contextrc comes with the repository it is in, and parser commands
+// are run by "ctx recall" and "ctx agent --with-history", so without the
+// opt-in a cloned repository could run any command it chose.
+func ParsersTrusted() bool {
+	return os.Getenv("CTX_TRUST_PARSERS") == "1"
+}
+
+// GetPricing returns the model prices declared in .contextrc, keyed by
+// model name or name prefix.
+func GetPricing() map[string]ModelPrice {
+	return GetRC().Pricing
+}
+
 // OverrideContextDir sets a CLI-provided override for the context directory.
 // This takes precedence over all other configuration sources.
 func OverrideContextDir(dir string) {
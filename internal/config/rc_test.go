@@ -238,3 +238,89 @@ func TestGetRC_Singleton(t *testing.T) {
 		t.Error("GetRC() should return same instance")
 	}
 }
+
+func TestContextPath(t *testing.T) {
+	tempDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(origDir)
+
+	ResetRC()
+	defer ResetRC()
+
+	if got, want := ContextPath(FilenameTask), filepath.Join(DirContext, FilenameTask); got != want {
+		t.Errorf("ContextPath() = %q, want %q", got, want)
+	}
+
+	os.Setenv("CTX_DIR", "docs/context")
+	defer os.Unsetenv("CTX_DIR")
+	ResetRC()
+
+	want := filepath.Join("docs", "context", DirArchive, "tasks.md")
+	if got := ContextPath(DirArchive, "tasks.md"); got != want {
+		t.Errorf("ContextPath() with CTX_DIR = %q, want %q", got, want)
+	}
+
+	OverrideContextDir("custom")
+	if got, want := ContextPath(FilenameTask), filepath.Join("custom", FilenameTask); got != want {
+		t.Errorf("ContextPath() with override = %q, want %q", got, want)
+	}
+}
+
+func TestGetParsers(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	rcContent := `parsers:
+  - tool: acme
+    command: acme-export --json
+    glob: "*.acme.log"
+    dir: ~/.acme/sessions
+`
+	os.WriteFile(filepath.Join(tempDir, ".contextrc"), []byte(rcContent), 0644)
+
+	ResetRC()
+	defer ResetRC()
+
+	// Parsers from the repository are not run without the opt-in
+	t.Setenv("CTX_TRUST_PARSERS", "")
+	if parsers := GetParsers(); parsers != nil {
+		t.Errorf("GetParsers() without CTX_TRUST_PARSERS = %+v, want none", parsers)
+	}
+
+	t.Setenv("CTX_TRUST_PARSERS", "1")
+	parsers := GetParsers()
+	if len(parsers) != 1 {
+		t.Fatalf("GetParsers() returned %d parsers, want 1", len(parsers))
+	}
+	want := ParserConfig{
+		Tool: "acme", Command: "acme-export --json",
+		Glob: "*.acme.log", Dir: "~/.acme/sessions",
+	}
+	if parsers[0] != want {
+		t.Errorf("GetParsers()[0] = %+v, want %+v", parsers[0], want)
+	}
+}
+
+func TestGetPricing(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	rcContent := `pricing:
+  claude-sonnet-4:
+    input: 3
+    output: 15
+    cache_write: 3.75
+    cache_read: 0.3
+`
+	os.WriteFile(filepath.Join(tempDir, ".contextrc"), []byte(rcContent), 0644)
+
+	ResetRC()
+	defer ResetRC()
+
+	pricing := GetPricing()
+	want := ModelPrice{Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.3}
+	if got := pricing["claude-sonnet-4"]; got != want {
+		t.Errorf("GetPricing()[claude-sonnet-4] = %+v, want %+v", got, want)
+	}
+}
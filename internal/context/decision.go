@@ -0,0 +1,119 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Decision field names, as written by "ctx add decision".
+const (
+	FieldStatus       = "Status"
+	FieldContext      = "Context"
+	FieldDecision     = "Decision"
+	FieldRationale    = "Rationale"
+	FieldConsequences = "Consequences"
+	FieldSupersedes   = "Supersedes"
+	FieldSupersededBy = "Superseded By"
+)
+
+// Decision statuses, as written in the Status field.
+const (
+	StatusProposed   = "Proposed"
+	StatusAccepted   = "Accepted"
+	StatusSuperseded = "Superseded"
+	StatusDeprecated = "Deprecated"
+)
+
+// statusWordPattern matches the leading word of a Status field, so that
+// "Accepted (implemented)" reads as "Accepted".
+var statusWordPattern = regexp.MustCompile(`^[A-Za-z]+`)
+
+// Decision is an architectural decision record from DECISIONS.md.
+//
+// It starts at a "## [timestamp] Title" heading and holds
+// "**Field**: value" lines such as Status, Context, Decision,
+// Rationale and Consequences.
+type Decision struct {
+	record
+}
+
+// Kind returns KindDecision.
+func (d *Decision) Kind() EntryKind {
+	return KindDecision
+}
+
+// Status returns the Status field, e.g. "Accepted".
+func (d *Decision) Status() string {
+	return d.Field(FieldStatus)
+}
+
+// Lifecycle returns the lifecycle status of the decision: one of the
+// Status constants, matched by the first word of the Status field.
+// Decisions without a Status field predate it and count as Accepted;
+// an unrecognized status is returned as written.
+func (d *Decision) Lifecycle() string {
+	status := d.Status()
+	if status == "" {
+		return StatusAccepted
+	}
+	word := statusWordPattern.FindString(status)
+	for _, s := range []string{
+		StatusProposed, StatusAccepted, StatusSuperseded, StatusDeprecated,
+	} {
+		if strings.EqualFold(word, s) {
+			return s
+		}
+	}
+	return status
+}
+
+// SetStatus sets the Status field.
+func (d *Decision) SetStatus(status string) {
+	d.SetField(FieldStatus, status)
+}
+
+// Supersedes returns the IDs referenced by the Supersedes field.
+func (d *Decision) Supersedes() []string {
+	return References(d.Field(FieldSupersedes))
+}
+
+// SupersededBy returns the ID referenced by the Superseded By field, or
+// "" if the decision has not been superseded.
+func (d *Decision) SupersededBy() string {
+	if ids := References(d.Field(FieldSupersededBy)); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
+
+// IsActive reports whether the decision is in force: Accepted and not
+// superseded.
+func (d *Decision) IsActive() bool {
+	return d.Lifecycle() == StatusAccepted && d.SupersededBy() == ""
+}
+
+// Context returns the Context field.
+func (d *Decision) Context() string {
+	return d.Field(FieldContext)
+}
+
+// Decision returns the Decision field.
+func (d *Decision) Decision() string {
+	return d.Field(FieldDecision)
+}
+
+// Rationale returns the Rationale field.
+func (d *Decision) Rationale() string {
+	return d.Field(FieldRationale)
+}
+
+// Consequences returns the Consequences field.
+func (d *Decision) Consequences() string {
+	return d.Field(FieldConsequences)
+}
@@ -0,0 +1,94 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import "slices"
+
+// DependencyCycles returns the groups of tasks that depend on each other
+// in a cycle through their "#after:" labels, so that none of them can
+// ever become ready. A task that depends on itself forms a cycle of one.
+//
+// Tasks are matched by ID; dependencies on IDs not among tasks are
+// ignored. Each cycle lists its tasks in the order they were given, and
+// cycles are ordered by their first task.
+func DependencyCycles(tasks []*Task) [][]*Task {
+	byID := make(map[string]*Task)
+	order := make(map[*Task]int)
+	for i, t := range tasks {
+		order[t] = i
+		if id, ok := NormalizeID(t.ID()); ok {
+			if _, seen := byID[id]; !seen {
+				byID[id] = t
+			}
+		}
+	}
+
+	// Tarjan's strongly connected components
+	var (
+		cycles  [][]*Task
+		stack   []*Task
+		index   = make(map[*Task]int)
+		low     = make(map[*Task]int)
+		onStack = make(map[*Task]bool)
+		next    int
+		visit   func(t *Task)
+	)
+	visit = func(t *Task) {
+		index[t], low[t] = next, next
+		next++
+		stack = append(stack, t)
+		onStack[t] = true
+
+		selfLoop := false
+		for _, dep := range t.Dependencies() {
+			d, ok := byID[dep]
+			if !ok {
+				continue
+			}
+			if d == t {
+				selfLoop = true
+			}
+			if _, seen := index[d]; !seen {
+				visit(d)
+				low[t] = min(low[t], low[d])
+			} else if onStack[d] {
+				low[t] = min(low[t], index[d])
+			}
+		}
+
+		if low[t] != index[t] {
+			return
+		}
+		var scc []*Task
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			scc = append(scc, top)
+			if top == t {
+				break
+			}
+		}
+		if len(scc) > 1 || selfLoop {
+			slices.SortFunc(scc, func(a, b *Task) int {
+				return order[a] - order[b]
+			})
+			cycles = append(cycles, scc)
+		}
+	}
+
+	for _, t := range byID {
+		if _, seen := index[t]; !seen {
+			visit(t)
+		}
+	}
+
+	slices.SortFunc(cycles, func(a, b []*Task) int {
+		return order[a[0]] - order[b[0]]
+	})
+	return cycles
+}
@@ -0,0 +1,305 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// EntryKind identifies the type of structured context entry.
+type EntryKind string
+
+// Entry kinds, one per structured context file.
+const (
+	KindDecision EntryKind = "decision"
+	KindLearning EntryKind = "learning"
+	KindTask     EntryKind = "task"
+)
+
+// Span locates an entry in the source it was parsed from.
+//
+// Lines are 1-based and inclusive. Offsets are byte offsets into the
+// source, with End exclusive. Spans describe the parsed source and are
+// not updated when entries are edited.
+type Span struct {
+	StartLine int
+	EndLine   int
+	Start     int
+	End       int
+}
+
+// Entry is a structured item parsed from a context file.
+type Entry interface {
+	// Kind returns the type of the entry.
+	Kind() EntryKind
+	// ID returns the stable ID of the entry, or "" if it has none.
+	ID() string
+	// Span returns the location of the entry in the parsed source.
+	Span() Span
+	// String returns the Markdown text of the entry as it is serialized.
+	String() string
+}
+
+// Document is a context file parsed into entries and the free text
+// between them.
+//
+// Entries can be queried and edited in place. Serializing a Document
+// reproduces its source byte for byte, except for the entries that were
+// edited.
+type Document struct {
+	Name  string
+	Path  string
+	nodes []docNode
+}
+
+// docNode is either a run of free text or an entry.
+type docNode struct {
+	text  string
+	entry Entry
+}
+
+// ParseDocument parses the content of a context file into a Document.
+//
+// The parser is selected by file name: DECISIONS.md, LEARNINGS.md and
+// TASKS.md yield decisions, learnings and tasks; any other file is kept
+// as a single run of free text.
+func ParseDocument(name string, content []byte) *Document {
+	src := newSource(string(content))
+
+	var nodes []docNode
+	switch name {
+	case config.FilenameDecision:
+		nodes = parseRecords(src, KindDecision)
+	case config.FilenameLearning:
+		nodes = parseRecords(src, KindLearning)
+	case config.FilenameTask:
+		nodes = parseTasks(src)
+	default:
+		if len(content) > 0 {
+			nodes = []docNode{{text: string(content)}}
+		}
+	}
+
+	return &Document{Name: name, nodes: nodes}
+}
+
+// LoadDocument reads and parses a file from the context directory.
+// If `dir` is empty, it uses the configured context directory.
+func LoadDocument(dir, name string) (*Document, error) {
+	if dir == "" {
+		dir = config.GetContextDir()
+	}
+	path := filepath.Join(dir, name)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	doc := ParseDocument(name, content)
+	doc.Path = path
+	return doc, nil
+}
+
+// Save writes the serialized document back to the file it was loaded from.
+func (d *Document) Save() error {
+	return os.WriteFile(d.Path, d.Bytes(), 0644)
+}
+
+// Bytes serializes the document to Markdown.
+func (d *Document) Bytes() []byte {
+	return []byte(d.String())
+}
+
+// String serializes the document to Markdown.
+func (d *Document) String() string {
+	var sb strings.Builder
+	for _, n := range d.nodes {
+		if n.entry != nil {
+			sb.WriteString(n.entry.String())
+		} else {
+			sb.WriteString(n.text)
+		}
+	}
+	return sb.String()
+}
+
+// Entries returns the top-level entries of the document in file order.
+// Subtasks are reachable through their parent's Children.
+func (d *Document) Entries() []Entry {
+	var entries []Entry
+	for _, n := range d.nodes {
+		if n.entry != nil {
+			entries = append(entries, n.entry)
+		}
+	}
+	return entries
+}
+
+// Decisions returns the decisions of the document in file order.
+func (d *Document) Decisions() []*Decision {
+	var decisions []*Decision
+	for _, e := range d.Entries() {
+		if dec, ok := e.(*Decision); ok {
+			decisions = append(decisions, dec)
+		}
+	}
+	return decisions
+}
+
+// Learnings returns the learnings of the document in file order.
+func (d *Document) Learnings() []*Learning {
+	var learnings []*Learning
+	for _, e := range d.Entries() {
+		if l, ok := e.(*Learning); ok {
+			learnings = append(learnings, l)
+		}
+	}
+	return learnings
+}
+
+// Tasks returns all tasks of the document in file order, with each
+// subtask following its parent.
+func (d *Document) Tasks() []*Task {
+	var tasks []*Task
+	for _, e := range d.Entries() {
+		if t, ok := e.(*Task); ok {
+			tasks = append(tasks, t.Walk()...)
+		}
+	}
+	return tasks
+}
+
+// headingPattern matches Markdown ATX headings.
+var headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*$`)
+
+// separatorPattern matches horizontal rules used between entries.
+var separatorPattern = regexp.MustCompile(`^-{3,}\s*$`)
+
+// source is parsed input split into lines, with line offsets and
+// HTML comment tracking.
+type source struct {
+	lines   []string
+	offsets []int
+	comment []bool
+}
+
+// newSource splits content into lines that keep their line endings.
+func newSource(content string) *source {
+	lines := strings.SplitAfter(content, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	src := &source{
+		lines:   lines,
+		offsets: make([]int, len(lines)+1),
+		comment: make([]bool, len(lines)),
+	}
+
+	open := false
+	for i, line := range lines {
+		src.offsets[i+1] = src.offsets[i] + len(line)
+
+		inside := open
+		rest := line
+		for {
+			if open {
+				idx := strings.Index(rest, "-->")
+				if idx < 0 {
+					break
+				}
+				open = false
+				rest = rest[idx+3:]
+			} else {
+				idx := strings.Index(rest, "<!--")
+				if idx < 0 {
+					break
+				}
+				open = true
+				inside = true
+				rest = rest[idx+4:]
+			}
+		}
+		src.comment[i] = inside
+	}
+
+	return src
+}
+
+// span returns the span of lines [from, to).
+func (s *source) span(from, to int) Span {
+	return Span{
+		StartLine: from + 1,
+		EndLine:   to,
+		Start:     s.offsets[from],
+		End:       s.offsets[to],
+	}
+}
+
+// heading returns the level and text of line i if it is a heading
+// outside an HTML comment.
+func (s *source) heading(i int) (int, string, bool) {
+	if s.comment[i] {
+		return 0, "", false
+	}
+	m := headingPattern.FindStringSubmatch(trimEOL(s.lines[i]))
+	if m == nil {
+		return 0, "", false
+	}
+	return len(m[1]), m[2], true
+}
+
+// isBlank reports whether line i contains only whitespace.
+func (s *source) isBlank(i int) bool {
+	return strings.TrimSpace(s.lines[i]) == ""
+}
+
+// nodeBuilder accumulates free text and entries into document nodes.
+type nodeBuilder struct {
+	nodes []docNode
+	free  strings.Builder
+}
+
+// text appends free text.
+func (b *nodeBuilder) text(s string) {
+	b.free.WriteString(s)
+}
+
+// entry appends an entry, flushing any pending free text first.
+func (b *nodeBuilder) entry(e Entry) {
+	b.flush()
+	b.nodes = append(b.nodes, docNode{entry: e})
+}
+
+// flush turns pending free text into a node.
+func (b *nodeBuilder) flush() {
+	if b.free.Len() > 0 {
+		b.nodes = append(b.nodes, docNode{text: b.free.String()})
+		b.free.Reset()
+	}
+}
+
+// done returns the accumulated nodes.
+func (b *nodeBuilder) done() []docNode {
+	b.flush()
+	return b.nodes
+}
+
+// trimEOL removes a trailing line ending.
+func trimEOL(line string) string {
+	return strings.TrimRight(line, "\r\n")
+}
+
+// lineEnding returns the line ending of a line, or "" for the last line
+// of a file without a trailing newline.
+func lineEnding(line string) string {
+	return line[len(trimEOL(line)):]
+}
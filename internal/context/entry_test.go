@@ -0,0 +1,306 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/templates"
+)
+
+const sampleDecisions = `# Decisions
+
+<!-- Use this format for each decision:
+
+## [YYYY-MM-DD] Decision Title
+
+**Status**: Accepted
+-->
+
+## [2026-01-28-051426] No custom UI
+
+**Status**: Accepted
+
+**Context**: Considering a web UI for
+browsing sessions.
+
+**Decision**: No custom UI
+
+**Rationale**: UI is a liability
+
+**Consequences**:
+1) No UI codebase to maintain.
+2) Users use their preferred editor.
+
+---
+
+## [2026-01-27] Older decision
+
+**Status**: Proposed
+`
+
+const sampleLearnings = `# Learnings
+
+---
+
+## [2026-01-28-191951] Required flags
+
+**Context**: Implemented flags
+
+**Lesson**: Structured entries are more useful
+
+**Application**: Always use all three flags
+
+- **[2026-01-28-051426]** IDE is already the UI: Discovery, search, and
+editing of markdown files works better in the IDE.
+
+- **[2026-01-28-040915]** Subtasks complete does not mean parent complete
+`
+
+func TestParseDocumentRoundTrip(t *testing.T) {
+	inputs := map[string]string{
+		"decisions":           sampleDecisions,
+		"learnings":           sampleLearnings,
+		"tasks":               sampleTasks,
+		"no trailing newline": "# Tasks\n\n- [ ] Last task",
+		"crlf":                "# Tasks\r\n\r\n- [ ] Task\r\n  - [x] Sub\r\n",
+		"empty":               "",
+	}
+	names := map[string]string{
+		"decisions": config.FilenameDecision,
+		"learnings": config.FilenameLearning,
+	}
+
+	for label, content := range inputs {
+		t.Run(label, func(t *testing.T) {
+			name, ok := names[label]
+			if !ok {
+				name = config.FilenameTask
+			}
+			doc := ParseDocument(name, []byte(content))
+			if got := doc.String(); got != content {
+				t.Errorf("round trip changed content:\ngot:\n%q\nwant:\n%q", got, content)
+			}
+		})
+	}
+
+	// Every shipped template must round-trip as well
+	for _, name := range []string{
+		config.FilenameDecision, config.FilenameLearning, config.FilenameTask,
+	} {
+		content, err := templates.GetTemplate(name)
+		if err != nil {
+			t.Fatalf("GetTemplate(%q) error: %v", name, err)
+		}
+		if got := ParseDocument(name, content).String(); got != string(content) {
+			t.Errorf("%s template did not round-trip", name)
+		}
+	}
+}
+
+func TestParseDecisions(t *testing.T) {
+	doc := ParseDocument(config.FilenameDecision, []byte(sampleDecisions))
+	decisions := doc.Decisions()
+
+	// The example inside the HTML comment is not an entry
+	if len(decisions) != 2 {
+		t.Fatalf("got %d decisions, want 2", len(decisions))
+	}
+
+	d := decisions[0]
+	checks := []struct {
+		field, got, want string
+	}{
+		{"Timestamp", d.Timestamp(), "2026-01-28-051426"},
+		{"Title", d.Title(), "No custom UI"},
+		{"Status", d.Status(), "Accepted"},
+		{"Context", d.Context(), "Considering a web UI for\nbrowsing sessions."},
+		{"Decision", d.Decision(), "No custom UI"},
+		{"Rationale", d.Rationale(), "UI is a liability"},
+		{
+			"Consequences", d.Consequences(),
+			"1) No UI codebase to maintain.\n2) Users use their preferred editor.",
+		},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %q, want %q", c.field, c.got, c.want)
+		}
+	}
+
+	if d.Kind() != KindDecision {
+		t.Errorf("Kind() = %q, want %q", d.Kind(), KindDecision)
+	}
+
+	span := d.Span()
+	if span.StartLine != 10 || span.EndLine != 23 {
+		t.Errorf("Span lines = %d-%d, want 10-23", span.StartLine, span.EndLine)
+	}
+	if got := sampleDecisions[span.Start:span.End]; got != d.String() {
+		t.Errorf("Span offsets do not cover the entry text:\n%q", got)
+	}
+
+	if decisions[1].Status() != "Proposed" {
+		t.Errorf("second Status = %q, want Proposed", decisions[1].Status())
+	}
+}
+
+func TestParseLearnings(t *testing.T) {
+	doc := ParseDocument(config.FilenameLearning, []byte(sampleLearnings))
+	learnings := doc.Learnings()
+	if len(learnings) != 3 {
+		t.Fatalf("got %d learnings, want 3", len(learnings))
+	}
+
+	if got := learnings[0].Lesson(); got != "Structured entries are more useful" {
+		t.Errorf("Lesson = %q", got)
+	}
+	if got := learnings[0].Application(); got != "Always use all three flags" {
+		t.Errorf("Application = %q", got)
+	}
+
+	list := learnings[1]
+	if list.Timestamp() != "2026-01-28-051426" {
+		t.Errorf("Timestamp = %q", list.Timestamp())
+	}
+	if !strings.HasPrefix(list.Title(), "IDE is already the UI") {
+		t.Errorf("Title = %q", list.Title())
+	}
+	if !strings.Contains(list.String(), "works better in the IDE.") {
+		t.Errorf("list learning lost its continuation line: %q", list.String())
+	}
+}
+
+func TestRecordSetField(t *testing.T) {
+	doc := ParseDocument(config.FilenameDecision, []byte(sampleDecisions))
+	d := doc.Decisions()[0]
+
+	d.SetField(FieldStatus, "Superseded")
+	d.SetField(FieldConsequences, "Replaced by a TUI")
+	d.SetField("Superseded by", "2026-02-01 TUI")
+
+	if d.Status() != "Superseded" {
+		t.Errorf("Status = %q, want Superseded", d.Status())
+	}
+	if d.Consequences() != "Replaced by a TUI" {
+		t.Errorf("Consequences = %q", d.Consequences())
+	}
+	if d.Field("superseded by") != "2026-02-01 TUI" {
+		t.Errorf("new field not found: %q", d.String())
+	}
+	if d.Rationale() != "UI is a liability" {
+		t.Errorf("unrelated field changed: %q", d.Rationale())
+	}
+
+	// The rest of the document is untouched
+	out := doc.String()
+	if !strings.Contains(out, "## [2026-01-27] Older decision\n\n**Status**: Proposed\n") {
+		t.Errorf("following decision was changed:\n%s", out)
+	}
+	if !strings.Contains(out, "**Status**: Superseded\n\n**Context**:") {
+		t.Errorf("field spacing not preserved:\n%s", out)
+	}
+	if !strings.Contains(out, "**Superseded by**: 2026-02-01 TUI\n\n---\n") {
+		t.Errorf("appended field not followed by separator:\n%s", out)
+	}
+}
+
+func TestDecisionLifecycle(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		status string
+		active bool
+	}{
+		{"accepted", "**Status**: Accepted\n", StatusAccepted, true},
+		{"annotated", "**Status**: Accepted (implemented)\n", StatusAccepted, true},
+		{"lowercase", "**Status**: proposed\n", StatusProposed, false},
+		{"no status", "**Context**: Legacy\n", StatusAccepted, true},
+		{"deprecated", "**Status**: Deprecated\n", StatusDeprecated, false},
+		{"unknown", "**Status**: On hold\n", "On hold", false},
+		{
+			"superseded link only",
+			"**Status**: Accepted\n\n**Superseded By**: [[D-9]]\n",
+			StatusAccepted, false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "# Decisions\n\n## [2026-01-01] Title\n\n" + tt.body
+			d := ParseDocument(
+				config.FilenameDecision, []byte(content),
+			).Decisions()[0]
+			if got := d.Lifecycle(); got != tt.status {
+				t.Errorf("Lifecycle() = %q, want %q", got, tt.status)
+			}
+			if got := d.IsActive(); got != tt.active {
+				t.Errorf("IsActive() = %v, want %v", got, tt.active)
+			}
+		})
+	}
+}
+
+func TestRecordSetID(t *testing.T) {
+	doc := ParseDocument(config.FilenameDecision, []byte(sampleDecisions))
+	decisions := doc.Decisions()
+
+	decisions[0].SetID("D-007")
+	decisions[0].SetField(FieldSupersedes, "[[D-3]], [[D-004]]")
+	if got := decisions[0].Supersedes(); len(got) != 2 || got[0] != "D-003" {
+		t.Errorf("Supersedes() = %v", got)
+	}
+
+	out := doc.String()
+	want := "## [2026-01-28-051426] No custom UI\n\n**ID**: D-007\n\n**Status**: Accepted\n"
+	if !strings.Contains(out, want) {
+		t.Errorf("ID not inserted below heading:\n%s", out)
+	}
+
+	decisions[0].SetID("D-008")
+	if got := strings.Count(doc.String(), "**ID**"); got != 1 {
+		t.Errorf("SetID on an entry with an ID added a field (%d IDs)", got)
+	}
+	if decisions[0].ID() != "D-008" {
+		t.Errorf("ID() = %q, want D-008", decisions[0].ID())
+	}
+}
+
+func TestLoadDocumentSave(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, config.FilenameTask)
+	if err := os.WriteFile(path, []byte(sampleTasks), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := LoadDocument(dir, config.FilenameTask)
+	if err != nil {
+		t.Fatalf("LoadDocument error: %v", err)
+	}
+	if doc.Path != path {
+		t.Errorf("Path = %q, want %q", doc.Path, path)
+	}
+
+	doc.Tasks()[0].SetState(TaskDone)
+	if err := doc.Save(); err != nil {
+		t.Fatalf("Save error: %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	want := strings.Replace(sampleTasks, "- [ ] Set up repo", "- [x] Set up repo", 1)
+	if string(content) != want {
+		t.Errorf("saved content:\n%s\nwant:\n%s", content, want)
+	}
+
+	if _, err := LoadDocument(dir, "MISSING.md"); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
@@ -0,0 +1,315 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// idPattern matches an entry ID such as "D-014", case-insensitively.
+var idPattern = regexp.MustCompile(`^(?i)([DLT])-(\d+)$`)
+
+// refPattern matches "[[D-014]]" references to entries.
+var refPattern = regexp.MustCompile(`\[\[([DLTdlt]-\d+)]]`)
+
+// idPrefixes maps entry kinds to their ID prefixes.
+var idPrefixes = map[EntryKind]string{
+	KindDecision: "D",
+	KindLearning: "L",
+	KindTask:     "T",
+}
+
+// kindFiles maps entry kinds to the context files that hold them.
+var kindFiles = map[EntryKind]string{
+	KindDecision: config.FilenameDecision,
+	KindLearning: config.FilenameLearning,
+	KindTask:     config.FilenameTask,
+}
+
+// IDPrefix returns the ID prefix for an entry kind, e.g. "D" for decisions.
+func IDPrefix(kind EntryKind) string {
+	return idPrefixes[kind]
+}
+
+// FormatID returns the ID of the n-th entry of a kind, e.g. "D-014".
+func FormatID(kind EntryKind, n int) string {
+	return fmt.Sprintf("%s-%03d", IDPrefix(kind), n)
+}
+
+// ParseID splits an ID into its entry kind and number.
+func ParseID(id string) (EntryKind, int, bool) {
+	m := idPattern.FindStringSubmatch(strings.TrimSpace(id))
+	if m == nil {
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(m[2])
+	if err != nil {
+		return "", 0, false
+	}
+	prefix := strings.ToUpper(m[1])
+	for kind, p := range idPrefixes {
+		if p == prefix {
+			return kind, n, true
+		}
+	}
+	return "", 0, false
+}
+
+// NormalizeID returns the canonical form of an ID, so that "d-14" and
+// "D-014" compare equal. Returns false if s is not an ID.
+func NormalizeID(s string) (string, bool) {
+	kind, n, ok := ParseID(s)
+	if !ok {
+		return "", false
+	}
+	return FormatID(kind, n), true
+}
+
+// References returns the IDs referenced as "[[ID]]" in text, normalized,
+// in order of appearance.
+func References(text string) []string {
+	var ids []string
+	for _, m := range refPattern.FindAllStringSubmatch(text, -1) {
+		if id, ok := NormalizeID(m[1]); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// NextID returns the ID for a new entry of the given kind: one past the
+// highest ID of that kind in the context directory, archive included.
+// If `dir` is empty, it uses the configured context directory.
+func NextID(dir string, kind EntryKind) (string, error) {
+	idx, err := LoadIndex(dir)
+	if err != nil {
+		return "", err
+	}
+	return idx.Next(kind), nil
+}
+
+// IndexEntry is an entry with an ID, located in a context file.
+type IndexEntry struct {
+	// ID is the normalized ID of the entry.
+	ID    string
+	Entry Entry
+	// File is the path of the file relative to the context directory,
+	// e.g. "DECISIONS.md" or "archive/tasks-2026-01-20.md".
+	File     string
+	Archived bool
+}
+
+// Ref is a "[[ID]]" reference found in a context file.
+type Ref struct {
+	// ID is the normalized ID of the referenced entry.
+	ID   string
+	File string
+	Line int
+	// From is the ID of the entry containing the reference, if any.
+	From string
+}
+
+// Index maps entry IDs to entries across the context directory and its
+// archive, and records the references between them.
+type Index struct {
+	entries    map[string]IndexEntry
+	duplicates []IndexEntry
+	refs       []Ref
+	max        map[EntryKind]int
+}
+
+// LoadIndex builds an Index from the context directory.
+// If `dir` is empty, it uses the configured context directory.
+//
+// Live entries are read from DECISIONS.md, LEARNINGS.md and TASKS.md, and
+// references from every Markdown file in the directory. Archive files do
+// not record what they hold, so each is parsed as every kind of entry;
+// only entries carrying an ID of their own kind are kept.
+func LoadIndex(dir string) (*Index, error) {
+	if dir == "" {
+		dir = config.GetContextDir()
+	}
+
+	idx := &Index{
+		entries: make(map[string]IndexEntry),
+		max:     make(map[EntryKind]int),
+	}
+
+	names, err := markdownFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		doc := ParseDocument(name, content)
+		entries := withIDs(doc)
+		for _, e := range entries {
+			idx.add(e, name, false)
+		}
+		idx.scanRefs(name, content, entries)
+	}
+
+	archived, err := markdownFiles(filepath.Join(dir, config.DirArchive))
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range archived {
+		content, err := os.ReadFile(filepath.Join(dir, config.DirArchive, name))
+		if err != nil {
+			return nil, err
+		}
+		file := filepath.ToSlash(filepath.Join(config.DirArchive, name))
+		for _, kindFile := range kindFiles {
+			doc := ParseDocument(kindFile, content)
+			for _, e := range withIDs(doc) {
+				idx.add(e, file, true)
+			}
+		}
+	}
+
+	return idx, nil
+}
+
+// Lookup returns the entry with the given ID. Live entries take
+// precedence over archived ones.
+func (x *Index) Lookup(id string) (IndexEntry, bool) {
+	id, ok := NormalizeID(id)
+	if !ok {
+		return IndexEntry{}, false
+	}
+	e, ok := x.entries[id]
+	return e, ok
+}
+
+// Refs returns all references found in live context files, in file order.
+func (x *Index) Refs() []Ref {
+	return x.refs
+}
+
+// Backlinks returns the references to the given ID.
+func (x *Index) Backlinks(id string) []Ref {
+	id, ok := NormalizeID(id)
+	if !ok {
+		return nil
+	}
+	var refs []Ref
+	for _, r := range x.refs {
+		if r.ID == id {
+			refs = append(refs, r)
+		}
+	}
+	return refs
+}
+
+// Duplicates returns the live entries whose ID is already used by an
+// earlier entry.
+func (x *Index) Duplicates() []IndexEntry {
+	return x.duplicates
+}
+
+// Next returns the ID for a new entry of the given kind.
+func (x *Index) Next(kind EntryKind) string {
+	return FormatID(kind, x.max[kind]+1)
+}
+
+// add records an entry. Archived entries never shadow live ones.
+func (x *Index) add(e Entry, file string, archived bool) {
+	kind, n, _ := ParseID(e.ID())
+	if n > x.max[kind] {
+		x.max[kind] = n
+	}
+
+	id := FormatID(kind, n)
+	ie := IndexEntry{ID: id, Entry: e, File: file, Archived: archived}
+	prev, seen := x.entries[id]
+	switch {
+	case !seen, prev.Archived && !archived:
+		x.entries[id] = ie
+	case !archived:
+		x.duplicates = append(x.duplicates, ie)
+	}
+}
+
+// scanRefs records the references in a file outside HTML comments,
+// attributing each to the innermost entry that contains it.
+func (x *Index) scanRefs(file string, content []byte, entries []Entry) {
+	src := newSource(string(content))
+	for i, line := range src.lines {
+		if src.comment[i] {
+			continue
+		}
+		ids := References(line)
+		if len(ids) == 0 {
+			continue
+		}
+
+		var from string
+		for _, e := range entries {
+			if s := e.Span(); s.StartLine <= i+1 && i+1 <= s.EndLine {
+				from, _ = NormalizeID(e.ID())
+			}
+		}
+		for _, id := range ids {
+			x.refs = append(x.refs, Ref{ID: id, File: file, Line: i + 1, From: from})
+		}
+	}
+}
+
+// withIDs returns the entries of a document that carry a valid ID of
+// their own kind, with subtasks following their parents.
+func withIDs(doc *Document) []Entry {
+	var all []Entry
+	for _, e := range doc.Entries() {
+		if t, ok := e.(*Task); ok {
+			for _, sub := range t.Walk() {
+				all = append(all, sub)
+			}
+			continue
+		}
+		all = append(all, e)
+	}
+
+	var entries []Entry
+	for _, e := range all {
+		k, _, ok := ParseID(e.ID())
+		if !ok || k != e.Kind() {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// markdownFiles returns the names of the Markdown files in a directory,
+// sorted. A missing directory has no files.
+func markdownFiles(dir string) ([]string, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, de := range dirEntries {
+		if !de.IsDir() && filepath.Ext(de.Name()) == ".md" {
+			names = append(names, de.Name())
+		}
+	}
+	return names, nil
+}
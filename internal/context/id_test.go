@@ -0,0 +1,174 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		in   string
+		kind EntryKind
+		n    int
+		norm string
+		ok   bool
+	}{
+		{"D-014", KindDecision, 14, "D-014", true},
+		{"l-32", KindLearning, 32, "L-032", true},
+		{" T-1107 ", KindTask, 1107, "T-1107", true},
+		{"X-1", "", 0, "", false},
+		{"T-", "", 0, "", false},
+		{"T107", "", 0, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			kind, n, ok := ParseID(tt.in)
+			if kind != tt.kind || n != tt.n || ok != tt.ok {
+				t.Errorf("ParseID(%q) = %q, %d, %v; want %q, %d, %v",
+					tt.in, kind, n, ok, tt.kind, tt.n, tt.ok)
+			}
+			norm, ok := NormalizeID(tt.in)
+			if norm != tt.norm || ok != tt.ok {
+				t.Errorf("NormalizeID(%q) = %q, %v; want %q, %v",
+					tt.in, norm, ok, tt.norm, tt.ok)
+			}
+		})
+	}
+}
+
+func TestReferences(t *testing.T) {
+	got := References("See [[D-14]] and [[l-002]], not [D-3] or [[X-1]].")
+	want := []string{"D-014", "L-002"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("References() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadIndex(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		config.FilenameDecision: `# Decisions
+
+<!-- Example: see [[D-999]] -->
+
+## [2026-01-02-100000] Use SQLite
+
+**ID**: D-002
+
+**Context**: Replaces [[D-001]]
+
+---
+
+## [2026-01-01-100000] Use flat files
+
+**ID**: D-001
+`,
+		config.FilenameLearning: `# Learnings
+
+## [2026-01-01-100000] Locks matter
+
+**ID**: L-004
+
+**Lesson**: See [[T-003]]
+`,
+		config.FilenameTask: `# Tasks
+
+- [ ] Migrate storage #id:T-010
+  - [ ] Write migration for [[D-002]] #id:T-011
+- [ ] Duplicate #id:T-010
+`,
+		config.FilenameArchitecture: "# Architecture\n\nStorage: [[D-002]]\n",
+		filepath.Join(config.DirArchive, "tasks-2026-01-01.md"): `# Archived Tasks - 2026-01-01
+
+- [x] Evaluate databases #id:T-003
+- [x] Spike on storage #id:T-012
+`,
+	}
+	if err := os.MkdirAll(filepath.Join(dir, config.DirArchive), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(
+			filepath.Join(dir, name), []byte(content), 0644,
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	idx, err := LoadIndex(dir)
+	if err != nil {
+		t.Fatalf("LoadIndex() error: %v", err)
+	}
+
+	lookups := []struct {
+		id       string
+		file     string
+		line     int
+		archived bool
+	}{
+		{"D-2", config.FilenameDecision, 5, false},
+		{"D-001", config.FilenameDecision, 13, false},
+		{"L-004", config.FilenameLearning, 3, false},
+		{"T-011", config.FilenameTask, 4, false},
+		{"T-010", config.FilenameTask, 3, false},
+		{"T-003", "archive/tasks-2026-01-01.md", 3, true},
+	}
+	for _, tt := range lookups {
+		e, ok := idx.Lookup(tt.id)
+		if !ok {
+			t.Errorf("Lookup(%s) not found", tt.id)
+			continue
+		}
+		if e.File != tt.file || e.Entry.Span().StartLine != tt.line ||
+			e.Archived != tt.archived {
+			t.Errorf("Lookup(%s) = %s:%d archived=%v; want %s:%d archived=%v",
+				tt.id, e.File, e.Entry.Span().StartLine, e.Archived,
+				tt.file, tt.line, tt.archived)
+		}
+	}
+	if _, ok := idx.Lookup("D-999"); ok {
+		t.Error("references in comments should not define entries")
+	}
+
+	wantBacklinks := []Ref{
+		{ID: "D-002", File: config.FilenameArchitecture, Line: 3},
+		{ID: "D-002", File: config.FilenameTask, Line: 4, From: "T-011"},
+	}
+	if got := idx.Backlinks("D-002"); !reflect.DeepEqual(got, wantBacklinks) {
+		t.Errorf("Backlinks(D-002) = %+v, want %+v", got, wantBacklinks)
+	}
+	if got := idx.Backlinks("D-001"); len(got) != 1 || got[0].From != "D-002" {
+		t.Errorf("Backlinks(D-001) = %+v, want one from D-002", got)
+	}
+	for _, ref := range idx.Refs() {
+		if ref.ID == "D-999" {
+			t.Error("references in comments should be ignored")
+		}
+	}
+
+	dups := idx.Duplicates()
+	if len(dups) != 1 || dups[0].ID != "T-010" || dups[0].Entry.Span().StartLine != 5 {
+		t.Errorf("Duplicates() = %+v, want T-010 at line 5", dups)
+	}
+
+	for kind, want := range map[EntryKind]string{
+		KindDecision: "D-003",
+		KindLearning: "L-005",
+		KindTask:     "T-013",
+	} {
+		if got := idx.Next(kind); got != want {
+			t.Errorf("Next(%s) = %s, want %s", kind, got, want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+// Learning field names, as written by "ctx add learning".
+const (
+	FieldLesson      = "Lesson"
+	FieldApplication = "Application"
+)
+
+// Learning is a lesson learned from LEARNINGS.md.
+//
+// It is either a "## [timestamp] Title" section with Context, Lesson and
+// Application fields, or a one-paragraph "- **[timestamp]** Text" list
+// item from the older format, whose Title is the first line of the text.
+type Learning struct {
+	record
+}
+
+// Kind returns KindLearning.
+func (l *Learning) Kind() EntryKind {
+	return KindLearning
+}
+
+// Context returns the Context field.
+func (l *Learning) Context() string {
+	return l.Field(FieldContext)
+}
+
+// Lesson returns the Lesson field.
+func (l *Learning) Lesson() string {
+	return l.Field(FieldLesson)
+}
+
+// Application returns the Application field.
+func (l *Learning) Application() string {
+	return l.Field(FieldApplication)
+}
@@ -0,0 +1,133 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Headings returns the text of the headings of the document outside
+// HTML comments, in file order.
+func (d *Document) Headings() []string {
+	src := newSource(d.String())
+	var headings []string
+	for i := range src.lines {
+		if _, text, ok := src.heading(i); ok {
+			headings = append(headings, text)
+		}
+	}
+	return headings
+}
+
+// Phases returns the text of the phase headings of a TASKS.md document,
+// e.g. "Phase 1: Setup", in file order.
+func (d *Document) Phases() []string {
+	var phases []string
+	for _, h := range d.Headings() {
+		if phasePattern.MatchString(h) {
+			phases = append(phases, h)
+		}
+	}
+	return phases
+}
+
+// RemoveTasks removes top-level tasks, with their subtasks, from the
+// document. Nothing is removed if any of the tasks is not a top-level
+// task of the document.
+func (d *Document) RemoveTasks(tasks ...*Task) error {
+	remove := make(map[Entry]bool)
+	for _, t := range tasks {
+		remove[t] = true
+	}
+
+	nodes := make([]docNode, 0, len(d.nodes))
+	for _, n := range d.nodes {
+		if n.entry != nil && remove[n.entry] {
+			delete(remove, n.entry)
+			continue
+		}
+		nodes = append(nodes, n)
+	}
+	if len(remove) > 0 {
+		return fmt.Errorf("only top-level tasks can be removed")
+	}
+
+	d.nodes = nodes
+	return nil
+}
+
+// MoveTask moves a top-level task, with its subtasks, to the end of the
+// section under the given phase heading.
+//
+// The document is re-parsed after the move, so previously returned
+// entries no longer belong to it.
+func (d *Document) MoveTask(t *Task, phase string) error {
+	return d.MoveTasks([]*Task{t}, phase)
+}
+
+// MoveTasks moves top-level tasks, with their subtasks, to the end of the
+// section under the given heading, keeping their order.
+//
+// The document is re-parsed after the move, so previously returned
+// entries no longer belong to it.
+func (d *Document) MoveTasks(tasks []*Task, heading string) error {
+	var text strings.Builder
+	for _, t := range tasks {
+		s := t.String()
+		if lineEnding(s) == "" {
+			s += "\n"
+		}
+		text.WriteString(s)
+	}
+
+	rest := &Document{Name: d.Name, nodes: d.nodes}
+	if err := rest.RemoveTasks(tasks...); err != nil {
+		return fmt.Errorf("only top-level tasks can be moved")
+	}
+	content, err := insertInSection(rest.String(), heading, text.String())
+	if err != nil {
+		return err
+	}
+
+	moved := ParseDocument(d.Name, []byte(content))
+	d.nodes = moved.nodes
+	return nil
+}
+
+// insertInSection inserts text after the last non-blank line of the
+// section under the given heading. The section runs until the next
+// heading of the same or a higher level.
+func insertInSection(content, heading, text string) (string, error) {
+	src := newSource(content)
+	start, level := -1, 0
+	for i := range src.lines {
+		if l, h, ok := src.heading(i); ok && h == heading {
+			start, level = i, l
+			break
+		}
+	}
+	if start < 0 {
+		return "", fmt.Errorf("section %q not found", heading)
+	}
+
+	end := start + 1
+	for i := start + 1; i < len(src.lines); i++ {
+		if l, _, ok := src.heading(i); ok && l <= level {
+			break
+		}
+		if !src.isBlank(i) {
+			end = i + 1
+		}
+	}
+
+	offset := src.offsets[end]
+	if offset > 0 && !strings.HasSuffix(content[:offset], "\n") {
+		text = "\n" + text
+	}
+	return content[:offset] + text + content[offset:], nil
+}
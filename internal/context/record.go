@@ -0,0 +1,262 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"regexp"
+	"strings"
+)
+
+// recordHeadingPattern matches "## [YYYY-MM-DD-HHMMSS] Title" headings.
+var recordHeadingPattern = regexp.MustCompile(`^##\s+\[([\d-]+)]\s*(.*?)\s*$`)
+
+// recordListPattern matches "- **[YYYY-MM-DD-HHMMSS]** Text" list items,
+// the older one-line learning format.
+var recordListPattern = regexp.MustCompile(`^-\s+\*\*\[([\d-]+)]\*\*\s*(.*?)\s*$`)
+
+// FieldID is the field holding the stable ID of a decision or learning.
+const FieldID = "ID"
+
+// fieldPattern matches "**Name**: value" field lines.
+var fieldPattern = regexp.MustCompile(`^\*\*([^*]+)\*\*:[ \t]?(.*)$`)
+
+// record is a timestamped entry shared by decisions and learnings.
+//
+// A record is kept as its source lines; the title, timestamp and fields
+// are read from those lines on demand, so edits never go out of sync.
+type record struct {
+	lines []string
+	span  Span
+}
+
+// field is a "**Name**: value" field located in a record.
+type field struct {
+	name  string
+	value string
+	start int
+	end   int
+}
+
+// Timestamp returns the timestamp from the entry heading.
+func (r *record) Timestamp() string {
+	if m := r.headingMatch(); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// Title returns the entry title without its timestamp.
+func (r *record) Title() string {
+	if m := r.headingMatch(); m != nil {
+		return m[2]
+	}
+	return ""
+}
+
+// ID returns the stable ID of the entry, e.g. "D-014", or "" if it has
+// none.
+func (r *record) ID() string {
+	return r.Field(FieldID)
+}
+
+// Span returns the location of the entry in the parsed source.
+func (r *record) Span() Span {
+	return r.span
+}
+
+// String returns the Markdown text of the entry.
+func (r *record) String() string {
+	return strings.Join(r.lines, "")
+}
+
+// Field returns the trimmed value of the named field, matched
+// case-insensitively, or "" if the entry has no such field.
+func (r *record) Field(name string) string {
+	if f, ok := r.field(name); ok {
+		return f.value
+	}
+	return ""
+}
+
+// HasField reports whether the entry has the named field.
+func (r *record) HasField(name string) bool {
+	_, ok := r.field(name)
+	return ok
+}
+
+// SetField sets the value of the named field.
+//
+// An existing field is replaced in place, including any continuation
+// lines; a missing field is appended after a blank line.
+func (r *record) SetField(name, value string) {
+	line := "**" + name + "**: " + value + "\n"
+
+	if f, ok := r.field(name); ok {
+		// Keep the blank lines that separated the old value from the
+		// next field
+		end := f.end
+		for end > f.start+1 && strings.TrimSpace(r.lines[end-1]) == "" {
+			end--
+		}
+		if end == len(r.lines) && lineEnding(r.lines[end-1]) == "" {
+			// The replaced value ended the file without a newline
+			line = strings.TrimSuffix(line, "\n")
+		}
+		lines := append([]string{}, r.lines[:f.start]...)
+		lines = append(lines, line)
+		r.lines = append(lines, r.lines[end:]...)
+		return
+	}
+
+	if n := len(r.lines); n > 0 && lineEnding(r.lines[n-1]) == "" {
+		r.lines[n-1] += "\n"
+	}
+	if n := len(r.lines); n > 0 && strings.TrimSpace(r.lines[n-1]) != "" {
+		r.lines = append(r.lines, "\n")
+	}
+	r.lines = append(r.lines, line)
+}
+
+// SetID sets the ID field. A missing ID is inserted directly below the
+// heading, where "ctx add" writes it.
+func (r *record) SetID(id string) {
+	if r.HasField(FieldID) || len(r.lines) == 0 {
+		r.SetField(FieldID, id)
+		return
+	}
+	if lineEnding(r.lines[0]) == "" {
+		r.lines[0] += "\n"
+	}
+	lines := append([]string{}, r.lines[0], "\n", "**"+FieldID+"**: "+id+"\n")
+	if len(r.lines) > 1 && strings.TrimSpace(r.lines[1]) != "" {
+		lines = append(lines, "\n")
+	}
+	r.lines = append(lines, r.lines[1:]...)
+}
+
+// headingMatch parses the first line of the entry.
+func (r *record) headingMatch() []string {
+	if len(r.lines) == 0 {
+		return nil
+	}
+	first := trimEOL(r.lines[0])
+	if m := recordHeadingPattern.FindStringSubmatch(first); m != nil {
+		return m
+	}
+	return recordListPattern.FindStringSubmatch(first)
+}
+
+// fields returns the fields of the entry in order.
+func (r *record) fields() []field {
+	var fields []field
+	for i := 1; i < len(r.lines); i++ {
+		m := fieldPattern.FindStringSubmatch(trimEOL(r.lines[i]))
+		if m == nil {
+			continue
+		}
+		if n := len(fields); n > 0 {
+			fields[n-1].end = i
+		}
+		fields = append(fields, field{name: m[1], start: i, end: len(r.lines)})
+	}
+
+	for i := range fields {
+		var value strings.Builder
+		value.WriteString(fieldPattern.FindStringSubmatch(
+			trimEOL(r.lines[fields[i].start]),
+		)[2])
+		for j := fields[i].start + 1; j < fields[i].end; j++ {
+			value.WriteString("\n")
+			value.WriteString(trimEOL(r.lines[j]))
+		}
+		fields[i].value = strings.TrimSpace(value.String())
+	}
+
+	return fields
+}
+
+// field looks up a field by name, case-insensitively.
+func (r *record) field(name string) (field, bool) {
+	for _, f := range r.fields() {
+		if strings.EqualFold(f.name, name) {
+			return f, true
+		}
+	}
+	return field{}, false
+}
+
+// parseRecords splits a DECISIONS.md or LEARNINGS.md source into
+// timestamped entries and free text.
+//
+// A heading entry ("## [timestamp] Title") runs until the next level 1-2
+// heading, horizontal rule or entry; a list entry ("- **[timestamp]**",
+// learnings only) runs until the next blank line or structural line.
+// Trailing blank lines are left to the free text between entries.
+func parseRecords(src *source, kind EntryKind) []docNode {
+	var b nodeBuilder
+
+	isList := func(i int) bool {
+		return kind == KindLearning && !src.comment[i] &&
+			recordListPattern.MatchString(trimEOL(src.lines[i]))
+	}
+	isHeading := func(i int) bool {
+		return !src.comment[i] &&
+			recordHeadingPattern.MatchString(trimEOL(src.lines[i]))
+	}
+
+	n := len(src.lines)
+	for i := 0; i < n; {
+		var end int
+		switch {
+		case isHeading(i):
+			end = i + 1
+			for j := i + 1; j < n; j++ {
+				if level, _, ok := src.heading(j); ok && level <= 2 {
+					break
+				}
+				if !src.comment[j] &&
+					separatorPattern.MatchString(trimEOL(src.lines[j])) {
+					break
+				}
+				if isList(j) {
+					break
+				}
+				if !src.isBlank(j) {
+					end = j + 1
+				}
+			}
+		case isList(i):
+			end = i + 1
+			for j := i + 1; j < n; j++ {
+				line := trimEOL(src.lines[j])
+				if src.isBlank(j) || strings.HasPrefix(line, "- ") ||
+					strings.HasPrefix(line, "#") ||
+					separatorPattern.MatchString(line) {
+					break
+				}
+				end = j + 1
+			}
+		default:
+			b.text(src.lines[i])
+			i++
+			continue
+		}
+
+		r := record{
+			lines: append([]string{}, src.lines[i:end]...),
+			span:  src.span(i, end),
+		}
+		if kind == KindDecision {
+			b.entry(&Decision{record: r})
+		} else {
+			b.entry(&Learning{record: r})
+		}
+		i = end
+	}
+
+	return b.done()
+}
@@ -45,8 +45,15 @@ func summarizeConstitution(content []byte) string {
 
 func summarizeTasks(content []byte) string {
 	// Count active (unchecked) and completed (checked) tasks
-	active := bytes.Count(content, []byte("- [ ]"))
-	completed := bytes.Count(content, []byte("- [x]"))
+	active, completed := 0, 0
+	for _, t := range ParseDocument(config.FilenameTask, content).Tasks() {
+		switch t.State() {
+		case TaskPending:
+			active++
+		case TaskDone:
+			completed++
+		}
+	}
 
 	if active == 0 && completed == 0 {
 		return "empty"
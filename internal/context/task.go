@@ -0,0 +1,563 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// TaskState is the checkbox state of a task.
+type TaskState string
+
+// Task states, as written in the checkbox.
+const (
+	TaskPending TaskState = "pending" // "- [ ]"
+	TaskDone    TaskState = "done"    // "- [x]"
+	TaskSkipped TaskState = "skipped" // "- [-]"
+)
+
+// Common task labels.
+const (
+	LabelInProgress = "in-progress"
+	LabelBlocked    = "blocked"
+	LabelPriority   = "priority"
+	LabelAdded      = "added"
+	LabelID         = "id"
+	LabelAfter      = "after"
+)
+
+// Task note names, written as "Name: text" lines below a task.
+const (
+	NoteBlocked = "Blocked"
+	NoteSkipped = "Skipped"
+)
+
+// taskPattern matches a checkbox line, capturing the indentation and list
+// marker, the checkbox content, the space after it and the task text.
+var taskPattern = regexp.MustCompile(`^(\s*-\s*)\[(\s*|[xX]|-)]([ \t]?)(.*?)\r?\n?$`)
+
+// labelPattern matches "#name" and "#name:value" labels. The leading
+// whitespace (or line start) is captured so that labels inside words or
+// backticks are ignored.
+var labelPattern = regexp.MustCompile("(^|\\s)#([A-Za-z][\\w-]*)(?::([^\\s`]+))?")
+
+// phasePattern matches the text of a phase heading, e.g. "Phase 1: Setup".
+var phasePattern = regexp.MustCompile(`^Phase\b`)
+
+// Label is an inline "#name" or "#name:value" task label.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// String returns the label as written in a task, e.g. "#priority:high".
+func (l Label) String() string {
+	if l.Value == "" {
+		return "#" + l.Name
+	}
+	return "#" + l.Name + ":" + l.Value
+}
+
+// Task is a checkbox item from TASKS.md.
+//
+// A task owns the indented lines below its checkbox line: continuation
+// text and nested subtasks, which are parsed as Children. Labels are read
+// from the checkbox line and from continuation lines that hold only
+// labels.
+type Task struct {
+	// Phase is the text of the enclosing "Phase" heading, if any.
+	Phase string
+	// Section is the text of the nearest enclosing heading.
+	Section string
+	// Parent is the enclosing task of a subtask; nil at the top level.
+	Parent *Task
+	// Children are the direct subtasks in file order.
+	Children []*Task
+
+	prefix   string
+	mark     string
+	sep      string
+	text     string
+	eol      string
+	head     string
+	modified bool
+	parts    []taskPart
+	span     Span
+}
+
+// taskPart is a line of continuation text or a subtask below a task.
+type taskPart struct {
+	line  string
+	child *Task
+}
+
+// Kind returns KindTask.
+func (t *Task) Kind() EntryKind {
+	return KindTask
+}
+
+// ID returns the stable ID of the task from its "#id:" label, or "" if
+// it has none.
+func (t *Task) ID() string {
+	id, _ := t.Label(LabelID)
+	return id
+}
+
+// Span returns the location of the task, including its continuation
+// lines and subtasks, in the parsed source.
+func (t *Task) Span() Span {
+	return t.span
+}
+
+// String returns the Markdown text of the task and its subtasks.
+func (t *Task) String() string {
+	var sb strings.Builder
+	if t.modified {
+		sb.WriteString(t.prefix + "[" + t.mark + "]" + t.sep + t.text + t.eol)
+	} else {
+		sb.WriteString(t.head)
+	}
+	for _, p := range t.parts {
+		if p.child != nil {
+			sb.WriteString(p.child.String())
+		} else {
+			sb.WriteString(p.line)
+		}
+	}
+	return sb.String()
+}
+
+// State returns the checkbox state of the task.
+func (t *Task) State() TaskState {
+	switch strings.TrimSpace(t.mark) {
+	case "":
+		return TaskPending
+	case "-":
+		return TaskSkipped
+	default:
+		return TaskDone
+	}
+}
+
+// SetState changes the checkbox state of the task.
+func (t *Task) SetState(state TaskState) {
+	switch state {
+	case TaskDone:
+		t.mark = "x"
+	case TaskSkipped:
+		t.mark = "-"
+	default:
+		t.mark = " "
+	}
+	t.touch()
+}
+
+// Text returns the text of the checkbox line after the checkbox,
+// including labels.
+func (t *Task) Text() string {
+	return t.text
+}
+
+// SetText replaces the text of the checkbox line after the checkbox.
+func (t *Task) SetText(text string) {
+	t.text = text
+	t.touch()
+}
+
+// Title returns the text of the checkbox line without labels.
+func (t *Task) Title() string {
+	return strings.Join(
+		strings.Fields(labelPattern.ReplaceAllString(t.text, "$1")), " ",
+	)
+}
+
+// Indent returns the indentation width of the checkbox line, counting
+// a tab as four spaces.
+func (t *Task) Indent() int {
+	return indentWidth(t.prefix)
+}
+
+// Labels returns the labels of the task in order of appearance.
+func (t *Task) Labels() []Label {
+	labels := parseLabels(t.text)
+	for _, p := range t.parts {
+		if p.child == nil && isLabelLine(p.line) {
+			labels = append(labels, parseLabels(p.line)...)
+		}
+	}
+	return labels
+}
+
+// Label returns the value of the named label and whether it is present.
+func (t *Task) Label(name string) (string, bool) {
+	for _, l := range t.Labels() {
+		if l.Name == name {
+			return l.Value, true
+		}
+	}
+	return "", false
+}
+
+// HasLabel reports whether the task carries the named label.
+func (t *Task) HasLabel(name string) bool {
+	_, ok := t.Label(name)
+	return ok
+}
+
+// Dependencies returns the IDs of the tasks this task depends on, from
+// "#after:T-012" labels. A label may list several IDs separated by
+// commas. IDs are normalized; values that are not IDs are returned as
+// written.
+func (t *Task) Dependencies() []string {
+	var deps []string
+	for _, l := range t.Labels() {
+		if l.Name != LabelAfter {
+			continue
+		}
+		for _, v := range strings.Split(l.Value, ",") {
+			v = strings.TrimSpace(v)
+			if v == "" {
+				continue
+			}
+			if id, ok := NormalizeID(v); ok {
+				v = id
+			}
+			if !slices.Contains(deps, v) {
+				deps = append(deps, v)
+			}
+		}
+	}
+	return deps
+}
+
+// SetLabel adds the label to the task, or updates its value where it is
+// already written. New labels are appended to the checkbox line.
+func (t *Task) SetLabel(name, value string) {
+	label := Label{Name: name, Value: value}.String()
+
+	if text, ok := replaceLabel(t.text, name, label); ok {
+		t.SetText(text)
+		return
+	}
+	for i, p := range t.parts {
+		if p.child != nil || !isLabelLine(p.line) {
+			continue
+		}
+		if line, ok := replaceLabel(p.line, name, label); ok {
+			t.parts[i].line = line
+			return
+		}
+	}
+
+	t.SetText(strings.TrimRight(t.text, " \t") + " " + label)
+}
+
+// RemoveLabel removes every occurrence of the named label. Continuation
+// lines left empty by the removal are dropped.
+func (t *Task) RemoveLabel(name string) {
+	if text, ok := replaceLabel(t.text, name, ""); ok {
+		t.SetText(strings.TrimRight(text, " \t"))
+	}
+
+	parts := t.parts[:0]
+	for _, p := range t.parts {
+		if p.child == nil && isLabelLine(p.line) {
+			if line, ok := replaceLabel(p.line, name, ""); ok {
+				if strings.TrimSpace(line) == "" {
+					continue
+				}
+				p.line = strings.TrimRight(trimEOL(line), " \t") + lineEnding(line)
+			}
+		}
+		parts = append(parts, p)
+	}
+	t.parts = parts
+}
+
+// Note returns the text of the named note, a continuation line of the
+// form "Name: text", and whether it is present.
+func (t *Task) Note(name string) (string, bool) {
+	for _, p := range t.parts {
+		if p.child != nil {
+			continue
+		}
+		if text, ok := parseNote(p.line, name); ok {
+			return text, true
+		}
+	}
+	return "", false
+}
+
+// SetNote sets the named note. An existing note is replaced in place; a
+// new one is inserted directly below the checkbox line.
+func (t *Task) SetNote(name, text string) {
+	for i, p := range t.parts {
+		if p.child != nil {
+			continue
+		}
+		if _, ok := parseNote(p.line, name); ok {
+			t.parts[i].line = leadingSpace(p.line) + name + ": " + text +
+				lineEnding(p.line)
+			return
+		}
+	}
+
+	line := leadingSpace(t.prefix) + "  " + name + ": " + text
+	if t.eol == "" {
+		// The checkbox line ended the file; the note now ends it instead
+		t.eol = "\n"
+		t.head += t.eol
+	} else {
+		line += t.eol
+	}
+	t.parts = append([]taskPart{{line: line}}, t.parts...)
+}
+
+// RemoveNote removes the named note.
+func (t *Task) RemoveNote(name string) {
+	parts := t.parts[:0]
+	for _, p := range t.parts {
+		if p.child == nil {
+			if _, ok := parseNote(p.line, name); ok {
+				continue
+			}
+		}
+		parts = append(parts, p)
+	}
+	t.parts = parts
+}
+
+// Walk returns the task followed by all of its subtasks, depth first.
+func (t *Task) Walk() []*Task {
+	tasks := []*Task{t}
+	for _, c := range t.Children {
+		tasks = append(tasks, c.Walk()...)
+	}
+	return tasks
+}
+
+// OpenSubtasks returns the pending subtasks of the task, at any depth,
+// in file order.
+func (t *Task) OpenSubtasks() []*Task {
+	var open []*Task
+	for _, sub := range t.Walk()[1:] {
+		if sub.State() == TaskPending {
+			open = append(open, sub)
+		}
+	}
+	return open
+}
+
+// Progress returns the number of subtasks of the task, at any depth,
+// that are closed (done or skipped), and the total number of subtasks.
+func (t *Task) Progress() (closed, total int) {
+	for _, sub := range t.Walk()[1:] {
+		total++
+		if sub.State() != TaskPending {
+			closed++
+		}
+	}
+	return closed, total
+}
+
+// IsComplete reports whether the task is done and none of its subtasks
+// is still pending, so that the whole subtree can be archived.
+func (t *Task) IsComplete() bool {
+	return t.State() == TaskDone && len(t.OpenSubtasks()) == 0
+}
+
+// touch marks the checkbox line as modified so it is re-rendered.
+func (t *Task) touch() {
+	t.modified = true
+}
+
+// parseTasks splits a TASKS.md source into tasks and free text.
+//
+// Tasks record the enclosing phase and section headings. HTML comments
+// are kept as free text, so example checkboxes in them are ignored.
+func parseTasks(src *source) []docNode {
+	type heading struct {
+		level int
+		text  string
+	}
+
+	var b nodeBuilder
+	var headings []heading
+
+	for i := 0; i < len(src.lines); {
+		if level, text, ok := src.heading(i); ok {
+			for len(headings) > 0 && headings[len(headings)-1].level >= level {
+				headings = headings[:len(headings)-1]
+			}
+			headings = append(headings, heading{level, text})
+			b.text(src.lines[i])
+			i++
+			continue
+		}
+
+		if !src.comment[i] {
+			var phase, section string
+			if len(headings) > 0 {
+				section = headings[len(headings)-1].text
+			}
+			for _, h := range headings {
+				if phasePattern.MatchString(h.text) {
+					phase = h.text
+				}
+			}
+
+			if t, end := parseTask(src, i, nil, phase, section); t != nil {
+				b.entry(t)
+				i = end
+				continue
+			}
+		}
+
+		b.text(src.lines[i])
+		i++
+	}
+
+	return b.done()
+}
+
+// parseTask parses the task starting at line i together with its
+// continuation lines and subtasks.
+//
+// Lines indented deeper than the checkbox belong to the task. Blank lines
+// belong to it only when more such lines follow them.
+//
+// Returns the task, or nil if line i is not a checkbox line, and the index
+// of the first line after the task.
+func parseTask(
+	src *source, i int, parent *Task, phase, section string,
+) (*Task, int) {
+	m := taskPattern.FindStringSubmatch(src.lines[i])
+	if m == nil {
+		return nil, i
+	}
+
+	t := &Task{
+		Phase:   phase,
+		Section: section,
+		Parent:  parent,
+		prefix:  m[1],
+		mark:    m[2],
+		sep:     m[3],
+		text:    m[4],
+		eol:     lineEnding(src.lines[i]),
+		head:    src.lines[i],
+	}
+	if t.sep == "" {
+		t.sep = " "
+	}
+	indent := t.Indent()
+
+	n := len(src.lines)
+	j := i + 1
+	for j < n {
+		if src.isBlank(j) {
+			k := j
+			for k < n && src.isBlank(k) {
+				k++
+			}
+			if k == n || indentWidth(src.lines[k]) <= indent {
+				break
+			}
+			for ; j < k; j++ {
+				t.parts = append(t.parts, taskPart{line: src.lines[j]})
+			}
+			continue
+		}
+
+		if indentWidth(src.lines[j]) <= indent {
+			break
+		}
+		if _, _, ok := src.heading(j); ok {
+			break
+		}
+
+		if child, end := parseTask(src, j, t, phase, section); child != nil {
+			t.Children = append(t.Children, child)
+			t.parts = append(t.parts, taskPart{child: child})
+			j = end
+			continue
+		}
+
+		t.parts = append(t.parts, taskPart{line: src.lines[j]})
+		j++
+	}
+
+	t.span = src.span(i, j)
+	return t, j
+}
+
+// parseLabels extracts the labels from a line of text.
+func parseLabels(text string) []Label {
+	var labels []Label
+	for _, m := range labelPattern.FindAllStringSubmatch(text, -1) {
+		labels = append(labels, Label{Name: m[2], Value: m[3]})
+	}
+	return labels
+}
+
+// parseNote returns the text of a "Name: text" continuation line if it
+// holds the named note.
+func parseNote(line, name string) (string, bool) {
+	text := strings.TrimSpace(line)
+	prefix := name + ":"
+	if len(text) < len(prefix) || !strings.EqualFold(text[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(text[len(prefix):]), true
+}
+
+// isLabelLine reports whether a line contains only labels.
+func isLabelLine(line string) bool {
+	rest := strings.TrimSpace(labelPattern.ReplaceAllString(line, "$1"))
+	return rest == "" && len(parseLabels(line)) > 0
+}
+
+// replaceLabel replaces every occurrence of the named label in text.
+func replaceLabel(text, name, replacement string) (string, bool) {
+	found := false
+	out := labelPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := labelPattern.FindStringSubmatch(match)
+		if m[2] != name {
+			return match
+		}
+		found = true
+		if replacement == "" {
+			return ""
+		}
+		return m[1] + replacement
+	})
+	return out, found
+}
+
+// leadingSpace returns the leading whitespace of a line.
+func leadingSpace(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
+
+// indentWidth returns the width of the leading whitespace of a line,
+// counting a tab as four spaces.
+func indentWidth(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
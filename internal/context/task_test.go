@@ -0,0 +1,289 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+const sampleTasks = `# Tasks
+
+<!--
+- [ ] Example task in a comment
+-->
+
+### Phase 1: Setup ` + "`#priority:high`" + `
+- [ ] Set up repo #priority:high #added:2026-01-28
+- [x] Write README
+  - [x] Install section
+  - [ ] Usage section #in-progress
+    notes about usage
+
+  - [-] Badges (skipped: no CI yet)
+
+### Phase 2: Build
+- [ ] Implement parser
+  #priority:medium
+
+## Backlog
+
+- [ ] Someday task
+`
+
+func TestParseTasks(t *testing.T) {
+	doc := ParseDocument(config.FilenameTask, []byte(sampleTasks))
+	tasks := doc.Tasks()
+
+	want := []struct {
+		title    string
+		state    TaskState
+		phase    string
+		section  string
+		children int
+	}{
+		{"Set up repo", TaskPending, "Phase 1: Setup `#priority:high`", "Phase 1: Setup `#priority:high`", 0},
+		{"Write README", TaskDone, "Phase 1: Setup `#priority:high`", "Phase 1: Setup `#priority:high`", 3},
+		{"Install section", TaskDone, "Phase 1: Setup `#priority:high`", "Phase 1: Setup `#priority:high`", 0},
+		{"Usage section", TaskPending, "Phase 1: Setup `#priority:high`", "Phase 1: Setup `#priority:high`", 0},
+		{"Badges (skipped: no CI yet)", TaskSkipped, "Phase 1: Setup `#priority:high`", "Phase 1: Setup `#priority:high`", 0},
+		{"Implement parser", TaskPending, "Phase 2: Build", "Phase 2: Build", 0},
+		{"Someday task", TaskPending, "", "Backlog", 0},
+	}
+
+	if len(tasks) != len(want) {
+		t.Fatalf("got %d tasks, want %d", len(tasks), len(want))
+	}
+	for i, w := range want {
+		task := tasks[i]
+		if task.Title() != w.title {
+			t.Errorf("task %d Title = %q, want %q", i, task.Title(), w.title)
+		}
+		if task.State() != w.state {
+			t.Errorf("task %d State = %q, want %q", i, task.State(), w.state)
+		}
+		if task.Phase != w.phase {
+			t.Errorf("task %d Phase = %q, want %q", i, task.Phase, w.phase)
+		}
+		if task.Section != w.section {
+			t.Errorf("task %d Section = %q, want %q", i, task.Section, w.section)
+		}
+		if len(task.Children) != w.children {
+			t.Errorf("task %d has %d children, want %d", i, len(task.Children), w.children)
+		}
+	}
+
+	readme := tasks[1]
+	if tasks[3].Parent != readme {
+		t.Error("subtask Parent not set")
+	}
+	if !strings.Contains(tasks[3].String(), "notes about usage") {
+		t.Errorf("continuation line not attached to subtask: %q", tasks[3].String())
+	}
+	if span := readme.Span(); span.StartLine != 9 || span.EndLine != 14 {
+		t.Errorf("README span = %d-%d, want 9-14", span.StartLine, span.EndLine)
+	}
+}
+
+func TestTaskLabels(t *testing.T) {
+	doc := ParseDocument(config.FilenameTask, []byte(sampleTasks))
+	tasks := doc.Tasks()
+
+	labels := tasks[0].Labels()
+	if len(labels) != 2 || labels[0] != (Label{"priority", "high"}) ||
+		labels[1] != (Label{"added", "2026-01-28"}) {
+		t.Errorf("Labels = %v", labels)
+	}
+
+	if !tasks[3].HasLabel(LabelInProgress) {
+		t.Error("expected #in-progress label")
+	}
+
+	// Labels on a continuation line count too
+	if v, ok := tasks[5].Label(LabelPriority); !ok || v != "medium" {
+		t.Errorf("Label(priority) = %q, %v; want medium, true", v, ok)
+	}
+
+	// Backticked labels in headings are not task labels
+	if (Label{"priority", "high"}).String() != "#priority:high" {
+		t.Error("Label.String mismatch")
+	}
+}
+
+func TestTaskEdits(t *testing.T) {
+	doc := ParseDocument(config.FilenameTask, []byte(sampleTasks))
+	tasks := doc.Tasks()
+
+	tasks[0].SetState(TaskDone)
+	tasks[0].SetLabel(LabelPriority, "low")
+	tasks[3].RemoveLabel(LabelInProgress)
+	tasks[5].SetLabel(LabelPriority, "high")
+	tasks[6].SetLabel(LabelBlocked, "")
+	tasks[6].SetState(TaskSkipped)
+
+	want := strings.NewReplacer(
+		"- [ ] Set up repo #priority:high", "- [x] Set up repo #priority:low",
+		"- [ ] Usage section #in-progress\n", "- [ ] Usage section\n",
+		"  #priority:medium\n", "  #priority:high\n",
+		"- [ ] Someday task\n", "- [-] Someday task #blocked\n",
+	).Replace(sampleTasks)
+
+	if got := doc.String(); got != want {
+		t.Errorf("edited document:\n%s\nwant:\n%s", got, want)
+	}
+
+	tasks[5].RemoveLabel(LabelPriority)
+	if strings.Contains(doc.String(), "#priority:high\n\n## Backlog") {
+		t.Error("label-only line not removed")
+	}
+}
+
+func TestTaskNotes(t *testing.T) {
+	doc := ParseDocument(config.FilenameTask, []byte(sampleTasks))
+	tasks := doc.Tasks()
+
+	tasks[1].SetNote(NoteBlocked, "waiting on review")
+	tasks[6].SetNote(NoteSkipped, "not needed")
+
+	want := strings.NewReplacer(
+		"- [x] Write README\n", "- [x] Write README\n  Blocked: waiting on review\n",
+		"- [ ] Someday task\n", "- [ ] Someday task\n  Skipped: not needed\n",
+	).Replace(sampleTasks)
+	if got := doc.String(); got != want {
+		t.Fatalf("document with notes:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Notes survive a round trip and stay with their task
+	reparsed := ParseDocument(config.FilenameTask, doc.Bytes()).Tasks()
+	if note, ok := reparsed[1].Note(NoteBlocked); !ok || note != "waiting on review" {
+		t.Errorf("Note(Blocked) = %q, %v", note, ok)
+	}
+	if len(reparsed[1].Children) != 3 {
+		t.Errorf("note displaced subtasks: %d children", len(reparsed[1].Children))
+	}
+
+	reparsed[1].SetNote(NoteBlocked, "approved")
+	reparsed[6].RemoveNote(NoteSkipped)
+	if _, ok := reparsed[6].Note(NoteSkipped); ok {
+		t.Error("note not removed")
+	}
+	if note, _ := reparsed[1].Note(NoteBlocked); note != "approved" {
+		t.Errorf("Note(Blocked) = %q after update", note)
+	}
+
+	// A note on the last line of a file without a trailing newline
+	doc = ParseDocument(config.FilenameTask, []byte("# Tasks\n- [ ] Last"))
+	doc.Tasks()[0].SetNote(NoteSkipped, "why")
+	if got := doc.String(); got != "# Tasks\n- [ ] Last\n  Skipped: why" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestMoveTask(t *testing.T) {
+	doc := ParseDocument(config.FilenameTask, []byte(sampleTasks))
+
+	wantPhases := []string{"Phase 1: Setup `#priority:high`", "Phase 2: Build"}
+	if got := doc.Phases(); len(got) != 2 || got[0] != wantPhases[0] ||
+		got[1] != wantPhases[1] {
+		t.Errorf("Phases() = %q, want %q", got, wantPhases)
+	}
+
+	someday := doc.Tasks()[6]
+	if err := doc.MoveTask(someday, "Phase 2: Build"); err != nil {
+		t.Fatalf("MoveTask() error: %v", err)
+	}
+
+	want := strings.Replace(sampleTasks,
+		"  #priority:medium\n\n## Backlog\n\n- [ ] Someday task\n",
+		"  #priority:medium\n- [ ] Someday task\n\n## Backlog\n\n", 1)
+	if got := doc.String(); got != want {
+		t.Errorf("moved document:\n%s\nwant:\n%s", got, want)
+	}
+
+	tasks := doc.Tasks()
+	if moved := tasks[len(tasks)-1]; moved.Phase != "Phase 2: Build" {
+		t.Errorf("moved task phase = %q", moved.Phase)
+	}
+
+	if err := doc.MoveTask(tasks[2], "Phase 2: Build"); err == nil {
+		t.Error("moving a subtask should fail")
+	}
+	if err := doc.MoveTask(tasks[0], "Phase 9"); err == nil {
+		t.Error("moving to an unknown phase should fail")
+	}
+}
+
+func TestTaskDependencies(t *testing.T) {
+	doc := ParseDocument(config.FilenameTask, []byte(
+		"# Tasks\n\n"+
+			"- [ ] A #id:T-001\n"+
+			"- [ ] B #id:T-002 #after:t-1,T-003 #after:T-003\n"+
+			"- [ ] C #id:T-003 #after:T-002\n"+
+			"- [ ] D #id:T-004 #after:later\n",
+	))
+	tasks := doc.Tasks()
+
+	if deps := tasks[0].Dependencies(); deps != nil {
+		t.Errorf("Dependencies() = %q, want none", deps)
+	}
+	want := []string{"T-001", "T-003"}
+	if deps := tasks[1].Dependencies(); !slices.Equal(deps, want) {
+		t.Errorf("Dependencies() = %q, want %q", deps, want)
+	}
+	if deps := tasks[3].Dependencies(); !slices.Equal(deps, []string{"later"}) {
+		t.Errorf("Dependencies() = %q, want [later]", deps)
+	}
+
+	cycles := DependencyCycles(tasks)
+	if len(cycles) != 1 || len(cycles[0]) != 2 ||
+		cycles[0][0] != tasks[1] || cycles[0][1] != tasks[2] {
+		t.Errorf("DependencyCycles() = %v, want [[T-002 T-003]]", cycles)
+	}
+}
+
+func TestTaskProgress(t *testing.T) {
+	doc := ParseDocument(config.FilenameTask, []byte(
+		"# Tasks\n\n"+
+			"- [x] Parent\n"+
+			"  - [x] Done\n"+
+			"  - [ ] Open\n"+
+			"    - [-] Skipped\n"+
+			"- [x] Leaf\n",
+	))
+	tasks := doc.Tasks()
+	parent, open, leaf := tasks[0], tasks[2], tasks[4]
+
+	if got := parent.OpenSubtasks(); len(got) != 1 || got[0] != open {
+		t.Errorf("OpenSubtasks() = %v, want [Open]", got)
+	}
+	if closed, total := parent.Progress(); closed != 2 || total != 3 {
+		t.Errorf("Progress() = %d/%d, want 2/3", closed, total)
+	}
+	if parent.IsComplete() {
+		t.Error("parent with an open subtask should not be complete")
+	}
+	if !leaf.IsComplete() {
+		t.Error("done leaf task should be complete")
+	}
+	if open.IsComplete() {
+		t.Error("pending task should not be complete")
+	}
+
+	if err := doc.RemoveTasks(open); err == nil {
+		t.Error("removing a subtask should fail")
+	}
+	if err := doc.RemoveTasks(leaf); err != nil {
+		t.Fatalf("RemoveTasks() error: %v", err)
+	}
+	if got := doc.String(); strings.Contains(got, "Leaf") ||
+		!strings.Contains(got, "    - [-] Skipped\n") {
+		t.Errorf("document after RemoveTasks:\n%s", got)
+	}
+}
@@ -8,6 +8,7 @@
 package drift
 
 import (
+	"fmt"
 	"os"
 	"regexp"
 	"strings"
@@ -64,6 +65,12 @@ func Detect(ctx *context.Context) *Report {
 	// Check for empty required files
 	checkRequiredFiles(ctx, report)
 
+	// Check entry IDs and [[ID]] references between entries
+	checkEntryReferences(ctx, report)
+
+	// Check "#after:" task dependencies for cycles and missing tasks
+	checkTaskDependencies(ctx, report)
+
 	return report
 }
 
@@ -209,6 +216,111 @@ func checkRequiredFiles(ctx *context.Context, report *Report) {
 	}
 }
 
+func checkEntryReferences(ctx *context.Context, report *Report) {
+	idx, err := context.LoadIndex(ctx.Dir)
+	if err != nil {
+		return
+	}
+
+	foundIssues := false
+
+	for _, ref := range idx.Refs() {
+		entry, ok := idx.Lookup(ref.ID)
+		switch {
+		case !ok:
+			report.Warnings = append(report.Warnings, Issue{
+				File:    ref.File,
+				Line:    ref.Line,
+				Type:    "unknown_reference",
+				Message: fmt.Sprintf("references unknown entry [[%s]]", ref.ID),
+			})
+			foundIssues = true
+		case entry.Archived:
+			report.Warnings = append(report.Warnings, Issue{
+				File: ref.File,
+				Line: ref.Line,
+				Type: "archived_reference",
+				Message: fmt.Sprintf(
+					"references archived entry [[%s]] (%s)", ref.ID, entry.File,
+				),
+			})
+			foundIssues = true
+		}
+	}
+
+	for _, dup := range idx.Duplicates() {
+		report.Warnings = append(report.Warnings, Issue{
+			File:    dup.File,
+			Line:    dup.Entry.Span().StartLine,
+			Type:    "duplicate_id",
+			Message: fmt.Sprintf("ID %s is used by more than one entry", dup.ID),
+		})
+		foundIssues = true
+	}
+
+	if !foundIssues {
+		report.Passed = append(report.Passed, "entry_references")
+	}
+}
+
+func checkTaskDependencies(ctx *context.Context, report *Report) {
+	var doc *context.Document
+	for _, f := range ctx.Files {
+		if f.Name == config.FilenameTask {
+			doc = context.ParseDocument(f.Name, f.Content)
+		}
+	}
+	if doc == nil {
+		return
+	}
+	idx, err := context.LoadIndex(ctx.Dir)
+	if err != nil {
+		return
+	}
+
+	foundIssues := false
+	tasks := doc.Tasks()
+
+	for _, t := range tasks {
+		for _, dep := range t.Dependencies() {
+			if e, ok := idx.Lookup(dep); ok && e.Entry.Kind() == context.KindTask {
+				continue
+			}
+			report.Warnings = append(report.Warnings, Issue{
+				File: config.FilenameTask,
+				Line: t.Span().StartLine,
+				Type: "missing_dependency",
+				Message: fmt.Sprintf(
+					"depends on %s, which is not a task in TASKS.md or the archive",
+					dep,
+				),
+			})
+			foundIssues = true
+		}
+	}
+
+	for _, cycle := range context.DependencyCycles(tasks) {
+		ids := make([]string, len(cycle))
+		for i, t := range cycle {
+			ids[i] = t.ID()
+		}
+		report.Warnings = append(report.Warnings, Issue{
+			File: config.FilenameTask,
+			Line: cycle[0].Span().StartLine,
+			Type: "dependency_cycle",
+			Message: fmt.Sprintf(
+				"tasks depend on each other in a cycle: %s",
+				strings.Join(ids, ", "),
+			),
+		})
+		foundIssues = true
+	}
+
+	if !foundIssues {
+		report.Passed = append(report.Passed, "task_dependencies")
+	}
+}
+
 func isTemplateFile(content []byte) bool {
 	s := string(content)
 	// Check for common template markers
@@ -337,3 +337,124 @@ func TestIsTemplateFile(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckEntryReferences(t *testing.T) {
+	ctxDir := t.TempDir()
+	files := map[string]string{
+		"DECISIONS.md":                "# Decisions\n\n## [2026-01-01-100000] Use Go\n\n**ID**: D-001\n\n**Context**: See [[T-002]]\n",
+		"TASKS.md":                    "# Tasks\n\n- [ ] Build on [[D-001]] and [[D-009]] #id:T-005\n- [ ] Again #id:T-005\n",
+		"archive/tasks-2026-01-01.md": "# Archived Tasks - 2026-01-01\n\n- [x] Setup #id:T-002\n",
+	}
+	if err := os.Mkdir(filepath.Join(ctxDir, "archive"), 0755); err != nil {
+		t.Fatalf("failed to create archive dir: %v", err)
+	}
+	for name, content := range files {
+		path := filepath.Join(ctxDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	ctx, err := context.Load(ctxDir)
+	if err != nil {
+		t.Fatalf("failed to load context: %v", err)
+	}
+
+	report := &Report{
+		Warnings:   []Issue{},
+		Violations: []Issue{},
+		Passed:     []string{},
+	}
+	checkEntryReferences(ctx, report)
+
+	want := []Issue{
+		{
+			File: "DECISIONS.md", Line: 7, Type: "archived_reference",
+			Message: "references archived entry [[T-002]] (archive/tasks-2026-01-01.md)",
+		},
+		{
+			File: "TASKS.md", Line: 3, Type: "unknown_reference",
+			Message: "references unknown entry [[D-009]]",
+		},
+		{
+			File: "TASKS.md", Line: 4, Type: "duplicate_id",
+			Message: "ID T-005 is used by more than one entry",
+		},
+	}
+	if len(report.Warnings) != len(want) {
+		t.Fatalf("got %d warnings, want %d: %+v",
+			len(report.Warnings), len(want), report.Warnings)
+	}
+	for i, w := range want {
+		if report.Warnings[i] != w {
+			t.Errorf("warning %d = %+v, want %+v", i, report.Warnings[i], w)
+		}
+	}
+	for _, p := range report.Passed {
+		if p == "entry_references" {
+			t.Error("entry_references should not pass")
+		}
+	}
+}
+
+func TestCheckTaskDependencies(t *testing.T) {
+	ctxDir := t.TempDir()
+	files := map[string]string{
+		"TASKS.md": "# Tasks\n\n" +
+			"- [ ] Ship #id:T-003 #after:T-001,T-009\n" +
+			"- [ ] Ping #id:T-004 #after:T-005\n" +
+			"- [ ] Pong #id:T-005 #after:T-004\n" +
+			"- [ ] Self #id:T-006 #after:T-006\n",
+		"archive/tasks-2026-01-01.md": "# Archived Tasks - 2026-01-01\n\n- [x] Setup #id:T-001\n",
+	}
+	if err := os.Mkdir(filepath.Join(ctxDir, "archive"), 0755); err != nil {
+		t.Fatalf("failed to create archive dir: %v", err)
+	}
+	for name, content := range files {
+		path := filepath.Join(ctxDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	ctx, err := context.Load(ctxDir)
+	if err != nil {
+		t.Fatalf("failed to load context: %v", err)
+	}
+
+	report := &Report{
+		Warnings:   []Issue{},
+		Violations: []Issue{},
+		Passed:     []string{},
+	}
+	checkTaskDependencies(ctx, report)
+
+	want := []Issue{
+		{
+			File: "TASKS.md", Line: 3, Type: "missing_dependency",
+			Message: "depends on T-009, which is not a task in TASKS.md or the archive",
+		},
+		{
+			File: "TASKS.md", Line: 4, Type: "dependency_cycle",
+			Message: "tasks depend on each other in a cycle: T-004, T-005",
+		},
+		{
+			File: "TASKS.md", Line: 6, Type: "dependency_cycle",
+			Message: "tasks depend on each other in a cycle: T-006",
+		},
+	}
+	if len(report.Warnings) != len(want) {
+		t.Fatalf("got %d warnings, want %d: %+v",
+			len(report.Warnings), len(want), report.Warnings)
+	}
+	for i, w := range want {
+		if report.Warnings[i] != w {
+			t.Errorf("warning %d = %+v, want %+v", i, report.Warnings[i], w)
+		}
+	}
+	for _, p := range report.Passed {
+		if p == "task_dependencies" {
+			t.Error("task_dependencies should not pass")
+		}
+	}
+}
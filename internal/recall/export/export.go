@@ -0,0 +1,159 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+// Package export renders sessions as Markdown, HTML and JSON documents.
+//
+// The output depends on nothing but the session and the options: times
+// are written in UTC and tool inputs in key order, so an export can be
+// committed and regenerated without spurious changes.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+	"github.com/ActiveMemory/ctx/internal/validation"
+)
+
+// Format is an export format, named by its file extension.
+type Format string
+
+// Export formats.
+const (
+	FormatMarkdown Format = "md"
+	FormatHTML     Format = "html"
+	FormatJSON     Format = "json"
+)
+
+// Formats lists the export formats.
+var Formats = []Format{FormatMarkdown, FormatHTML, FormatJSON}
+
+// Options select the parts of a session to export. Text and tool calls
+// are always exported.
+type Options struct {
+	// Thinking includes reasoning blocks
+	Thinking bool
+	// ToolResults includes the results of tool calls
+	ToolResults bool
+	// Sidechains includes subagent conversations
+	Sidechains bool
+}
+
+// All exports every part of a session.
+var All = Options{Thinking: true, ToolResults: true, Sidechains: true}
+
+// Render writes a loaded session in a format.
+func Render(w io.Writer, s *parser.Session, format Format, opts Options) error {
+	switch format {
+	case FormatMarkdown:
+		_, err := io.WriteString(w, Markdown(s, opts))
+		return err
+	case FormatHTML:
+		return HTML(w, s, opts)
+	case FormatJSON:
+		data, err := json.MarshalIndent(filter(s, opts), "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(data, '\n'))
+		return err
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// FileName returns the name of a session's export file: its start date,
+// slug and short ID, e.g. "2026-01-21-gleaming-wobbling-sutherland-abc12345.md".
+func FileName(s *parser.Session, format Format) string {
+	name := s.StartTime.UTC().Format("2006-01-02")
+	if s.Slug != "" {
+		name += "-" + validation.SanitizeFilename(s.Slug)
+	}
+	if s.ID != "" {
+		id := validation.SanitizeFilename(s.ID)
+		name += "-" + id[:min(8, len(id))]
+	}
+	return name + "." + string(format)
+}
+
+// filter returns a copy of a session without the parts the options
+// leave out. The session itself is not changed.
+func filter(s *parser.Session, opts Options) *parser.Session {
+	c := *s
+	c.Messages = filterMessages(s.Messages, opts)
+	c.Branches = nil
+	for _, b := range s.Branches {
+		c.Branches = append(c.Branches, parser.Branch{
+			ParentID: b.ParentID, Messages: filterMessages(b.Messages, opts),
+		})
+	}
+	c.Sidechains = nil
+	if opts.Sidechains {
+		for _, b := range s.Sidechains {
+			c.Sidechains = append(c.Sidechains, parser.Branch{
+				ParentID: b.ParentID, Messages: filterMessages(b.Messages, opts),
+			})
+		}
+	}
+	return &c
+}
+
+// filterMessages returns copies of messages without the parts the
+// options leave out, dropping messages left empty.
+func filterMessages(msgs []parser.Message, opts Options) []parser.Message {
+	var out []parser.Message
+	for _, m := range msgs {
+		if !opts.Thinking {
+			m.Thinking = ""
+		}
+		// File contents recorded with results are for replaying edits
+		results := m.ToolResults
+		m.ToolResults = nil
+		if opts.ToolResults {
+			for _, r := range results {
+				r.File = nil
+				m.ToolResults = append(m.ToolResults, r)
+			}
+		}
+		tools := make([]parser.ToolUse, len(m.ToolUses))
+		for i, t := range m.ToolUses {
+			t.Sidechain = nil
+			if opts.Sidechains {
+				t.Sidechain = filterMessages(m.ToolUses[i].Sidechain, opts)
+			}
+			tools[i] = t
+		}
+		m.ToolUses = tools
+		if len(tools) == 0 {
+			m.ToolUses = nil
+		}
+		if strings.TrimSpace(m.Text) == "" && m.Thinking == "" &&
+			len(m.ToolUses) == 0 && len(m.ToolResults) == 0 {
+			continue
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+// title returns the title of a session's export.
+func title(s *parser.Session) string {
+	switch {
+	case s.Slug != "":
+		return s.Slug
+	case s.ID != "":
+		return s.ID
+	}
+	return "Conversation Transcript"
+}
+
+// formatTime formats a time in UTC.
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05 UTC")
+}
@@ -0,0 +1,121 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// testSession returns a session with thinking, a tool result carrying
+// file content and a subagent conversation, in a time zone other than
+// UTC.
+func testSession() *parser.Session {
+	zone := time.FixedZone("CET", 3600)
+	at := func(sec int) time.Time { return time.Date(2026, 1, 21, 11, 0, sec, 0, zone) }
+	file := "package main\n"
+	return &parser.Session{
+		ID:           "abcdef12-3456-7890",
+		Slug:         "gleaming-wobbling-sutherland",
+		Tool:         "claude-code",
+		Project:      "ctx",
+		SourceFile:   "/home/user/.claude/projects/ctx/abcdef12.jsonl",
+		StartTime:    at(0),
+		Duration:     5 * time.Second,
+		TurnCount:    1,
+		FirstUserMsg: "Read main.go",
+		Messages: []parser.Message{
+			{Role: "user", Timestamp: at(0), Text: "Read main.go"},
+			{Role: "assistant", Timestamp: at(1), Thinking: "Reading first", ToolUses: []parser.ToolUse{
+				{ID: "t1", Name: "Read", Input: `{"file_path":"/src/main.go","limit":10}`},
+				{ID: "t2", Name: "Task", Input: `{"description":"Look around"}`, Sidechain: []parser.Message{
+					{Role: "user", Timestamp: at(2), Text: "Look around"},
+					{Role: "assistant", Timestamp: at(3), Text: "Nothing else"},
+				}},
+			}},
+			{Role: "user", Timestamp: at(4), ToolResults: []parser.ToolResult{
+				{ToolUseID: "t1", Content: "     1→package main", File: &file},
+			}},
+			{Role: "assistant", Timestamp: at(5), Text: "It is the main package"},
+		},
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	s := testSession()
+	out := Markdown(s, All)
+	for _, want := range []string{
+		"# gleaming-wobbling-sutherland\n\n**Date**: 2026-01-21\n**ID**: abcdef12-3456-7890\n",
+		"**Started**: 2026-01-21 10:00:00 UTC\n",
+		"**Source**: abcdef12.jsonl\n",
+		"## Summary",
+		"## User\n\n*2026-01-21 10:00:00 UTC*\n\nRead main.go\n\n---\n\n",
+		"<summary>💭 Thinking</summary>\n\nReading first\n</details>",
+		"**🔧 Tool: Read**\n- file_path: `/src/main.go`\n- limit: `10`\n",
+		"<summary>🤖 Subagent (2 messages)</summary>\n\n### User\n",
+		"## Tool Results\n",
+		"**📋 Tool Result**\n```\npackage main\n```\n",
+		"*Total messages: 4*\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Markdown() missing %q:\n%s", want, out)
+		}
+	}
+	if again := Markdown(s, All); again != out {
+		t.Error("Markdown() is not deterministic")
+	}
+
+	out = Markdown(s, Options{})
+	for _, unwanted := range []string{"Thinking", "Subagent", "Tool Result", "package main"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("Markdown() without options contains %q:\n%s", unwanted, out)
+		}
+	}
+	if !strings.Contains(out, "*Total messages: 3*") {
+		t.Errorf("message left empty by the options was kept:\n%s", out)
+	}
+	if len(s.Messages[1].ToolUses[1].Sidechain) != 2 || s.Messages[1].Thinking == "" {
+		t.Error("Markdown() changed the session")
+	}
+}
+
+func TestRenderJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := Render(&b, testSession(), FormatJSON, Options{ToolResults: true}); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	var s parser.Session
+	if err := json.Unmarshal(b.Bytes(), &s); err != nil {
+		t.Fatalf("output is not a session: %v", err)
+	}
+	if s.Messages[1].Thinking != "" || s.Messages[1].ToolUses[1].Sidechain != nil {
+		t.Errorf("thinking or subagent conversation exported: %+v", s.Messages[1])
+	}
+	if r := s.Messages[2].ToolResults[0]; r.Content == "" || r.File != nil {
+		t.Errorf("tool result = %+v, want its content without the file", r)
+	}
+
+	if err := Render(&b, testSession(), Format("pdf"), All); err == nil {
+		t.Error("Render() with an unknown format succeeded")
+	}
+}
+
+func TestFileName(t *testing.T) {
+	s := testSession()
+	if got, want := FileName(s, FormatMarkdown), "2026-01-21-gleaming-wobbling-sutherland-abcdef12.md"; got != want {
+		t.Errorf("FileName() = %q, want %q", got, want)
+	}
+	s.Slug, s.ID = "", "ab"
+	if got, want := FileName(s, FormatHTML), "2026-01-21-ab.html"; got != want {
+		t.Errorf("FileName() = %q, want %q", got, want)
+	}
+}
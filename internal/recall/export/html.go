@@ -0,0 +1,200 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+//go:embed templates/session.html
+var templateFS embed.FS
+
+// sessionTemplate renders a session as a standalone HTML page.
+var sessionTemplate = template.Must(template.New("session.html").Funcs(template.FuncMap{
+	"text":             TextHTML,
+	"prettyJSON":       PrettyJSON,
+	"stripLineNumbers": StripLineNumbers,
+	"toolSummary":      ToolSummary,
+	"time":             formatTime,
+}).ParseFS(templateFS, "templates/session.html"))
+
+// lineNumberPattern matches Claude Code's line number prefixes like "     1→"
+var lineNumberPattern = regexp.MustCompile(`(?m)^\s*\d+→`)
+
+// htmlPage is the data of the HTML template.
+type htmlPage struct {
+	Session    *parser.Session
+	Title      string
+	Messages   []htmlMessage
+	Sidechains [][]htmlMessage
+}
+
+// htmlMessage is a message with its tool results matched to the tool
+// calls that produced them, and its tool calls with their subagent
+// conversations.
+type htmlMessage struct {
+	parser.Message
+	Tools       []htmlTool
+	Results     []htmlResult
+	ResultsOnly bool
+}
+
+// htmlTool is a tool call with its subagent conversation.
+type htmlTool struct {
+	parser.ToolUse
+	Sidechain []htmlMessage
+}
+
+// htmlResult is a tool result with the name of its tool call.
+type htmlResult struct {
+	parser.ToolResult
+	Name string
+}
+
+// HTML writes a loaded session as a standalone HTML page with inline
+// styles and no links to other pages.
+func HTML(w io.Writer, s *parser.Session, opts Options) error {
+	s = filter(s, opts)
+
+	names := make(map[string]string)
+	for _, t := range s.AllToolUses() {
+		names[t.ID] = t.Name
+	}
+	page := htmlPage{Session: s, Title: title(s), Messages: htmlMessages(s.Messages, names)}
+	for _, b := range s.Sidechains {
+		page.Sidechains = append(page.Sidechains, htmlMessages(b.Messages, names))
+	}
+	return sessionTemplate.Execute(w, page)
+}
+
+// htmlMessages prepares messages and their subagent conversations for
+// the HTML template.
+func htmlMessages(msgs []parser.Message, names map[string]string) []htmlMessage {
+	var out []htmlMessage
+	for _, m := range msgs {
+		view := htmlMessage{Message: m}
+		for _, t := range m.ToolUses {
+			view.Tools = append(view.Tools, htmlTool{
+				ToolUse: t, Sidechain: htmlMessages(t.Sidechain, names),
+			})
+		}
+		for _, r := range m.ToolResults {
+			view.Results = append(view.Results, htmlResult{ToolResult: r, Name: names[r.ToolUseID]})
+		}
+		view.ResultsOnly = strings.TrimSpace(m.Text) == "" && len(view.Results) > 0
+		out = append(out, view)
+	}
+	return out
+}
+
+// TextHTML renders message text as HTML: fenced code blocks become
+// preformatted blocks and the rest becomes paragraphs. All text is
+// escaped.
+func TextHTML(text string) template.HTML {
+	var sb strings.Builder
+	var para, code []string
+	inCode := false
+
+	flush := func() {
+		if p := strings.TrimSpace(strings.Join(para, "\n")); p != "" {
+			sb.WriteString("<p>" + template.HTMLEscapeString(p) + "</p>")
+		}
+		para = nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			if inCode {
+				sb.WriteString("<pre><code>" +
+					template.HTMLEscapeString(strings.Join(code, "\n")) +
+					"</code></pre>")
+				code = nil
+			} else {
+				flush()
+			}
+			inCode = !inCode
+			continue
+		}
+		switch {
+		case inCode:
+			code = append(code, line)
+		case strings.TrimSpace(line) == "":
+			flush()
+		default:
+			para = append(para, line)
+		}
+	}
+	if inCode {
+		// An unterminated fence runs to the end of the text
+		para = append([]string{"```"}, code...)
+	}
+	flush()
+
+	return template.HTML(sb.String())
+}
+
+// PrettyJSON indents a JSON document, returning it unchanged if it is
+// not valid JSON.
+func PrettyJSON(s string) string {
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return s
+	}
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return s
+	}
+	return string(out)
+}
+
+// StripLineNumbers removes Claude Code's line number prefixes from
+// content.
+func StripLineNumbers(content string) string {
+	return lineNumberPattern.ReplaceAllString(content, "")
+}
+
+// ToolSummary formats a tool call as its name and most relevant
+// parameter, e.g. "Read: /src/main.go".
+func ToolSummary(t parser.ToolUse) string {
+	var input map[string]any
+	if err := json.Unmarshal([]byte(t.Input), &input); err != nil {
+		return t.Name
+	}
+
+	key := ""
+	switch t.Name {
+	case "Read", "Write", "Edit":
+		key = "file_path"
+	case "Bash":
+		key = "command"
+	case "Grep", "Glob":
+		key = "pattern"
+	case "WebFetch":
+		key = "url"
+	case "WebSearch":
+		key = "query"
+	case "Task":
+		key = "description"
+	}
+	value, ok := input[key].(string)
+	if !ok {
+		return t.Name
+	}
+	if t.Name == "Bash" && len(value) > maxInputLen {
+		// Long commands are cut for readability
+		value = value[:maxInputLen] + "..."
+	}
+	return fmt.Sprintf("%s: %s", t.Name, value)
+}
@@ -0,0 +1,76 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+func TestHTML(t *testing.T) {
+	s := testSession()
+	s.Messages[0].Text = "Read <main.go>"
+
+	var b strings.Builder
+	if err := HTML(&b, s, All); err != nil {
+		t.Fatalf("HTML: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"<title>gleaming-wobbling-sutherland</title>",
+		"<p>Read &lt;main.go&gt;</p>",
+		"2026-01-21 10:00:00 UTC",
+		"🔧 Read: /src/main.go",
+		"🤖 Subagent (2 messages)",
+		"Nothing else",
+		"↳ Result: Read",
+		"<pre>package main</pre>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("HTML() missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "href=") {
+		t.Error("HTML() links to other pages")
+	}
+
+	b.Reset()
+	if err := HTML(&b, s, Options{}); err != nil {
+		t.Fatalf("HTML: %v", err)
+	}
+	if strings.Contains(b.String(), "Reading first") || strings.Contains(b.String(), "Nothing else") {
+		t.Errorf("HTML() without options contains thinking or a subagent conversation")
+	}
+}
+
+func TestTextHTML(t *testing.T) {
+	got := string(TextHTML("Intro <b>\n\n```go\nx := 1 < 2\n```\nafter"))
+	want := "<p>Intro &lt;b&gt;</p><pre><code>x := 1 &lt; 2</code></pre><p>after</p>"
+	if got != want {
+		t.Errorf("TextHTML() = %q, want %q", got, want)
+	}
+}
+
+func TestToolSummary(t *testing.T) {
+	tests := []struct {
+		tool parser.ToolUse
+		want string
+	}{
+		{parser.ToolUse{Name: "Read", Input: `{"file_path":"/src/a.go"}`}, "Read: /src/a.go"},
+		{parser.ToolUse{Name: "Bash", Input: `{"command":"` + strings.Repeat("x", 120) + `"}`},
+			"Bash: " + strings.Repeat("x", 100) + "..."},
+		{parser.ToolUse{Name: "TodoWrite", Input: `{"todos":[]}`}, "TodoWrite"},
+		{parser.ToolUse{Name: "Grep", Input: `not json`}, "Grep"},
+	}
+	for _, tt := range tests {
+		if got := ToolSummary(tt.tool); got != tt.want {
+			t.Errorf("ToolSummary(%s) = %q, want %q", tt.tool.Name, got, tt.want)
+		}
+	}
+}
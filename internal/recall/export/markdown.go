@@ -0,0 +1,175 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// maxInputLen is the length tool input values are cut to in Markdown.
+const maxInputLen = 100
+
+// Markdown renders a loaded session as Markdown: a metadata header, the
+// first prompt as summary, then the messages of its main branch with
+// subagent conversations under the tool calls that spawned them.
+func Markdown(s *parser.Session, opts Options) string {
+	s = filter(s, opts)
+
+	var sb strings.Builder
+	sb.WriteString("# " + title(s) + "\n\n")
+	field := func(name, value string) {
+		if value != "" {
+			sb.WriteString("**" + name + "**: " + value + "\n")
+		}
+	}
+	if !s.StartTime.IsZero() {
+		field("Date", s.StartTime.UTC().Format("2006-01-02"))
+	}
+	field("ID", s.ID)
+	field("Tool", s.Tool)
+	field("Project", s.Project)
+	field("Branch", s.GitBranch)
+	field("Model", s.Model)
+	if !s.StartTime.IsZero() {
+		field("Started", formatTime(s.StartTime))
+		field("Duration", s.Duration.String())
+	}
+	field("Turns", fmt.Sprint(s.TurnCount))
+	if s.TotalTokens > 0 {
+		field("Tokens", fmt.Sprint(s.TotalTokens))
+	}
+	if s.SourceFile != "" {
+		field("Source", baseName(s.SourceFile))
+	}
+	if s.FirstUserMsg != "" {
+		sb.WriteString("\n## Summary\n\n" + s.FirstUserMsg + "\n")
+	}
+	sb.WriteString("\n---\n\n")
+
+	writeMessages(&sb, s.Messages, 2)
+	if len(s.Sidechains) > 0 {
+		sb.WriteString("## Subagent Conversations\n\n")
+		for _, b := range s.Sidechains {
+			writeMessages(&sb, b.Messages, 3)
+		}
+	}
+	fmt.Fprintf(&sb, "*Total messages: %d*\n", len(s.Messages))
+	return sb.String()
+}
+
+// writeMessages writes messages with headings of the given level,
+// each followed by a rule.
+func writeMessages(sb *strings.Builder, msgs []parser.Message, level int) {
+	heading := strings.Repeat("#", min(level, 6)) + " "
+	for _, m := range msgs {
+		switch {
+		case !m.IsUser():
+			sb.WriteString(heading + "Assistant\n\n")
+		case strings.TrimSpace(m.Text) == "" && len(m.ToolResults) > 0:
+			sb.WriteString(heading + "Tool Results\n\n")
+		default:
+			sb.WriteString(heading + "User\n\n")
+		}
+		if !m.Timestamp.IsZero() {
+			sb.WriteString("*" + formatTime(m.Timestamp) + "*\n\n")
+		}
+
+		if m.Thinking != "" {
+			sb.WriteString("<details>\n<summary>💭 Thinking</summary>\n\n")
+			sb.WriteString(m.Thinking)
+			sb.WriteString("\n</details>\n\n")
+		}
+		if strings.TrimSpace(m.Text) != "" {
+			sb.WriteString(m.Text + "\n\n")
+		}
+		for _, t := range m.ToolUses {
+			writeToolUse(sb, t)
+			if len(t.Sidechain) > 0 {
+				fmt.Fprintf(sb, "<details>\n<summary>🤖 Subagent (%d messages)</summary>\n\n", len(t.Sidechain))
+				writeMessages(sb, t.Sidechain, level+1)
+				sb.WriteString("</details>\n\n")
+			}
+		}
+		for _, r := range m.ToolResults {
+			if r.IsError {
+				sb.WriteString("**📋 Tool Result (error)**\n")
+			} else {
+				sb.WriteString("**📋 Tool Result**\n")
+			}
+			writeFenced(sb, StripLineNumbers(r.Content))
+		}
+		sb.WriteString("---\n\n")
+	}
+}
+
+// writeToolUse writes a tool call with its input values in key order,
+// each on one line and cut to maxInputLen.
+func writeToolUse(sb *strings.Builder, t parser.ToolUse) {
+	sb.WriteString("**🔧 Tool: " + t.Name + "**\n")
+	var input map[string]any
+	if json.Unmarshal([]byte(t.Input), &input) == nil {
+		keys := make([]string, 0, len(input))
+		for k := range input {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			v, ok := input[k].(string)
+			if !ok {
+				data, _ := json.Marshal(input[k])
+				v = string(data)
+			}
+			v = strings.Join(strings.Fields(v), " ")
+			if len(v) > maxInputLen {
+				v = v[:maxInputLen] + "..."
+			}
+			sb.WriteString("- " + k + ": " + codeSpan(v) + "\n")
+		}
+	}
+	sb.WriteString("\n")
+}
+
+// writeFenced writes text as a fenced code block, with a fence longer
+// than any run of backticks in the text.
+func writeFenced(sb *strings.Builder, text string) {
+	fence := strings.Repeat("`", max(3, longestRun(text, '`')+1))
+	sb.WriteString(fence + "\n" + strings.TrimSuffix(text, "\n") + "\n" + fence + "\n\n")
+}
+
+// codeSpan returns text as an inline code span.
+func codeSpan(text string) string {
+	ticks := strings.Repeat("`", longestRun(text, '`')+1)
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		return ticks + " " + text + " " + ticks
+	}
+	return ticks + text + ticks
+}
+
+// longestRun returns the length of the longest run of a byte in text.
+func longestRun(text string, c byte) int {
+	longest, run := 0, 0
+	for i := 0; i < len(text); i++ {
+		if text[i] == c {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
+
+// baseName returns the last element of a slash- or backslash-separated
+// path.
+func baseName(path string) string {
+	return path[strings.LastIndexAny(path, `/\`)+1:]
+}
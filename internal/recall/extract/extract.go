@@ -0,0 +1,181 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+// Package extract finds candidate decisions and learnings in session
+// transcripts.
+//
+// Extraction is deterministic: assistant text and thinking are matched
+// against phrase patterns such as "decided to ..." or "TIL: ...". The
+// results are candidates for review, not context entries.
+package extract
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// Kind is the type of context entry an insight would become.
+type Kind string
+
+// Insight kinds, named like the "ctx add" entry types.
+const (
+	KindDecision Kind = config.UpdateTypeDecision
+	KindLearning Kind = config.UpdateTypeLearning
+)
+
+// maxInsightLength is the length beyond which insights are truncated.
+const maxInsightLength = 150
+
+// maxExcerptLength is the length beyond which excerpts are truncated.
+const maxExcerptLength = 300
+
+// pattern is a phrase pattern whose single capture group is the insight.
+type pattern struct {
+	kind  Kind
+	regex *regexp.Regexp
+}
+
+// clause returns a capture group of lo to hi characters that ends at
+// sentence punctuation followed by whitespace, or at the end of the line
+// or text. Dots within words, as in "config.yaml" or "1.25", do not end
+// it.
+func clause(lo, hi int) string {
+	return fmt.Sprintf(`((?:[^.!?\n]|[.!?]\S){%d,%d})`, lo, hi)
+}
+
+// patterns are the phrase patterns, decisions first.
+var patterns = []pattern{
+	{KindDecision, regexp.MustCompile(`(?i)decided to\s+` + clause(20, 100))},
+	{KindDecision, regexp.MustCompile(`(?i)decision:\s*` + clause(20, 100))},
+	{KindDecision, regexp.MustCompile(`(?i)we(?:'ll| will) use\s+` + clause(10, 80))},
+	{KindDecision, regexp.MustCompile(`(?i)going with\s+` + clause(10, 80))},
+	{KindDecision, regexp.MustCompile(`(?i)chose\s+` + clause(10, 80) + `\s+(?:over|instead)`)},
+	{KindLearning, regexp.MustCompile(`(?i)learned that\s+` + clause(20, 100))},
+	{KindLearning, regexp.MustCompile(`(?i)gotcha:\s*` + clause(20, 100))},
+	{KindLearning, regexp.MustCompile(`(?i)lesson:\s*` + clause(20, 100))},
+	{KindLearning, regexp.MustCompile(`(?i)\bTIL\b:?\s*` + clause(20, 100))},
+	{KindLearning, regexp.MustCompile(`(?i)turns out\s+` + clause(20, 100))},
+	{KindLearning, regexp.MustCompile(`(?i)important to (?:note|remember):\s*` + clause(20, 100))},
+}
+
+// Insight is a phrase matched in a text.
+type Insight struct {
+	Kind Kind
+	// Text is the cleaned insight, e.g. "use PostgreSQL for storage".
+	Text string
+	// Excerpt is the line of the text the insight was found in.
+	Excerpt string
+}
+
+// Candidate is an insight found in a session.
+type Candidate struct {
+	Insight
+	SessionID string
+	Slug      string
+	Project   string
+	// Time is the timestamp of the message the insight was found in.
+	Time time.Time
+}
+
+// Text returns the insights in a text, in pattern order. Insights with
+// the same normalized text are reported once.
+func Text(text string) []Insight {
+	var insights []Insight
+	seen := make(map[string]bool)
+	for _, p := range patterns {
+		for _, m := range p.regex.FindAllStringSubmatchIndex(text, -1) {
+			insight := Clean(text[m[2]:m[3]])
+			key := Normalize(insight)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			insights = append(insights, Insight{
+				Kind:    p.kind,
+				Text:    insight,
+				Excerpt: excerpt(text, m[0]),
+			})
+		}
+	}
+	return insights
+}
+
+// Session returns the insights in the assistant messages of a session,
+// text and thinking included, in message order. Insights with the same
+// normalized text are reported once.
+func Session(s *parser.Session) []Candidate {
+	var candidates []Candidate
+	seen := make(map[string]bool)
+	for _, msg := range s.Messages {
+		if !msg.IsAssistant() {
+			continue
+		}
+		for _, text := range []string{msg.Text, msg.Thinking} {
+			for _, insight := range Text(text) {
+				key := Normalize(insight.Text)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				candidates = append(candidates, Candidate{
+					Insight:   insight,
+					SessionID: s.ID,
+					Slug:      s.Slug,
+					Project:   s.Project,
+					Time:      msg.Timestamp,
+				})
+			}
+		}
+	}
+	return candidates
+}
+
+// Clean trims whitespace and trailing punctuation from an insight and
+// truncates long insights, at a word boundary when possible.
+func Clean(s string) string {
+	s = strings.TrimSpace(s)
+	// Remove trailing punctuation fragments
+	s = strings.TrimRight(s, ".,;:!?")
+	// Truncate if too long
+	if len(s) > maxInsightLength {
+		// Try to cut at word boundary
+		idx := strings.LastIndex(s[:maxInsightLength], " ")
+		if idx > 100 {
+			s = s[:idx] + "..."
+		} else {
+			s = s[:maxInsightLength-3] + "..."
+		}
+	}
+	return s
+}
+
+// Normalize reduces a text to lowercase words separated by single
+// spaces, for comparing insights with each other and with entries.
+func Normalize(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+	}), " ")
+}
+
+// excerpt returns the trimmed line of text containing the byte offset,
+// truncated if long.
+func excerpt(text string, offset int) string {
+	start := strings.LastIndex(text[:offset], "\n") + 1
+	end := len(text)
+	if i := strings.Index(text[offset:], "\n"); i >= 0 {
+		end = offset + i
+	}
+	line := strings.TrimSpace(text[start:end])
+	if runes := []rune(line); len(runes) > maxExcerptLength {
+		line = string(runes[:maxExcerptLength-3]) + "..."
+	}
+	return line
+}
@@ -0,0 +1,193 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package extract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// TestText tests phrase matching, cleaning and deduplication.
+func TestText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Insight
+	}{
+		{
+			name: "decision",
+			text: "Okay.\nI decided to use PostgreSQL for the primary database.\nDone.",
+			want: []Insight{{
+				Kind:    KindDecision,
+				Text:    "use PostgreSQL for the primary database",
+				Excerpt: "I decided to use PostgreSQL for the primary database.",
+			}},
+		},
+		{
+			name: "learning after a label",
+			text: "Important to note: the cache is not shared across workers",
+			want: []Insight{{
+				Kind:    KindLearning,
+				Text:    "the cache is not shared across workers",
+				Excerpt: "Important to note: the cache is not shared across workers",
+			}},
+		},
+		{
+			name: "chose over",
+			text: "We chose cobra for the CLI over urfave/cli",
+			want: []Insight{{
+				Kind:    KindDecision,
+				Text:    "cobra for the CLI",
+				Excerpt: "We chose cobra for the CLI over urfave/cli",
+			}},
+		},
+		{
+			name: "duplicates ignoring case and punctuation",
+			text: "Gotcha: tests need CTX_SKIP_PATH_CHECK set.\nLesson: Tests need ctx_skip_path_check set!",
+			want: []Insight{{
+				Kind:    KindLearning,
+				Text:    "tests need CTX_SKIP_PATH_CHECK set",
+				Excerpt: "Gotcha: tests need CTX_SKIP_PATH_CHECK set.",
+			}},
+		},
+		{
+			name: "stops at sentence end",
+			text: "I decided to use PostgreSQL for the primary database. Then I wrote the migrations for it.",
+			want: []Insight{{
+				Kind:    KindDecision,
+				Text:    "use PostgreSQL for the primary database",
+				Excerpt: "I decided to use PostgreSQL for the primary database. Then I wrote the migrations for it.",
+			}},
+		},
+		{
+			name: "stops at line end",
+			text: "TIL: go test caches passing results\nso rerun with -count=1 after editing fixtures",
+			want: []Insight{{
+				Kind:    KindLearning,
+				Text:    "go test caches passing results",
+				Excerpt: "TIL: go test caches passing results",
+			}},
+		},
+		{
+			name: "file name within the sentence",
+			text: "I decided to move the settings into config.yaml and drop the env vars",
+			want: []Insight{{
+				Kind:    KindDecision,
+				Text:    "move the settings into config.yaml and drop the env vars",
+				Excerpt: "I decided to move the settings into config.yaml and drop the env vars",
+			}},
+		},
+		{
+			name: "version within the sentence",
+			text: "Turns out Go 1.25 changed how the test cache keys work. Rerunning.",
+			want: []Insight{{
+				Kind:    KindLearning,
+				Text:    "Go 1.25 changed how the test cache keys work",
+				Excerpt: "Turns out Go 1.25 changed how the test cache keys work. Rerunning.",
+			}},
+		},
+		{
+			name: "TIL inside a word",
+			text: "Until the build finishes on CI, nothing else can run",
+		},
+		{
+			name: "no match",
+			text: "Nothing to see here.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Text(tt.text)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Text() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Text()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestSession tests that only assistant messages are scanned and that
+// candidates carry their source.
+func TestSession(t *testing.T) {
+	at := time.Date(2026, 1, 20, 10, 0, 0, 0, time.UTC)
+	s := &parser.Session{
+		ID:      "sess-1",
+		Slug:    "brave-sailing-mercury",
+		Project: "alpha",
+		Messages: []parser.Message{
+			{Role: "user", Text: "I decided to rewrite everything in Rust today"},
+			{
+				Role:      "assistant",
+				Timestamp: at,
+				Thinking:  "Turns out the parser drops empty lines at EOF",
+				Text:      "Going with a streaming parser for large files",
+			},
+			{Role: "assistant", Text: "As noted, going with a streaming parser for large files."},
+		},
+	}
+
+	got := Session(s)
+	if len(got) != 2 {
+		t.Fatalf("Session() returned %d candidates, want 2: %+v", len(got), got)
+	}
+	if got[0].Kind != KindDecision || got[0].Text != "a streaming parser for large files" {
+		t.Errorf("first candidate = %+v", got[0])
+	}
+	if got[1].Kind != KindLearning || got[1].Text != "the parser drops empty lines at EOF" {
+		t.Errorf("second candidate = %+v", got[1])
+	}
+	for _, c := range got {
+		if c.SessionID != "sess-1" || c.Project != "alpha" || !c.Time.Equal(at) {
+			t.Errorf("candidate source = %q %q %v", c.SessionID, c.Project, c.Time)
+		}
+	}
+}
+
+// TestClean tests trimming and truncating insights.
+func TestClean(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"simple text", "simple text"},
+		{"  trimmed  ", "trimmed"},
+		{"ends with period.", "ends with period"},
+		{"ends with comma,", "ends with comma"},
+		{"ends with multiple...", "ends with multiple"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Clean(tt.input); got != tt.want {
+			t.Errorf("Clean(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+// TestNormalize tests text normalization for comparisons.
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Use PostgreSQL!", "use postgresql"},
+		{"  go:embed   needs `files`  ", "go embed needs files"},
+		{"Café au lait", "café au lait"},
+		{"...", ""},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.input); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Aider history file names, written to the root of the project.
+const (
+	aiderChatHistory  = ".aider.chat.history.md"
+	aiderInputHistory = ".aider.input.history"
+)
+
+// aiderSessionGap is the pause between two prompts of an input history
+// that starts a new session. Input histories have no session markers.
+const aiderSessionGap = time.Hour
+
+var (
+	// aiderStartPattern matches the line that opens each chat session.
+	aiderStartPattern = regexp.MustCompile(`^# aider chat started at (\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2})\s*$`)
+	// aiderInputTimePattern matches the timestamp line before each prompt
+	// in the input history.
+	aiderInputTimePattern = regexp.MustCompile(`^# (\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(?:\.\d+)?)\s*$`)
+	// aiderModelPattern matches the model line of the session header.
+	aiderModelPattern = regexp.MustCompile(`^Model: (\S+)`)
+	// aiderAppliedPattern matches the confirmation of an applied edit.
+	aiderAppliedPattern = regexp.MustCompile(`^Applied edit to (.+?)\s*$`)
+	// aiderFailedPattern matches aider's reports of edits that failed.
+	aiderFailedPattern = regexp.MustCompile(`(?i)failed to apply edit|did not conform to the edit format|SearchReplaceNoExactMatch`)
+
+	aiderSearchPattern  = regexp.MustCompile(`^<{5,9} SEARCH\s*$`)
+	aiderDividerPattern = regexp.MustCompile(`^={5,9}\s*$`)
+	aiderReplacePattern = regexp.MustCompile(`^>{5,9} REPLACE\s*$`)
+)
+
+// AiderParser parses Aider chat and input history files.
+//
+// Aider appends every session to .aider.chat.history.md in the project
+// root. Each session opens with a "# aider chat started at" line; user
+// prompts are prefixed with "####", aider's own output with ">", and
+// everything else is the model's reply. Prompts carry no timestamps in
+// the chat history, so they are taken from .aider.input.history when it
+// is present. An input history without a chat history is parsed on its
+// own, as user prompts only.
+type AiderParser struct{}
+
+// NewAiderParser creates a new Aider session parser.
+func NewAiderParser() *AiderParser {
+	return &AiderParser{}
+}
+
+// Tool returns the tool identifier.
+func (p *AiderParser) Tool() string {
+	return "aider"
+}
+
+// CanParse returns true for Aider chat histories, and for input
+// histories that have no chat history next to them.
+func (p *AiderParser) CanParse(path string) bool {
+	switch filepath.Base(path) {
+	case aiderChatHistory:
+		return true
+	case aiderInputHistory:
+		_, err := os.Stat(filepath.Join(filepath.Dir(path), aiderChatHistory))
+		return os.IsNotExist(err)
+	}
+	return false
+}
+
+// ParseFile reads an Aider history file and returns its sessions, oldest
+// first.
+func (p *AiderParser) ParseFile(path string) ([]*Session, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolve path: %w", err)
+	}
+
+	if filepath.Base(abs) == aiderInputHistory {
+		prompts, err := readAiderInputHistory(abs)
+		if err != nil {
+			return nil, err
+		}
+		return p.promptSessions(abs, prompts), nil
+	}
+
+	lines, err := readLines(abs)
+	if err != nil {
+		return nil, err
+	}
+	// Prompt timestamps are a bonus; a missing input history is fine
+	prompts, _ := readAiderInputHistory(filepath.Join(filepath.Dir(abs), aiderInputHistory))
+
+	var sessions []*Session
+	var b *aiderSessionBuilder
+	for _, line := range lines {
+		if m := aiderStartPattern.FindStringSubmatch(line); m != nil {
+			if b != nil {
+				sessions = append(sessions, b.done())
+			}
+			start, _ := time.ParseInLocation(time.DateTime, m[1], time.Local)
+			b = newAiderSessionBuilder(abs, start, prompts)
+			continue
+		}
+		// Lines before the first marker belong to no session
+		if b != nil {
+			b.line(line)
+		}
+	}
+	if b != nil {
+		sessions = append(sessions, b.done())
+	}
+
+	return sessions, nil
+}
+
+// ParseLine parses a single line of a chat history. Only prompt lines
+// yield a message; Aider replies span many lines and need ParseFile.
+func (p *AiderParser) ParseLine(line []byte) (*Message, string, error) {
+	text, ok := strings.CutPrefix(string(line), "#### ")
+	if !ok {
+		return nil, "", nil
+	}
+	return &Message{Role: "user", Text: strings.TrimSpace(text)}, "", nil
+}
+
+// promptSessions groups the prompts of an input history into sessions,
+// starting a new one after each pause of aiderSessionGap.
+func (p *AiderParser) promptSessions(path string, prompts []aiderPrompt) []*Session {
+	var sessions []*Session
+	var b *aiderSessionBuilder
+	for i, prompt := range prompts {
+		if b == nil || prompt.time.Sub(prompts[i-1].time) > aiderSessionGap {
+			if b != nil {
+				sessions = append(sessions, b.done())
+			}
+			b = newAiderSessionBuilder(path, prompt.time, nil)
+		}
+		b.flush()
+		b.msg = &Message{Role: "user", Text: prompt.text, Timestamp: prompt.time}
+	}
+	if b != nil {
+		sessions = append(sessions, b.done())
+	}
+	return sessions
+}
+
+// aiderPrompt is a prompt recorded in the input history.
+type aiderPrompt struct {
+	time time.Time
+	text string
+	used bool
+}
+
+// readAiderInputHistory reads the prompts of an input history: each is
+// a "# timestamp" line followed by its lines prefixed with "+".
+func readAiderInputHistory(path string) ([]aiderPrompt, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var prompts []aiderPrompt
+	for _, line := range lines {
+		if m := aiderInputTimePattern.FindStringSubmatch(line); m != nil {
+			t, _ := time.ParseInLocation("2006-01-02 15:04:05.999999", m[1], time.Local)
+			prompts = append(prompts, aiderPrompt{time: t})
+			continue
+		}
+		text, ok := strings.CutPrefix(line, "+")
+		if !ok || len(prompts) == 0 {
+			continue
+		}
+		last := &prompts[len(prompts)-1]
+		if last.text != "" {
+			last.text += "\n"
+		}
+		last.text += text
+	}
+	return prompts, nil
+}
+
+// aiderSessionBuilder accumulates the lines of one chat session.
+type aiderSessionBuilder struct {
+	session *Session
+	prompts []aiderPrompt
+	// msg is the message being read; prompt tells whether its last line
+	// was a prompt line, so that the next one continues it
+	msg    *Message
+	prompt bool
+	// output collects aider's own output since the last message
+	output []string
+	edits  int
+}
+
+// newAiderSessionBuilder starts a session of the history file at path.
+func newAiderSessionBuilder(path string, start time.Time, prompts []aiderPrompt) *aiderSessionBuilder {
+	sum := sha256.Sum256([]byte(path + "\n" + start.Format(time.DateTime)))
+	cwd := filepath.Dir(path)
+	return &aiderSessionBuilder{
+		session: &Session{
+			ID:         hex.EncodeToString(sum[:8]),
+			Slug:       "aider-" + start.Format("20060102-150405"),
+			Tool:       "aider",
+			SourceFile: path,
+			CWD:        cwd,
+			Project:    filepath.Base(cwd),
+			StartTime:  start,
+		},
+		prompts: prompts,
+	}
+}
+
+// line adds a line of the chat history to the session.
+func (b *aiderSessionBuilder) line(line string) {
+	if text, ok := strings.CutPrefix(line, "####"); ok {
+		text = strings.TrimPrefix(text, " ")
+		if b.msg != nil && b.prompt {
+			b.msg.Text += "\n" + text
+			return
+		}
+		b.flush()
+		b.msg = &Message{Role: "user", Text: text}
+		b.prompt = true
+		return
+	}
+	b.prompt = false
+
+	// Aider's output is quoted; ">>>>>>> REPLACE" markers are not
+	if line == ">" || strings.HasPrefix(line, "> ") {
+		text := strings.TrimSpace(line[1:])
+		if m := aiderModelPattern.FindStringSubmatch(text); m != nil && b.session.Model == "" {
+			b.session.Model = m[1]
+		}
+		b.output = append(b.output, text)
+		return
+	}
+
+	// Text after aider's output is a new reply, as when aider asks the
+	// model to fix an edit that failed
+	if b.msg == nil || b.msg.IsUser() || len(b.output) > 0 {
+		if strings.TrimSpace(line) == "" {
+			return
+		}
+		b.flush()
+		b.msg = &Message{Role: "assistant"}
+	}
+	b.msg.Text += line + "\n"
+}
+
+// flush finishes the message being read and adds it to the session.
+func (b *aiderSessionBuilder) flush() {
+	msg := b.msg
+	b.msg = nil
+	// Aider's output after a reply reports on its edits; output after a
+	// prompt is only informational
+	defer func() { b.output = nil }()
+	if msg == nil {
+		return
+	}
+
+	msg.Text = strings.TrimSpace(msg.Text)
+	switch {
+	case !msg.Timestamp.IsZero():
+	case msg.IsUser():
+		msg.Timestamp = b.promptTime(msg.Text)
+	default:
+		msg.Timestamp = b.lastTime()
+	}
+	if msg.IsAssistant() {
+		b.addEdits(msg)
+	}
+	msg.ID = fmt.Sprintf("%s-%d", b.session.ID, len(b.session.Messages))
+	b.session.Messages = append(b.session.Messages, *msg)
+}
+
+// addEdits records the file edits of a reply as Edit tool uses, with
+// aider's report on applying them as their results.
+func (b *aiderSessionBuilder) addEdits(msg *Message) {
+	for _, e := range aiderEdits(msg.Text) {
+		input, _ := json.Marshal(e)
+		msg.ToolUses = append(msg.ToolUses, ToolUse{
+			ID:    fmt.Sprintf("%s-edit-%d", b.session.ID, b.edits),
+			Name:  "Edit",
+			Input: string(input),
+		})
+		b.edits++
+	}
+	for _, out := range b.output {
+		if m := aiderAppliedPattern.FindStringSubmatch(out); m != nil {
+			id := editFor(msg, m[1])
+			if id == "" {
+				// Whole-file edits leave no block to parse
+				input, _ := json.Marshal(aiderEdit{FilePath: m[1]})
+				id = fmt.Sprintf("%s-edit-%d", b.session.ID, b.edits)
+				b.edits++
+				msg.ToolUses = append(msg.ToolUses, ToolUse{ID: id, Name: "Edit", Input: string(input)})
+			}
+			msg.ToolResults = append(msg.ToolResults, ToolResult{ToolUseID: id, Content: out})
+		}
+	}
+	if failed := strings.Join(b.output, "\n"); aiderFailedPattern.MatchString(failed) {
+		var id string
+		if n := len(msg.ToolUses); n > 0 {
+			id = msg.ToolUses[n-1].ID
+		}
+		msg.ToolResults = append(msg.ToolResults, ToolResult{
+			ToolUseID: id, Content: strings.TrimSpace(failed), IsError: true,
+		})
+		b.session.HasErrors = true
+	}
+}
+
+// promptTime returns the time of the first unused input history prompt
+// with the given text, or the time of the previous message.
+func (b *aiderSessionBuilder) promptTime(text string) time.Time {
+	for i := range b.prompts {
+		p := &b.prompts[i]
+		if !p.used && !p.time.Before(b.session.StartTime) && strings.TrimSpace(p.text) == text {
+			p.used = true
+			return p.time
+		}
+	}
+	return b.lastTime()
+}
+
+// lastTime returns the time of the last message, or the session start.
+func (b *aiderSessionBuilder) lastTime() time.Time {
+	if n := len(b.session.Messages); n > 0 {
+		return b.session.Messages[n-1].Timestamp
+	}
+	return b.session.StartTime
+}
+
+// done finishes the session and fills in its derived fields.
+func (b *aiderSessionBuilder) done() *Session {
+	b.flush()
+	s := b.session
+	s.EndTime = b.lastTime()
+	s.Duration = s.EndTime.Sub(s.StartTime)
+	for _, m := range s.Messages {
+		if !m.IsUser() {
+			continue
+		}
+		s.TurnCount++
+		if s.FirstUserMsg == "" && m.Text != "" {
+			s.FirstUserMsg = m.Preview(100)
+		}
+	}
+	return s
+}
+
+// aiderEdit is the input of an edit, named like the input of the Claude
+// Code Edit tool.
+type aiderEdit struct {
+	FilePath  string `json:"file_path"`
+	OldString string `json:"old_string,omitempty"`
+	NewString string `json:"new_string,omitempty"`
+	Diff      string `json:"diff,omitempty"`
+}
+
+// aiderEdits returns the SEARCH/REPLACE blocks and unified diffs of a
+// reply, in order.
+//
+// The file of a SEARCH/REPLACE block is named on the line before it,
+// skipping code fences; blocks that follow each other in one fence share
+// it.
+func aiderEdits(text string) []aiderEdit {
+	lines := strings.Split(text, "\n")
+	var edits []aiderEdit
+	file := ""
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if aiderSearchPattern.MatchString(line) {
+			if name := aiderFileName(lines[:i]); name != "" {
+				file = name
+			}
+			var search, replace []string
+			j := i + 1
+			for ; j < len(lines) && !aiderDividerPattern.MatchString(lines[j]); j++ {
+				search = append(search, lines[j])
+			}
+			for j++; j < len(lines) && !aiderReplacePattern.MatchString(lines[j]); j++ {
+				replace = append(replace, lines[j])
+			}
+			edits = append(edits, aiderEdit{
+				FilePath:  file,
+				OldString: strings.Join(search, "\n"),
+				NewString: strings.Join(replace, "\n"),
+			})
+			i = j
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(line, "+++ "); ok && i > 0 && strings.HasPrefix(lines[i-1], "--- ") {
+			name = strings.TrimPrefix(strings.Fields(name + " ")[0], "b/")
+			diff := []string{lines[i-1], line}
+			j := i + 1
+			for ; j < len(lines); j++ {
+				if strings.HasPrefix(lines[j], "```") ||
+					(strings.HasPrefix(lines[j], "--- ") && j+1 < len(lines) && strings.HasPrefix(lines[j+1], "+++ ")) {
+					break
+				}
+				diff = append(diff, lines[j])
+			}
+			edits = append(edits, aiderEdit{FilePath: name, Diff: strings.Join(diff, "\n")})
+			i = j - 1
+		}
+	}
+
+	return edits
+}
+
+// aiderFileName returns the file named on the last line before a
+// SEARCH/REPLACE block, or "" if that line ends an earlier block.
+func aiderFileName(before []string) string {
+	for i := len(before) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(before[i])
+		if line == "" || strings.HasPrefix(line, "```") {
+			continue
+		}
+		if aiderReplacePattern.MatchString(line) {
+			return ""
+		}
+		line = strings.Trim(line, "`*")
+		return strings.TrimSuffix(line, ":")
+	}
+	return ""
+}
+
+// editFor returns the ID of the last edit of a message to the given
+// file, or "".
+func editFor(msg *Message, file string) string {
+	for i := len(msg.ToolUses) - 1; i >= 0; i-- {
+		var e aiderEdit
+		if json.Unmarshal([]byte(msg.ToolUses[i].Input), &e) == nil && e.FilePath == file {
+			return msg.ToolUses[i].ID
+		}
+	}
+	return ""
+}
+
+// readLines reads a file into lines without their line endings.
+func readLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan file: %w", err)
+	}
+	return lines, nil
+}
+
+// Ensure AiderParser implements SessionParser
+var _ SessionParser = (*AiderParser)(nil)
@@ -0,0 +1,263 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const aiderChat = "# aider chat started at 2026-01-20 10:00:00\n" +
+	"\n" +
+	"> /usr/bin/aider --model gpt-4o\n" +
+	"> Aider v0.70.0\n" +
+	"> Model: gpt-4o with diff edit format\n" +
+	"\n" +
+	"#### add a hello function\n" +
+	"#### to greet.py\n" +
+	"\n" +
+	"Here is the function.\n" +
+	"\n" +
+	"greet.py\n" +
+	"```python\n" +
+	"<<<<<<< SEARCH\n" +
+	"=======\n" +
+	"def hello():\n" +
+	"    print(\"hello\")\n" +
+	">>>>>>> REPLACE\n" +
+	"```\n" +
+	"\n" +
+	"> Applied edit to greet.py\n" +
+	"> Commit 1a2b3c4 feat: Add hello function\n" +
+	"\n" +
+	"#### rename it to greet\n" +
+	"\n" +
+	"greet.py\n" +
+	"```python\n" +
+	"<<<<<<< SEARCH\n" +
+	"def hallo():\n" +
+	"=======\n" +
+	"def greet():\n" +
+	">>>>>>> REPLACE\n" +
+	"```\n" +
+	"\n" +
+	"> The LLM did not conform to the edit format.\n" +
+	"> SearchReplaceNoExactMatch: This SEARCH block failed to exactly match lines in greet.py\n" +
+	"\n" +
+	"Sorry, here is the fixed block.\n" +
+	"\n" +
+	"> Applied edit to greet.py\n" +
+	"\n" +
+	"# aider chat started at 2026-01-21 09:00:00\n" +
+	"\n" +
+	"#### what does greet.py do?\n" +
+	"\n" +
+	"It prints hello.\n"
+
+const aiderInput = "\n" +
+	"# 2026-01-20 10:00:05.123456\n" +
+	"+add a hello function\n" +
+	"+to greet.py\n" +
+	"\n" +
+	"# 2026-01-20 10:02:00.000000\n" +
+	"+rename it to greet\n" +
+	"\n" +
+	"# 2026-01-21 09:00:10.000000\n" +
+	"+what does greet.py do?\n"
+
+func TestAiderParser_CanParse(t *testing.T) {
+	parser := NewAiderParser()
+	dir := t.TempDir()
+
+	input := filepath.Join(dir, aiderInputHistory)
+	if err := os.WriteFile(input, []byte(aiderInput), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !parser.CanParse(input) {
+		t.Error("should parse an input history on its own")
+	}
+
+	chat := filepath.Join(dir, aiderChatHistory)
+	if err := os.WriteFile(chat, []byte(aiderChat), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !parser.CanParse(chat) {
+		t.Error("should parse a chat history")
+	}
+	if parser.CanParse(input) {
+		t.Error("should leave an input history to its chat history")
+	}
+	if parser.CanParse(filepath.Join(dir, "notes.md")) {
+		t.Error("should not parse other Markdown files")
+	}
+}
+
+func TestAiderParser_ParseFile(t *testing.T) {
+	dir := t.TempDir()
+	chat := filepath.Join(dir, aiderChatHistory)
+	if err := os.WriteFile(chat, []byte(aiderChat), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, aiderInputHistory), []byte(aiderInput), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := ParseFile(chat)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+
+	s := sessions[0]
+	if s.Tool != "aider" || s.Model != "gpt-4o" || s.CWD != dir {
+		t.Errorf("unexpected session: tool=%s model=%s cwd=%s", s.Tool, s.Model, s.CWD)
+	}
+	if s.Slug != "aider-20260120-100000" {
+		t.Errorf("expected slug 'aider-20260120-100000', got '%s'", s.Slug)
+	}
+	if s.TurnCount != 2 || len(s.Messages) != 5 {
+		t.Fatalf("expected 2 turns in 5 messages, got %d in %d", s.TurnCount, len(s.Messages))
+	}
+	if !s.HasErrors {
+		t.Error("expected the failed edit to be recorded")
+	}
+
+	first := s.Messages[0]
+	if first.Text != "add a hello function\nto greet.py" {
+		t.Errorf("unexpected prompt: %q", first.Text)
+	}
+	want := time.Date(2026, 1, 20, 10, 0, 5, 123456000, time.Local)
+	if !first.Timestamp.Equal(want) {
+		t.Errorf("expected prompt time %v, got %v", want, first.Timestamp)
+	}
+
+	reply := s.Messages[1]
+	if !reply.IsAssistant() || !strings.HasPrefix(reply.Text, "Here is the function.") {
+		t.Errorf("unexpected reply: %s %q", reply.Role, reply.Text)
+	}
+	if len(reply.ToolUses) != 1 || reply.ToolUses[0].Name != "Edit" {
+		t.Fatalf("expected 1 edit, got %+v", reply.ToolUses)
+	}
+	var edit map[string]string
+	if err := json.Unmarshal([]byte(reply.ToolUses[0].Input), &edit); err != nil {
+		t.Fatal(err)
+	}
+	if edit["file_path"] != "greet.py" || !strings.Contains(edit["new_string"], "def hello():") {
+		t.Errorf("unexpected edit input: %v", edit)
+	}
+	if len(reply.ToolResults) != 1 || reply.ToolResults[0].ToolUseID != reply.ToolUses[0].ID ||
+		reply.ToolResults[0].IsError {
+		t.Errorf("expected the edit to be applied, got %+v", reply.ToolResults)
+	}
+
+	// The failed edit and the retry after it are separate replies
+	failed := s.Messages[3]
+	if len(failed.ToolResults) != 1 || !failed.ToolResults[0].IsError {
+		t.Errorf("expected a failed edit, got %+v", failed.ToolResults)
+	}
+	retry := s.Messages[4]
+	if retry.Text != "Sorry, here is the fixed block." || len(retry.ToolResults) != 1 {
+		t.Errorf("unexpected retry: %q %+v", retry.Text, retry.ToolResults)
+	}
+
+	if sessions[1].FirstUserMsg != "what does greet.py do?" {
+		t.Errorf("unexpected first message: %q", sessions[1].FirstUserMsg)
+	}
+	if sessions[0].ID == sessions[1].ID {
+		t.Error("expected distinct session IDs")
+	}
+}
+
+func TestAiderParser_InputHistoryOnly(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, aiderInputHistory)
+	if err := os.WriteFile(input, []byte(aiderInput), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := ParseFile(input)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions split by the pause, got %d", len(sessions))
+	}
+	if n := len(sessions[0].Messages); n != 2 || sessions[0].TurnCount != 2 {
+		t.Errorf("expected 2 prompts in the first session, got %d", n)
+	}
+	if sessions[0].Duration != 115*time.Second-123456*time.Microsecond {
+		t.Errorf("unexpected duration: %v", sessions[0].Duration)
+	}
+}
+
+func TestAiderEdits(t *testing.T) {
+	text := "```diff\n" +
+		"--- a/greet.py\n" +
+		"+++ b/greet.py\n" +
+		"@@ -1 +1 @@\n" +
+		"-def hello():\n" +
+		"+def greet():\n" +
+		"```\n" +
+		"\n" +
+		"```\n" +
+		"main.py\n" +
+		"<<<<<<< SEARCH\n" +
+		"a\n" +
+		"=======\n" +
+		"b\n" +
+		">>>>>>> REPLACE\n" +
+		"<<<<<<< SEARCH\n" +
+		"c\n" +
+		"=======\n" +
+		"d\n" +
+		">>>>>>> REPLACE\n" +
+		"```\n"
+
+	edits := aiderEdits(text)
+	if len(edits) != 3 {
+		t.Fatalf("expected 3 edits, got %+v", edits)
+	}
+	if edits[0].FilePath != "greet.py" || !strings.Contains(edits[0].Diff, "+def greet():") {
+		t.Errorf("unexpected diff edit: %+v", edits[0])
+	}
+	if edits[1].FilePath != "main.py" || edits[1].OldString != "a" || edits[1].NewString != "b" {
+		t.Errorf("unexpected first block: %+v", edits[1])
+	}
+	if edits[2].FilePath != "main.py" || edits[2].OldString != "c" {
+		t.Errorf("expected the second block to share the file, got %+v", edits[2])
+	}
+}
+
+func TestFindSessions_Aider(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	repo := t.TempDir()
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, aiderChatHistory), []byte(aiderChat), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(repo, "src")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(sub)
+
+	sessions, err := FindSessions()
+	if err != nil {
+		t.Fatalf("FindSessions failed: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].Tool != "aider" {
+		t.Fatalf("expected the 2 aider sessions of the repository, got %d", len(sessions))
+	}
+}
@@ -0,0 +1,389 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// parserVersion is the version of the parsers' output. Bump it when a
+// parser changes the sessions it produces, so that cached sessions are
+// parsed again.
+const parserVersion = 6
+
+// cacheFile is the name of the parse cache in the cache directory.
+const cacheFile = "recall-sessions.json"
+
+// Cache holds the session metadata parsed from session files, so that
+// listing sessions only parses the files that changed.
+//
+// Entries are keyed by path and valid while the file's size and
+// modification time, the parser that read it, and the files it depends
+// on are unchanged. Cached sessions carry no messages; see Session.Load.
+type Cache struct {
+	Version int                    `json:"version"`
+	Files   map[string]*cacheEntry `json:"files"`
+
+	path    string
+	changed bool
+	mu      sync.Mutex
+}
+
+// cacheEntry is a parsed session file.
+type cacheEntry struct {
+	Parser string `json:"parser"`
+	fileStamp
+	// Deps are the other files the sessions were read from
+	Deps     map[string]fileStamp `json:"deps,omitempty"`
+	Sessions []*Session           `json:"sessions"`
+}
+
+// fileStamp identifies a version of a file.
+type fileStamp struct {
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+}
+
+// CacheStats counts the files and sessions seen by a cached scan.
+type CacheStats struct {
+	// Files is the number of session files found
+	Files int
+	// Parsed is the number of files parsed, as opposed to read from the
+	// cache
+	Parsed int
+	// Sessions is the number of sessions in the files
+	Sessions int
+	// Errors are the parse errors of the files that failed to parse,
+	// each naming its file
+	Errors []error
+}
+
+// add accumulates the counts of another scan.
+func (s *CacheStats) add(o CacheStats) {
+	s.Files += o.Files
+	s.Parsed += o.Parsed
+	s.Sessions += o.Sessions
+	s.Errors = append(s.Errors, o.Errors...)
+}
+
+// DefaultCachePath returns the path of the parse cache in the user cache
+// directory, e.g. ~/.cache/ctx/recall-sessions.json.
+func DefaultCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ctx", cacheFile), nil
+}
+
+// OpenCache reads the parse cache at path. A missing, unreadable or
+// outdated cache yields an empty cache that is written to path on Save.
+// An empty path gives a cache that is never written.
+func OpenCache(path string) (*Cache, error) {
+	c := &Cache{path: path}
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err == nil {
+			if json.Unmarshal(content, c) != nil || c.Version != parserVersion {
+				c = &Cache{path: path}
+			}
+		}
+	}
+	if c.Files == nil {
+		c.Files = make(map[string]*cacheEntry)
+	}
+	return c, nil
+}
+
+// openDefaultCache opens the parse cache at its default path, falling
+// back to a cache that is never written if it cannot be read.
+func openDefaultCache() *Cache {
+	if path, err := DefaultCachePath(); err == nil {
+		if c, err := OpenCache(path); err == nil {
+			return c
+		}
+	}
+	c, _ := OpenCache("")
+	return c
+}
+
+// Clear drops all entries, so that every file is parsed again.
+func (c *Cache) Clear() {
+	c.Files = make(map[string]*cacheEntry)
+	c.changed = true
+}
+
+// Save writes the cache to its file if it changed, creating the
+// directory if needed.
+func (c *Cache) Save() error {
+	if c.path == "" || !c.changed {
+		return nil
+	}
+	c.Version = parserVersion
+	content, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that an interrupted save never
+	// leaves a truncated cache behind
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return err
+	}
+	c.changed = false
+	return nil
+}
+
+// Prune drops the entries of files that no longer exist.
+func (c *Cache) Prune() {
+	for path := range c.Files {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			delete(c.Files, path)
+			c.changed = true
+		}
+	}
+}
+
+// ScanDirectories returns the sessions of the files under dirs that a
+// parser accepts, reading unchanged files from the cache and parsing
+// the others on a pool of workers. Missing directories and unreadable
+// files are skipped.
+func (c *Cache) ScanDirectories(dirs ...string) ([]*Session, CacheStats) {
+	var files []string
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		_ = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err == nil && !info.IsDir() && !seen[path] {
+				seen[path] = true
+				files = append(files, path)
+			}
+			return nil
+		})
+	}
+	return c.ParseFiles(files)
+}
+
+// ParseFiles returns the sessions of the files a parser accepts, in the
+// order of the files, reading unchanged files from the cache and parsing
+// the others on a pool of workers.
+//
+// Files that fail to parse are reported in the stats and not cached, so
+// they are parsed again on the next scan.
+func (c *Cache) ParseFiles(files []string) ([]*Session, CacheStats) {
+	available := make(map[string]SessionParser)
+	var ordered []SessionParser
+	for _, p := range parsers() {
+		key := parserKey(p)
+		if available[key] == nil {
+			available[key] = p
+			ordered = append(ordered, p)
+		}
+	}
+
+	type job struct {
+		path   string
+		stamp  fileStamp
+		parser SessionParser
+	}
+	results := make([][]*Session, len(files))
+	errs := make([]error, len(files))
+	var jobs []int
+	var pending []job
+	stats := CacheStats{}
+
+	for i, path := range files {
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		stamp := fileStamp{ModTime: info.ModTime(), Size: info.Size()}
+
+		if e := c.Files[path]; e != nil && available[e.Parser] != nil && c.fresh(e, stamp) {
+			stats.Files++
+			results[i] = stubs(e.Sessions)
+			continue
+		}
+
+		for _, p := range ordered {
+			if p.CanParse(path) {
+				stats.Files++
+				jobs = append(jobs, i)
+				pending = append(pending, job{path: path, stamp: stamp, parser: p})
+				break
+			}
+		}
+	}
+
+	// Parse changed files on a pool of workers
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for range min(runtime.GOMAXPROCS(0), len(pending)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range next {
+				j := pending[n]
+				sessions, err := parseWith(j.parser, j.path)
+				results[jobs[n]] = sessions
+				if err != nil {
+					errs[jobs[n]] = fmt.Errorf("%s: %w", j.path, err)
+					continue
+				}
+				c.store(j.path, j.stamp, j.parser, sessions)
+			}
+		}()
+	}
+	for n := range pending {
+		next <- n
+	}
+	close(next)
+	wg.Wait()
+	stats.Parsed = len(pending)
+
+	var all []*Session
+	for i, sessions := range results {
+		all = append(all, sessions...)
+		if errs[i] != nil {
+			stats.Errors = append(stats.Errors, errs[i])
+		}
+	}
+	stats.Sessions = len(all)
+	return all, stats
+}
+
+// fresh reports whether an entry still describes a file and the files it
+// was read with.
+func (c *Cache) fresh(e *cacheEntry, stamp fileStamp) bool {
+	if !e.ModTime.Equal(stamp.ModTime) || e.Size != stamp.Size {
+		return false
+	}
+	deps := make(map[string]fileStamp)
+	for _, dep := range dependencies(e.Sessions) {
+		deps[dep] = fileStamp{}
+	}
+	if len(deps) != len(e.Deps) {
+		return false
+	}
+	for dep := range deps {
+		prev, ok := e.Deps[dep]
+		info, err := os.Stat(dep)
+		if !ok || err != nil || !prev.ModTime.Equal(info.ModTime()) || prev.Size != info.Size() {
+			return false
+		}
+	}
+	return true
+}
+
+// store records the sessions parsed from a file, without their
+// messages.
+func (c *Cache) store(path string, stamp fileStamp, p SessionParser, sessions []*Session) {
+	e := &cacheEntry{Parser: parserKey(p), fileStamp: stamp}
+	for _, s := range sessions {
+		meta := *s
+		meta.Messages, meta.Branches, meta.Sidechains = nil, nil, nil
+		e.Sessions = append(e.Sessions, &meta)
+	}
+	for _, dep := range dependencies(sessions) {
+		if info, err := os.Stat(dep); err == nil {
+			if e.Deps == nil {
+				e.Deps = make(map[string]fileStamp)
+			}
+			e.Deps[dep] = fileStamp{ModTime: info.ModTime(), Size: info.Size()}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Files[path] = e
+	c.changed = true
+}
+
+// stubs returns copies of cached sessions marked to load their messages
+// on demand.
+func stubs(cached []*Session) []*Session {
+	sessions := make([]*Session, len(cached))
+	for i, s := range cached {
+		stub := *s
+		stub.stub = true
+		sessions[i] = &stub
+	}
+	return sessions
+}
+
+// dependencies returns the files other than their source file that
+// sessions were read from: the subagent files of Claude Code sessions
+// and the input history next to an Aider chat history.
+func dependencies(sessions []*Session) []string {
+	var deps []string
+	seen := make(map[string]bool)
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			deps = append(deps, path)
+		}
+	}
+	for _, s := range sessions {
+		switch {
+		case s.Tool == "claude-code" && filepath.Ext(s.SourceFile) == ".jsonl":
+			for _, agent := range agentFiles(s.SourceFile, s.ID) {
+				add(agent)
+			}
+		case s.Tool == "aider" && filepath.Base(s.SourceFile) == aiderChatHistory:
+			input := filepath.Join(filepath.Dir(s.SourceFile), aiderInputHistory)
+			if _, err := os.Stat(input); err == nil {
+				add(input)
+			}
+		}
+	}
+	return deps
+}
+
+// parserKey identifies a parser in the cache. External parsers are
+// identified by their command too, so that changing it in .contextrc
+// parses their files again.
+func parserKey(p SessionParser) string {
+	if ext, ok := p.(*ExternalParser); ok {
+		return ext.tool + "\x00" + ext.command
+	}
+	return p.Tool()
+}
+
+// Load reads the messages of a session listed from the parse cache by
+// parsing its source file again. Sessions that were parsed in full are
+// left as they are.
+func (s *Session) Load() error {
+	if !s.stub {
+		return nil
+	}
+	sessions, err := ParseFile(s.SourceFile)
+	if err != nil {
+		return err
+	}
+	for _, fresh := range sessions {
+		if fresh.ID == s.ID {
+			*s = *fresh
+			return nil
+		}
+	}
+	return fmt.Errorf("session %s not found in %s", s.ID, s.SourceFile)
+}
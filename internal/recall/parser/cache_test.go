@@ -0,0 +1,157 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// cachedScan opens the cache at path, scans dir through it and saves it.
+func cachedScan(t *testing.T, path, dir string) ([]*Session, CacheStats) {
+	t.Helper()
+	c, err := OpenCache(path)
+	if err != nil {
+		t.Fatalf("OpenCache: %v", err)
+	}
+	sessions, stats := c.ScanDirectories(dir)
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	return sessions, stats
+}
+
+func TestCache_AppendToActiveSession(t *testing.T) {
+	dir := t.TempDir()
+	cachePath := filepath.Join(t.TempDir(), "ctx", cacheFile)
+	file := filepath.Join(dir, "session.jsonl")
+	lines := treeLine("u1", "", "user", "00", treeText("First question"), "") + "\n" +
+		treeLine("a1", "u1", "assistant", "10", treeText("First answer"), "") + "\n"
+	if err := os.WriteFile(file, []byte(lines), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, stats := cachedScan(t, cachePath, dir)
+	if stats.Parsed != 1 || len(sessions) != 1 || sessions[0].TurnCount != 1 {
+		t.Fatalf("first scan: parsed %d, %d sessions", stats.Parsed, len(sessions))
+	}
+	if len(sessions[0].Messages) != 2 {
+		t.Errorf("parsed session has %d messages, want 2", len(sessions[0].Messages))
+	}
+
+	// Unchanged files are read from the cache, without messages
+	sessions, stats = cachedScan(t, cachePath, dir)
+	if stats.Parsed != 0 || stats.Files != 1 {
+		t.Fatalf("second scan: parsed %d of %d files, want 0 of 1", stats.Parsed, stats.Files)
+	}
+	s := sessions[0]
+	if len(s.Messages) != 0 || s.FirstUserMsg != "First question" {
+		t.Errorf("cached session: %d messages, preview %q", len(s.Messages), s.FirstUserMsg)
+	}
+	if err := s.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(s.Messages) != 2 {
+		t.Errorf("loaded session has %d messages, want 2", len(s.Messages))
+	}
+
+	// The session goes on while ctx lists it
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(treeLine("u2", "a1", "user", "20", treeText("Second question"), "") + "\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, stats = cachedScan(t, cachePath, dir)
+	if stats.Parsed != 1 {
+		t.Errorf("scan after append parsed %d files, want 1", stats.Parsed)
+	}
+	if got := sessions[0].TurnCount; got != 2 {
+		t.Errorf("TurnCount after append = %d, want 2", got)
+	}
+	if got := sessions[0].EndTime.Format(time.TimeOnly); got != "10:00:20" {
+		t.Errorf("EndTime after append = %s, want 10:00:20", got)
+	}
+
+	// An append that keeps the modification time still changes the size
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err = os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(treeLine("a2", "u2", "assistant", "30", treeText("Second answer"), "") + "\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(file, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, stats = cachedScan(t, cachePath, dir)
+	if stats.Parsed != 1 {
+		t.Errorf("scan after same-time append parsed %d files, want 1", stats.Parsed)
+	}
+	if got := sessions[0].EndTime.Format(time.TimeOnly); got != "10:00:30" {
+		t.Errorf("EndTime after same-time append = %s, want 10:00:30", got)
+	}
+}
+
+func TestCache_SubagentFiles(t *testing.T) {
+	dir := t.TempDir()
+	cachePath := filepath.Join(t.TempDir(), cacheFile)
+	lines := treeLine("u1", "", "user", "00", treeText("Who calls Parse?"), "") + "\n" +
+		treeLine("a1", "u1", "assistant", "10", `[{"type":"tool_use","id":"t1","name":"Task","input":{"prompt":"Find callers"}}]`, "") + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "session.jsonl"), []byte(lines), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cachedScan(t, cachePath, dir)
+
+	// A new subagent file changes the session it belongs to
+	agent := treeLine("s1", "", "user", "05", treeText("Find callers"), `,"isSidechain":true`) + "\n" +
+		treeLine("s2", "s1", "assistant", "40", treeText("Two callers"), `,"isSidechain":true`) + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "agent-1.jsonl"), []byte(agent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, stats := cachedScan(t, cachePath, dir)
+	if stats.Parsed != 2 {
+		t.Errorf("parsed %d files, want the session and the agent file", stats.Parsed)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("got %d sessions, want 1", len(sessions))
+	}
+	if got := sessions[0].EndTime.Format(time.TimeOnly); got != "10:00:40" {
+		t.Errorf("EndTime = %s, want the subagent's 10:00:40", got)
+	}
+}
+
+func TestOpenCache_Outdated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), cacheFile)
+	content := `{"version":0,"files":{"/gone.jsonl":{"parser":"claude-code","sessions":[]}}}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := OpenCache(path)
+	if err != nil {
+		t.Fatalf("OpenCache: %v", err)
+	}
+	if len(c.Files) != 0 {
+		t.Errorf("outdated cache kept %d files", len(c.Files))
+	}
+}
@@ -8,6 +8,8 @@ package parser
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -70,24 +72,89 @@ func (p *ClaudeCodeParser) CanParse(path string) bool {
 }
 
 // ParseFile reads a Claude Code JSONL file and returns all sessions.
+//
+// Subagent conversations that Claude Code wrote to agent-*.jsonl files
+// next to the session file are read with it. Sessions with nothing but
+// subagent conversations, as parsed from such a file on its own, are
+// skipped.
+//
+// Lines without a session ID, as in hand-written transcripts, form a
+// session if the file has no other sessions, with an ID derived from the
+// file's path. Only callers of ParseFile on this parser, such as
+// "ctx session parse", see such sessions: CanParse requires a session ID
+// and slug, so FindSessions and the package-level ParseFile skip the file.
 func (p *ClaudeCodeParser) ParseFile(path string) ([]*Session, error) {
+	// Group messages by session ID
+	sessionMsgs := make(map[string][]claudeRawMessage)
+	add := func(raw claudeRawMessage) {
+		sessionMsgs[raw.SessionID] = append(sessionMsgs[raw.SessionID], raw)
+	}
+	if err := scanClaudeFile(path, add); err != nil {
+		return nil, err
+	}
+	if len(sessionMsgs) > 1 {
+		delete(sessionMsgs, "")
+	}
+
+	if !strings.HasPrefix(filepath.Base(path), "agent-") {
+		for sessionID := range sessionMsgs {
+			for _, agent := range agentFiles(path, sessionID) {
+				// A subagent file that cannot be read only loses its sidechain
+				_ = scanClaudeFile(agent, func(raw claudeRawMessage) {
+					if raw.SessionID == sessionID {
+						add(raw)
+					}
+				})
+			}
+		}
+	}
+
+	// Convert to sessions
+	var sessions []*Session
+	for sessionID, msgs := range sessionMsgs {
+		if sessionID == "" {
+			sessionID = fileSessionID(path)
+		}
+		session := p.buildSession(sessionID, msgs, path)
+		if session != nil {
+			sessions = append(sessions, session)
+		}
+	}
+
+	// Sort sessions by start time
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartTime.Before(sessions[j].StartTime)
+	})
+
+	return sessions, nil
+}
+
+// fileSessionID returns the ID of the session formed by the lines
+// without a session ID in the file at path: a hash of its absolute path.
+func fileSessionID(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	sum := sha256.Sum256([]byte(path))
+	return hex.EncodeToString(sum[:8])
+}
+
+// scanClaudeFile reads the lines of a Claude Code JSONL file that can belong
+// to a session: user and assistant messages, and the system lines that
+// link them.
+func scanClaudeFile(path string, add func(claudeRawMessage)) error {
 	file, err := os.Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("open file: %w", err)
+		return fmt.Errorf("open file: %w", err)
 	}
 	defer file.Close()
 
-	// Group messages by session ID
-	sessionMsgs := make(map[string][]claudeRawMessage)
-
 	scanner := bufio.NewScanner(file)
 	// Increase buffer size for large lines
 	buf := make([]byte, 0, 64*1024)
 	scanner.Buffer(buf, 1024*1024) // 1MB max line size
 
-	lineNum := 0
 	for scanner.Scan() {
-		lineNum++
 		line := scanner.Bytes()
 		if len(line) == 0 {
 			continue
@@ -100,36 +167,18 @@ func (p *ClaudeCodeParser) ParseFile(path string) ([]*Session, error) {
 		}
 
 		// Skip non-message lines (e.g., file-history-snapshot)
-		if raw.Type != "user" && raw.Type != "assistant" {
+		if raw.Type != "user" && raw.Type != "assistant" &&
+			(raw.Type != "system" || raw.UUID == "") {
 			continue
 		}
 
-		if raw.SessionID == "" {
-			continue
-		}
-
-		sessionMsgs[raw.SessionID] = append(sessionMsgs[raw.SessionID], raw)
+		add(raw)
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("scan file: %w", err)
+		return fmt.Errorf("scan file: %w", err)
 	}
-
-	// Convert to sessions
-	var sessions []*Session
-	for sessionID, msgs := range sessionMsgs {
-		session := p.buildSession(sessionID, msgs, path)
-		if session != nil {
-			sessions = append(sessions, session)
-		}
-	}
-
-	// Sort sessions by start time
-	sort.Slice(sessions, func(i, j int) bool {
-		return sessions[i].StartTime.Before(sessions[j].StartTime)
-	})
-
-	return sessions, nil
+	return nil
 }
 
 // ParseLine parses a single JSONL line into a Message.
@@ -153,18 +202,38 @@ func (p *ClaudeCodeParser) ParseLine(line []byte) (*Message, string, error) {
 }
 
 // buildSession constructs a Session from raw Claude Code messages.
+//
+// Messages hold the main branch of the conversation tree; see
+// buildClaudeTree. Times, token counts, errors and the model cover all
+// messages, including branches and sidechains.
 func (p *ClaudeCodeParser) buildSession(id string, rawMsgs []claudeRawMessage, sourcePath string) *Session {
-	if len(rawMsgs) == 0 {
-		return nil
-	}
-
-	// Sort by timestamp
-	sort.Slice(rawMsgs, func(i, j int) bool {
+	// Sort by timestamp, keeping file order for equal times
+	sort.SliceStable(rawMsgs, func(i, j int) bool {
 		return rawMsgs[i].Timestamp.Before(rawMsgs[j].Timestamp)
 	})
 
-	first := rawMsgs[0]
-	last := rawMsgs[len(rawMsgs)-1]
+	var msgs []claudeRawMessage
+	for _, raw := range rawMsgs {
+		if raw.Type == "user" || raw.Type == "assistant" {
+			msgs = append(msgs, raw)
+		}
+	}
+
+	tree := buildClaudeTree(rawMsgs)
+	main := p.convertPath(tree.main)
+	if len(main) == 0 {
+		return nil
+	}
+
+	first := msgs[0]
+	for _, raw := range msgs {
+		if !raw.IsSidechain {
+			first = raw
+			break
+		}
+	}
+	start := msgs[0].Timestamp
+	end := msgs[len(msgs)-1].Timestamp
 
 	session := &Session{
 		ID:         id,
@@ -172,18 +241,42 @@ func (p *ClaudeCodeParser) buildSession(id string, rawMsgs []claudeRawMessage, s
 		Tool:       "claude-code",
 		SourceFile: sourcePath,
 		CWD:        first.CWD,
-		Project:    filepath.Base(first.CWD),
 		GitBranch:  first.GitBranch,
-		StartTime:  first.Timestamp,
-		EndTime:    last.Timestamp,
-		Duration:   last.Timestamp.Sub(first.Timestamp),
+		StartTime:  start,
+		EndTime:    end,
+		Duration:   end.Sub(start),
+		Messages:   main,
+	}
+	if first.CWD != "" {
+		session.Project = filepath.Base(first.CWD)
 	}
 
-	// Convert messages and accumulate stats
-	for _, raw := range rawMsgs {
-		msg := p.convertMessage(raw)
-		session.Messages = append(session.Messages, msg)
+	for i, path := range tree.branches {
+		branch := p.convertPath(path)
+		if len(branch) == 0 {
+			continue
+		}
+		b := Branch{Messages: branch}
+		if fork := tree.forks[i]; fork != nil {
+			if !fork.isMessage() {
+				fork = messageParent(fork)
+			}
+			if fork != nil {
+				b.ParentID = fork.raw.UUID
+			}
+		}
+		session.Branches = append(session.Branches, b)
+	}
 
+	var sidechains [][]Message
+	for _, path := range tree.sidechains {
+		if conv := p.convertPath(path); len(conv) > 0 {
+			sidechains = append(sidechains, conv)
+		}
+	}
+	attachSidechains(session, sidechains)
+
+	for _, msg := range session.Messages {
 		if msg.IsUser() {
 			session.TurnCount++
 			if session.FirstUserMsg == "" && msg.Text != "" {
@@ -195,9 +288,25 @@ func (p *ClaudeCodeParser) buildSession(id string, rawMsgs []claudeRawMessage, s
 				session.FirstUserMsg = preview
 			}
 		}
+	}
 
-		session.TotalTokensIn += msg.TokensIn
-		session.TotalTokensOut += msg.TokensOut
+	// Accumulate stats. Claude Code writes a line per content block of a
+	// response, each repeating its usage, so usage is counted once per
+	// message ID and request ID, from the response's last line.
+	var usages []claudeRawUsage
+	responses := make(map[string]int)
+	for _, raw := range msgs {
+		msg := p.convertMessage(raw)
+
+		if u := raw.Message.Usage; u != nil {
+			key := raw.Message.ID + "\x00" + raw.RequestID
+			if i, ok := responses[key]; ok && key != "\x00" {
+				usages[i] = *u
+			} else {
+				responses[key] = len(usages)
+				usages = append(usages, *u)
+			}
+		}
 
 		// Check for errors in tool results
 		for _, tr := range msg.ToolResults {
@@ -212,11 +321,34 @@ func (p *ClaudeCodeParser) buildSession(id string, rawMsgs []claudeRawMessage, s
 		}
 	}
 
-	session.TotalTokens = session.TotalTokensIn + session.TotalTokensOut
+	for _, u := range usages {
+		session.TotalTokensIn += u.InputTokens
+		session.TotalTokensOut += u.OutputTokens
+		session.TotalTokensCacheWrite += u.CacheCreationInputTokens
+		session.TotalTokensCacheRead += u.CacheReadInputTokens
+	}
+	session.TotalTokens = session.SumTokens()
 
 	return session
 }
 
+// convertPath converts the messages on a path of the conversation tree,
+// linking each to the message before it.
+func (p *ClaudeCodeParser) convertPath(path []*claudeNode) []Message {
+	var msgs []Message
+	for _, n := range path {
+		if !n.isMessage() {
+			continue
+		}
+		msg := p.convertMessage(n.raw)
+		if parent := messageParent(n); parent != nil {
+			msg.ParentID = parent.raw.UUID
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
 // convertMessage converts a Claude Code raw message to the common Message type.
 func (p *ClaudeCodeParser) convertMessage(raw claudeRawMessage) Message {
 	msg := Message{
@@ -228,6 +360,8 @@ func (p *ClaudeCodeParser) convertMessage(raw claudeRawMessage) Message {
 	if raw.Message.Usage != nil {
 		msg.TokensIn = raw.Message.Usage.InputTokens
 		msg.TokensOut = raw.Message.Usage.OutputTokens
+		msg.TokensCacheWrite = raw.Message.Usage.CacheCreationInputTokens
+		msg.TokensCacheRead = raw.Message.Usage.CacheReadInputTokens
 	}
 
 	// Parse content - can be a string or array of blocks
@@ -279,6 +413,12 @@ func (p *ClaudeCodeParser) convertMessage(raw claudeRawMessage) Message {
 		}
 	}
 
+	// The tool result record belongs to the line's only result
+	var record claudeToolUseResult
+	if len(msg.ToolResults) == 1 && json.Unmarshal(raw.ToolUseResult, &record) == nil {
+		msg.ToolResults[0].File = record.fileContent()
+	}
+
 	return msg
 }
 
@@ -319,6 +459,45 @@ type claudeRawMessage struct {
 	Version     string             `json:"version"`
 	Slug        string             `json:"slug"`
 	Message     claudeRawContent   `json:"message"`
+
+	// LogicalParentUUID links a compact boundary to the conversation it
+	// summarizes
+	LogicalParentUUID *string `json:"logicalParentUuid,omitempty"`
+
+	// ToolUseResult is Claude Code's own record of a tool result: an
+	// object for file tools, a string for errors
+	ToolUseResult json.RawMessage `json:"toolUseResult,omitempty"`
+}
+
+// claudeToolUseResult holds the file contents of a tool result record:
+// the file before an edit or write, or the part of a file read. Writes
+// that create a file have the type "create".
+type claudeToolUseResult struct {
+	Type         string  `json:"type"`
+	OriginalFile *string `json:"originalFile"`
+	File         *struct {
+		Content    string `json:"content"`
+		NumLines   int    `json:"numLines"`
+		StartLine  int    `json:"startLine"`
+		TotalLines int    `json:"totalLines"`
+	} `json:"file"`
+}
+
+// fileContent returns the full file content recorded with a tool
+// result, "" for a created file, or nil if there is none or the file was
+// read in part.
+func (r claudeToolUseResult) fileContent() *string {
+	if r.OriginalFile != nil {
+		return r.OriginalFile
+	}
+	if r.Type == "create" {
+		empty := ""
+		return &empty
+	}
+	if f := r.File; f != nil && f.StartLine <= 1 && f.NumLines >= f.TotalLines {
+		return &f.Content
+	}
+	return nil
 }
 
 type claudeRawContent struct {
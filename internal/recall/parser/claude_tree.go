@@ -0,0 +1,343 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// claudeNode is a line of a Claude Code session in its conversation tree.
+type claudeNode struct {
+	raw      claudeRawMessage
+	parent   *claudeNode
+	children []*claudeNode
+}
+
+// isMessage reports whether the node is a user or assistant message, as
+// opposed to a system line that only links the tree.
+func (n *claudeNode) isMessage() bool {
+	return n.raw.Type == "user" || n.raw.Type == "assistant"
+}
+
+// claudeTree is the conversation tree of a session, split into paths
+// from the oldest to the newest line.
+type claudeTree struct {
+	main []*claudeNode
+	// branches are the paths off the main branch; forks[i] is the node
+	// branches[i] continues from, or nil
+	branches [][]*claudeNode
+	forks    []*claudeNode
+	// sidechains are the subagent conversations
+	sidechains [][]*claudeNode
+}
+
+// buildClaudeTree links the lines of a session by parentUuid and splits
+// the tree into the main branch, the branches left behind, and the
+// sidechains.
+//
+// Lines must be sorted by time. The main branch ends at the newest line
+// outside the sidechains; every other leaf ends a branch that starts
+// after the last line it shares with the main branch or an earlier
+// branch. A line whose parent is missing from the session continues the
+// line before it, so sessions written without parentUuid stay linear.
+// After /compact, the conversation continues from logicalParentUuid.
+func buildClaudeTree(raws []claudeRawMessage) claudeTree {
+	byID := make(map[string]*claudeNode, len(raws))
+	var nodes []*claudeNode
+	for _, raw := range raws {
+		if raw.UUID != "" && byID[raw.UUID] != nil {
+			// Resumed sessions may repeat lines
+			continue
+		}
+		n := &claudeNode{raw: raw}
+		nodes = append(nodes, n)
+		if raw.UUID != "" {
+			byID[raw.UUID] = n
+		}
+	}
+
+	var prev *claudeNode
+	for _, n := range nodes {
+		parentID := ""
+		if p := n.raw.ParentUUID; p != nil {
+			parentID = *p
+		} else if p := n.raw.LogicalParentUUID; p != nil {
+			parentID = *p
+		}
+		p := byID[parentID]
+		if p == nil && !n.raw.IsSidechain {
+			p = prev
+		}
+		// Only a node that earlier lines named as their parent can be an
+		// ancestor of p, so most lines skip the walk to the root
+		if p != nil && p != n && (len(n.children) == 0 || !isAncestor(n, p)) {
+			n.parent = p
+			p.children = append(p.children, n)
+		}
+		if !n.raw.IsSidechain {
+			prev = n
+		}
+	}
+
+	var tree claudeTree
+	covered := make(map[*claudeNode]bool)
+
+	// Main branch and the branches left behind
+	var leaves []*claudeNode
+	for _, n := range nodes {
+		if !n.raw.IsSidechain && !hasChild(n, false) {
+			leaves = append(leaves, n)
+		}
+	}
+	if len(leaves) > 0 {
+		// The newest leaf ends the main branch; message leaves win over
+		// system lines, and ties go to the last line
+		newest := leaves[len(leaves)-1]
+		for _, leaf := range leaves {
+			if newer(leaf, newest) {
+				newest = leaf
+			}
+		}
+		tree.main, _ = claudePath(newest, covered)
+		for _, leaf := range leaves {
+			if leaf == newest {
+				continue
+			}
+			path, fork := claudePath(leaf, covered)
+			tree.branches = append(tree.branches, path)
+			tree.forks = append(tree.forks, fork)
+		}
+	}
+
+	// Sidechains, each from its root to its newest leaf
+	for _, root := range nodes {
+		if !root.raw.IsSidechain || (root.parent != nil && root.parent.raw.IsSidechain) {
+			continue
+		}
+		newest := root
+		stack := []*claudeNode{root}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !n.raw.Timestamp.Before(newest.raw.Timestamp) {
+				newest = n
+			}
+			for _, c := range n.children {
+				if c.raw.IsSidechain {
+					stack = append(stack, c)
+				}
+			}
+		}
+		var path []*claudeNode
+		for n := newest; n != nil; n = n.parent {
+			path = append(path, n)
+			if n == root {
+				break
+			}
+		}
+		reverse(path)
+		tree.sidechains = append(tree.sidechains, path)
+	}
+
+	return tree
+}
+
+// newer reports whether leaf a should end the main branch over leaf b.
+func newer(a, b *claudeNode) bool {
+	if a.isMessage() != b.isMessage() {
+		return a.isMessage()
+	}
+	return a.raw.Timestamp.After(b.raw.Timestamp)
+}
+
+// claudePath returns the path from the first uncovered ancestor of a
+// leaf to the leaf, marking it covered, and the covered node it
+// continues from.
+func claudePath(leaf *claudeNode, covered map[*claudeNode]bool) ([]*claudeNode, *claudeNode) {
+	var path []*claudeNode
+	n := leaf
+	for ; n != nil && !covered[n]; n = n.parent {
+		covered[n] = true
+		path = append(path, n)
+	}
+	reverse(path)
+	return path, n
+}
+
+// isAncestor reports whether a is an ancestor of n.
+func isAncestor(a, n *claudeNode) bool {
+	for p := n.parent; p != nil; p = p.parent {
+		if p == a {
+			return true
+		}
+	}
+	return false
+}
+
+// hasChild reports whether a node has a child inside or outside the
+// sidechains.
+func hasChild(n *claudeNode, sidechain bool) bool {
+	for _, c := range n.children {
+		if c.raw.IsSidechain == sidechain {
+			return true
+		}
+	}
+	return false
+}
+
+// reverse reverses a path in place.
+func reverse(path []*claudeNode) {
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+}
+
+// messageParent returns the nearest ancestor of a node that is a
+// message, or nil.
+func messageParent(n *claudeNode) *claudeNode {
+	for p := n.parent; p != nil; p = p.parent {
+		if p.isMessage() {
+			return p
+		}
+	}
+	return nil
+}
+
+// subagentTools are the tools that spawn sidechain conversations.
+var subagentTools = map[string]bool{"Task": true, "Agent": true}
+
+// attachSidechains attaches each sidechain conversation to the subagent
+// call that spawned it: the call whose prompt opens the conversation,
+// or else the last unattached call before it. Conversations without a
+// call are kept in the session's Sidechains.
+func attachSidechains(s *Session, sidechains [][]Message) {
+	type call struct {
+		use    *ToolUse
+		time   time.Time
+		prompt string
+	}
+	var calls []call
+	collect := func(msgs []Message) {
+		for i := range msgs {
+			for j := range msgs[i].ToolUses {
+				tu := &msgs[i].ToolUses[j]
+				if !subagentTools[tu.Name] {
+					continue
+				}
+				var input struct {
+					Prompt string `json:"prompt"`
+				}
+				_ = json.Unmarshal([]byte(tu.Input), &input)
+				calls = append(calls, call{tu, msgs[i].Timestamp, strings.TrimSpace(input.Prompt)})
+			}
+		}
+	}
+	collect(s.Messages)
+	for i := range s.Branches {
+		collect(s.Branches[i].Messages)
+	}
+
+	for _, conv := range sidechains {
+		prompt := ""
+		if conv[0].IsUser() {
+			prompt = strings.TrimSpace(conv[0].Text)
+		}
+
+		var match *call
+		for i := range calls {
+			if calls[i].use.Sidechain == nil && prompt != "" && calls[i].prompt == prompt {
+				match = &calls[i]
+				break
+			}
+		}
+		if match == nil {
+			for i := range calls {
+				c := &calls[i]
+				if c.use.Sidechain == nil && !c.time.After(conv[0].Timestamp) &&
+					(match == nil || c.time.After(match.time)) {
+					match = c
+				}
+			}
+		}
+
+		if match != nil {
+			match.use.Sidechain = conv
+		} else {
+			s.Sidechains = append(s.Sidechains, Branch{Messages: conv})
+		}
+	}
+}
+
+// claudeAgentFiles caches, per directory, the subagent files of each
+// session, keyed by the directory's modification time.
+var claudeAgentFiles = struct {
+	sync.Mutex
+	dirs map[string]claudeAgentDir
+}{dirs: make(map[string]claudeAgentDir)}
+
+type claudeAgentDir struct {
+	modTime   time.Time
+	bySession map[string][]string
+}
+
+// agentFiles returns the subagent files next to a session file that
+// belong to the given session.
+//
+// Claude Code writes each subagent conversation to its own
+// agent-*.jsonl file, with the session ID of the session that spawned
+// it.
+func agentFiles(path, sessionID string) []string {
+	dir := filepath.Dir(path)
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil
+	}
+
+	claudeAgentFiles.Lock()
+	defer claudeAgentFiles.Unlock()
+
+	cached, ok := claudeAgentFiles.dirs[dir]
+	if !ok || !cached.modTime.Equal(info.ModTime()) {
+		cached = claudeAgentDir{modTime: info.ModTime(), bySession: make(map[string][]string)}
+		matches, _ := filepath.Glob(filepath.Join(dir, "agent-*.jsonl"))
+		sort.Strings(matches)
+		for _, m := range matches {
+			if id := firstSessionID(m); id != "" {
+				cached.bySession[id] = append(cached.bySession[id], m)
+			}
+		}
+		claudeAgentFiles.dirs[dir] = cached
+	}
+	return cached.bySession[sessionID]
+}
+
+// firstSessionID returns the session ID on the first line of a session
+// file that has one.
+func firstSessionID(path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var raw claudeRawMessage
+		if json.Unmarshal(scanner.Bytes(), &raw) == nil && raw.SessionID != "" {
+			return raw.SessionID
+		}
+	}
+	return ""
+}
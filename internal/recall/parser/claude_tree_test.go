@@ -0,0 +1,176 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// treeLine returns a Claude Code JSONL line for the tree tests. An empty
+// parent writes a null parentUuid; extra is spliced into the object.
+func treeLine(uuid, parent, typ, second, content, extra string) string {
+	parentJSON := "null"
+	if parent != "" {
+		parentJSON = fmt.Sprintf("%q", parent)
+	}
+	return fmt.Sprintf(`{"uuid":%q,"parentUuid":%s,"sessionId":"sess-1","slug":"tree-session","type":%q,"timestamp":"2026-01-20T10:00:%sZ","cwd":"/home/test/project"%s,"message":{"role":%q,"content":%s}}`,
+		uuid, parentJSON, typ, second, extra, typ, content)
+}
+
+func treeText(text string) string {
+	return fmt.Sprintf(`[{"type":"text","text":%q}]`, text)
+}
+
+func parseTree(t *testing.T, files map[string][]string) *Session {
+	t.Helper()
+	dir := t.TempDir()
+	for name, lines := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(strings.Join(lines, "\n")), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sessions, err := NewClaudeCodeParser().ParseFile(filepath.Join(dir, "session.jsonl"))
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("got %d sessions, want 1", len(sessions))
+	}
+	return sessions[0]
+}
+
+func messageIDs(msgs []Message) string {
+	var ids []string
+	for _, m := range msgs {
+		ids = append(ids, m.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestClaudeCodeParser_ParseFile_Branches(t *testing.T) {
+	// The user rewinds to a1 and asks again; u2 and a2 are abandoned
+	s := parseTree(t, map[string][]string{"session.jsonl": {
+		treeLine("u1", "", "user", "00", treeText("Add a flag"), ""),
+		treeLine("a1", "u1", "assistant", "10", treeText("Which name?"), ""),
+		treeLine("u2", "a1", "user", "20", treeText("Call it --foo"), ""),
+		treeLine("a2", "u2", "assistant", "30", treeText("Added --foo"), ""),
+		treeLine("u3", "a1", "user", "40", treeText("Call it --tree"), ""),
+		treeLine("a3", "u3", "assistant", "50", treeText("Added --tree"), ""),
+	}})
+
+	if got := messageIDs(s.Messages); got != "u1,a1,u3,a3" {
+		t.Errorf("main branch = %s, want u1,a1,u3,a3", got)
+	}
+	if s.Messages[2].ParentID != "a1" {
+		t.Errorf("u3 parent = %q, want a1", s.Messages[2].ParentID)
+	}
+	if s.TurnCount != 2 {
+		t.Errorf("TurnCount = %d, want 2", s.TurnCount)
+	}
+	if len(s.Branches) != 1 {
+		t.Fatalf("got %d branches, want 1", len(s.Branches))
+	}
+	b := s.Branches[0]
+	if b.ParentID != "a1" {
+		t.Errorf("branch parent = %q, want a1", b.ParentID)
+	}
+	if got := messageIDs(b.Messages); got != "u2,a2" {
+		t.Errorf("branch = %s, want u2,a2", got)
+	}
+}
+
+func TestClaudeCodeParser_ParseFile_Sidechains(t *testing.T) {
+	taskCall := `[{"type":"tool_use","id":"t1","name":"Task","input":{"description":"Find callers","prompt":"Find the callers of Parse"}}]`
+	taskResult := `[{"type":"tool_result","tool_use_id":"t1","content":"Two callers"}]`
+	sidechain := `,"isSidechain":true`
+
+	s := parseTree(t, map[string][]string{
+		"session.jsonl": {
+			treeLine("u1", "", "user", "00", treeText("Who calls Parse?"), ""),
+			treeLine("a1", "u1", "assistant", "10", taskCall, ""),
+			treeLine("u2", "a1", "user", "40", taskResult, ""),
+			treeLine("a2", "u2", "assistant", "50", treeText("Two places call it"), ""),
+		},
+		"agent-1234.jsonl": {
+			treeLine("s1", "", "user", "11", treeText("Find the callers of Parse"), sidechain),
+			treeLine("s2", "s1", "assistant", "20", treeText("Searching"), sidechain),
+			treeLine("s3", "s2", "assistant", "30", treeText("Two callers"), sidechain),
+		},
+	})
+
+	if got := messageIDs(s.Messages); got != "u1,a1,u2,a2" {
+		t.Errorf("main branch = %s, want u1,a1,u2,a2", got)
+	}
+	if s.TurnCount != 2 {
+		t.Errorf("TurnCount = %d, want 2", s.TurnCount)
+	}
+	if got := messageIDs(s.Messages[1].ToolUses[0].Sidechain); got != "s1,s2,s3" {
+		t.Errorf("Task sidechain = %s, want s1,s2,s3", got)
+	}
+	if len(s.Branches) != 0 || len(s.Sidechains) != 0 {
+		t.Errorf("got %d branches and %d loose sidechains, want none",
+			len(s.Branches), len(s.Sidechains))
+	}
+}
+
+func TestClaudeCodeParser_ParseFile_CompactBoundary(t *testing.T) {
+	s := parseTree(t, map[string][]string{"session.jsonl": {
+		treeLine("u1", "", "user", "00", treeText("Start"), ""),
+		treeLine("a1", "u1", "assistant", "10", treeText("Started"), ""),
+		treeLine("c1", "", "system", "20", `"Conversation compacted"`, `,"logicalParentUuid":"a1","subtype":"compact_boundary"`),
+		treeLine("u2", "c1", "user", "30", treeText("Continue"), ""),
+		treeLine("a2", "u2", "assistant", "40", treeText("Continued"), ""),
+	}})
+
+	if got := messageIDs(s.Messages); got != "u1,a1,u2,a2" {
+		t.Errorf("main branch = %s, want u1,a1,u2,a2", got)
+	}
+	if s.Messages[2].ParentID != "a1" {
+		t.Errorf("u2 parent = %q, want a1", s.Messages[2].ParentID)
+	}
+	if len(s.Branches) != 0 {
+		t.Errorf("got %d branches, want 0", len(s.Branches))
+	}
+}
+
+func TestClaudeCodeParser_ParseFile_WithoutParents(t *testing.T) {
+	// Lines without parentUuid continue the line before them
+	lines := []string{
+		treeLine("u1", "", "user", "00", treeText("One"), ""),
+		treeLine("a1", "", "assistant", "10", treeText("Two"), ""),
+		treeLine("u2", "missing", "user", "20", treeText("Three"), ""),
+	}
+	s := parseTree(t, map[string][]string{"session.jsonl": lines})
+
+	if got := messageIDs(s.Messages); got != "u1,a1,u2" {
+		t.Errorf("messages = %s, want u1,a1,u2", got)
+	}
+	if len(s.Branches) != 0 {
+		t.Errorf("got %d branches, want 0", len(s.Branches))
+	}
+}
+
+func TestClaudeCodeParser_ParseFile_ParentCycle(t *testing.T) {
+	// Lines naming each other as parents must not form a loop
+	lines := []string{
+		treeLine("u1", "a1", "user", "00", treeText("One"), ""),
+		treeLine("a1", "u1", "assistant", "10", treeText("Two"), ""),
+		treeLine("u2", "a1", "user", "20", treeText("Three"), ""),
+	}
+	s := parseTree(t, map[string][]string{"session.jsonl": lines})
+
+	if got := messageIDs(s.Messages); got != "a1,u2" {
+		t.Errorf("messages = %s, want a1,u2", got)
+	}
+	if len(s.Branches) != 1 || messageIDs(s.Branches[0].Messages) != "u1" {
+		t.Errorf("branches = %+v, want u1 forked from a1", s.Branches)
+	}
+}
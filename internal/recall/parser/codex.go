@@ -0,0 +1,514 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CodexParser parses OpenAI Codex CLI rollout files.
+//
+// Codex CLI writes one JSONL rollout per session to
+// ~/.codex/sessions/YYYY/MM/DD/rollout-*.jsonl. The first line holds the
+// session metadata; the following lines are response items (messages,
+// reasoning, function calls and their outputs) and events such as token
+// counts. Older rollouts store the items bare, without the
+// {timestamp, type, payload} envelope.
+type CodexParser struct{}
+
+// NewCodexParser creates a new Codex CLI session parser.
+func NewCodexParser() *CodexParser {
+	return &CodexParser{}
+}
+
+// Tool returns the tool identifier.
+func (p *CodexParser) Tool() string {
+	return "codex"
+}
+
+// CanParse returns true if the file is a Codex CLI rollout file.
+func (p *CodexParser) CanParse(path string) bool {
+	base := filepath.Base(path)
+	if !strings.HasPrefix(base, "rollout-") || !strings.HasSuffix(base, ".jsonl") {
+		return false
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var line codexRawLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return false
+		}
+		_, ok := line.meta()
+		return ok
+	}
+	return false
+}
+
+// ParseFile reads a Codex CLI rollout file and returns its session.
+func (p *CodexParser) ParseFile(path string) ([]*Session, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	defer file.Close()
+
+	b := &codexSessionBuilder{session: &Session{Tool: "codex", SourceFile: path}}
+
+	scanner := bufio.NewScanner(file)
+	// Function outputs can be large
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var line codexRawLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			// Skip malformed lines, don't fail entire file
+			continue
+		}
+		b.line(line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan file: %w", err)
+	}
+
+	if b.session.ID == "" {
+		return nil, nil
+	}
+	return []*Session{b.done()}, nil
+}
+
+// ParseLine parses a single rollout line into a Message. Lines other
+// than user and assistant messages are skipped.
+func (p *CodexParser) ParseLine(line []byte) (*Message, string, error) {
+	if len(line) == 0 {
+		return nil, "", nil
+	}
+
+	var raw codexRawLine
+	if err := json.Unmarshal(line, &raw); err != nil {
+		return nil, "", fmt.Errorf("unmarshal: %w", err)
+	}
+	item, ok := raw.item()
+	if !ok || item.Type != "message" || (item.Role != "user" && item.Role != "assistant") {
+		return nil, "", nil
+	}
+	return &Message{Role: item.Role, Text: item.text(), Timestamp: raw.Timestamp}, "", nil
+}
+
+// codexSessionBuilder accumulates the lines of a rollout.
+type codexSessionBuilder struct {
+	session *Session
+	// time is the time of the line being read
+	time time.Time
+}
+
+// line adds a rollout line to the session.
+func (b *codexSessionBuilder) line(line codexRawLine) {
+	if !line.Timestamp.IsZero() {
+		b.time = line.Timestamp
+	}
+
+	if meta, ok := line.meta(); ok {
+		s := b.session
+		s.ID = meta.ID
+		s.CWD = meta.CWD
+		s.StartTime = meta.Timestamp
+		if meta.Git != nil {
+			s.GitBranch = meta.Git.Branch
+		}
+		if b.time.IsZero() {
+			b.time = meta.Timestamp
+		}
+		return
+	}
+
+	switch line.Type {
+	case "turn_context":
+		var ctx codexRawTurnContext
+		if json.Unmarshal(line.Payload, &ctx) == nil {
+			if b.session.Model == "" {
+				b.session.Model = ctx.Model
+			}
+			if b.session.CWD == "" {
+				b.session.CWD = ctx.CWD
+			}
+		}
+		return
+	case "event_msg":
+		var event codexRawEvent
+		if json.Unmarshal(line.Payload, &event) == nil && event.Type == "token_count" && event.Info != nil {
+			b.tokens(event.Info)
+		}
+		return
+	}
+
+	if item, ok := line.item(); ok {
+		b.item(item)
+	}
+}
+
+// item adds a response item to the session.
+//
+// Reasoning, function calls and replies of one turn are split into
+// assistant messages the way Claude Code records them: a message starts
+// with reasoning or text, and function calls join the message before
+// them. Function outputs are attached to the message holding the call.
+func (b *codexSessionBuilder) item(item codexRawItem) {
+	cur := b.current()
+
+	switch item.Type {
+	case "message":
+		text := item.text()
+		switch item.Role {
+		case "user":
+			// Codex sends the environment and project instructions as
+			// user messages of its own
+			if text == "" || strings.HasPrefix(text, "<environment_context>") ||
+				strings.HasPrefix(text, "<user_instructions>") {
+				return
+			}
+			b.add(Message{Role: "user", Text: text})
+		case "assistant":
+			if cur != nil && cur.Text == "" && !cur.HasToolUses() {
+				cur.Text = text
+				return
+			}
+			b.add(Message{Role: "assistant", Text: text})
+		}
+
+	case "reasoning":
+		thinking := item.summary()
+		if thinking == "" {
+			return
+		}
+		if cur != nil && cur.Text == "" && !cur.HasToolUses() {
+			if cur.Thinking != "" {
+				cur.Thinking += "\n"
+			}
+			cur.Thinking += thinking
+			return
+		}
+		b.add(Message{Role: "assistant", Thinking: thinking})
+
+	case "function_call", "custom_tool_call", "local_shell_call":
+		if cur == nil || len(cur.ToolResults) > 0 {
+			cur = b.add(Message{Role: "assistant"})
+		}
+		cur.ToolUses = append(cur.ToolUses, ToolUse{
+			ID:    item.callID(),
+			Name:  item.toolName(),
+			Input: item.toolInput(),
+		})
+
+	case "function_call_output", "custom_tool_call_output":
+		content, isError := item.output()
+		result := ToolResult{ToolUseID: item.CallID, Content: content, IsError: isError}
+		if isError {
+			b.session.HasErrors = true
+		}
+		if msg := b.caller(item.CallID); msg != nil {
+			msg.ToolResults = append(msg.ToolResults, result)
+		} else if cur != nil {
+			cur.ToolResults = append(cur.ToolResults, result)
+		}
+	}
+}
+
+// tokens records a token count: the session totals so far, and the usage
+// of the last request on the assistant message it produced.
+//
+// Codex counts cached input tokens as part of the input; they are moved
+// to the cache read count.
+func (b *codexSessionBuilder) tokens(info *codexRawTokenInfo) {
+	if u := info.TotalTokenUsage; u != nil {
+		b.session.TotalTokensIn = u.InputTokens - u.CachedInputTokens
+		b.session.TotalTokensOut = u.OutputTokens
+		b.session.TotalTokensCacheRead = u.CachedInputTokens
+		b.session.TotalTokens = b.session.SumTokens()
+	}
+	if u := info.LastTokenUsage; u != nil {
+		if cur := b.current(); cur != nil {
+			cur.TokensIn += u.InputTokens - u.CachedInputTokens
+			cur.TokensOut += u.OutputTokens
+			cur.TokensCacheRead += u.CachedInputTokens
+		}
+	}
+}
+
+// add appends a message and returns it.
+func (b *codexSessionBuilder) add(msg Message) *Message {
+	msg.Timestamp = b.time
+	msg.ID = fmt.Sprintf("%s-%d", b.session.ID, len(b.session.Messages))
+	b.session.Messages = append(b.session.Messages, msg)
+	return &b.session.Messages[len(b.session.Messages)-1]
+}
+
+// current returns the last message if it is an assistant message.
+func (b *codexSessionBuilder) current() *Message {
+	if n := len(b.session.Messages); n > 0 && b.session.Messages[n-1].IsAssistant() {
+		return &b.session.Messages[n-1]
+	}
+	return nil
+}
+
+// caller returns the message holding the call with the given ID.
+func (b *codexSessionBuilder) caller(callID string) *Message {
+	for i := len(b.session.Messages) - 1; i >= 0; i-- {
+		for _, tu := range b.session.Messages[i].ToolUses {
+			if tu.ID == callID {
+				return &b.session.Messages[i]
+			}
+		}
+	}
+	return nil
+}
+
+// done fills in the derived fields of the session.
+func (b *codexSessionBuilder) done() *Session {
+	s := b.session
+	s.Project = filepath.Base(s.CWD)
+	s.Slug = "codex-" + s.StartTime.Local().Format("20060102-150405")
+
+	s.EndTime = s.StartTime
+	if n := len(s.Messages); n > 0 && s.Messages[n-1].Timestamp.After(s.EndTime) {
+		s.EndTime = s.Messages[n-1].Timestamp
+	}
+	s.Duration = s.EndTime.Sub(s.StartTime)
+
+	for _, m := range s.Messages {
+		if !m.IsUser() {
+			continue
+		}
+		s.TurnCount++
+		if s.FirstUserMsg == "" && m.Text != "" {
+			s.FirstUserMsg = m.Preview(100)
+		}
+	}
+	return s
+}
+
+// Codex CLI-specific raw types for parsing rollouts
+
+// codexRawLine is a rollout line: either an envelope with a payload, or,
+// in older rollouts, the session metadata or a response item itself.
+type codexRawLine struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+
+	// Fields of older rollouts
+	ID   string          `json:"id"`
+	CWD  string          `json:"cwd"`
+	Git  *codexRawGit    `json:"git"`
+	Role string          `json:"role"`
+	raw  json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON keeps the raw line, which is the item of older rollouts.
+func (l *codexRawLine) UnmarshalJSON(data []byte) error {
+	type plain codexRawLine
+	if err := json.Unmarshal(data, (*plain)(l)); err != nil {
+		return err
+	}
+	l.raw = append(json.RawMessage{}, data...)
+	return nil
+}
+
+// meta returns the session metadata if the line holds it.
+func (l codexRawLine) meta() (codexRawMeta, bool) {
+	var meta codexRawMeta
+	switch {
+	case l.Type == "session_meta":
+		if json.Unmarshal(l.Payload, &meta) != nil {
+			return meta, false
+		}
+	case l.Type == "" && l.ID != "" && !l.Timestamp.IsZero():
+		meta = codexRawMeta{ID: l.ID, Timestamp: l.Timestamp, CWD: l.CWD, Git: l.Git}
+	default:
+		return meta, false
+	}
+	return meta, meta.ID != ""
+}
+
+// item returns the response item of the line, if any.
+func (l codexRawLine) item() (codexRawItem, bool) {
+	var item codexRawItem
+	data := l.raw
+	switch l.Type {
+	case "response_item":
+		data = l.Payload
+	case "message", "reasoning", "function_call", "function_call_output",
+		"custom_tool_call", "custom_tool_call_output", "local_shell_call":
+	default:
+		return item, false
+	}
+	return item, json.Unmarshal(data, &item) == nil
+}
+
+type codexRawMeta struct {
+	ID        string       `json:"id"`
+	Timestamp time.Time    `json:"timestamp"`
+	CWD       string       `json:"cwd"`
+	Git       *codexRawGit `json:"git,omitempty"`
+}
+
+type codexRawGit struct {
+	CommitHash string `json:"commit_hash,omitempty"`
+	Branch     string `json:"branch,omitempty"`
+}
+
+type codexRawTurnContext struct {
+	CWD   string `json:"cwd"`
+	Model string `json:"model"`
+}
+
+type codexRawEvent struct {
+	Type string             `json:"type"`
+	Info *codexRawTokenInfo `json:"info,omitempty"`
+}
+
+type codexRawTokenInfo struct {
+	TotalTokenUsage *codexRawUsage `json:"total_token_usage,omitempty"`
+	LastTokenUsage  *codexRawUsage `json:"last_token_usage,omitempty"`
+}
+
+type codexRawUsage struct {
+	InputTokens           int `json:"input_tokens"`
+	CachedInputTokens     int `json:"cached_input_tokens,omitempty"`
+	OutputTokens          int `json:"output_tokens"`
+	ReasoningOutputTokens int `json:"reasoning_output_tokens,omitempty"`
+	TotalTokens           int `json:"total_tokens,omitempty"`
+}
+
+type codexRawItem struct {
+	Type    string            `json:"type"`
+	Role    string            `json:"role,omitempty"`
+	Content []codexRawContent `json:"content,omitempty"`
+	Summary []codexRawContent `json:"summary,omitempty"`
+
+	Name      string          `json:"name,omitempty"`
+	Arguments string          `json:"arguments,omitempty"`
+	Input     string          `json:"input,omitempty"`
+	CallID    string          `json:"call_id,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Action    json.RawMessage `json:"action,omitempty"`
+	Output    json.RawMessage `json:"output,omitempty"`
+}
+
+type codexRawContent struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+}
+
+// text joins the text parts of a message.
+func (i codexRawItem) text() string {
+	var parts []string
+	for _, c := range i.Content {
+		if c.Text != "" {
+			parts = append(parts, c.Text)
+		}
+	}
+	return strings.TrimSpace(strings.Join(parts, "\n"))
+}
+
+// summary joins the summary parts of a reasoning item. The full
+// reasoning is encrypted.
+func (i codexRawItem) summary() string {
+	var parts []string
+	for _, c := range i.Summary {
+		if c.Text != "" {
+			parts = append(parts, c.Text)
+		}
+	}
+	return strings.TrimSpace(strings.Join(parts, "\n"))
+}
+
+// callID returns the ID that the output of the call refers to.
+func (i codexRawItem) callID() string {
+	if i.CallID != "" {
+		return i.CallID
+	}
+	return i.ID
+}
+
+// toolName returns the name of the called tool.
+func (i codexRawItem) toolName() string {
+	if i.Type == "local_shell_call" {
+		return "shell"
+	}
+	return i.Name
+}
+
+// toolInput returns the input of a call as it was sent: JSON arguments
+// for function calls, free-form input for custom tools such as
+// apply_patch, and the action for local shell calls.
+func (i codexRawItem) toolInput() string {
+	switch i.Type {
+	case "custom_tool_call":
+		return i.Input
+	case "local_shell_call":
+		return string(i.Action)
+	}
+	return i.Arguments
+}
+
+// output returns the content of a function output and whether the call
+// failed.
+//
+// Outputs are either a string or an object with content and success.
+// Shell outputs are often a JSON string themselves, holding the output
+// and the exit code.
+func (i codexRawItem) output() (string, bool) {
+	var content string
+	failed := false
+
+	var obj struct {
+		Content string `json:"content"`
+		Success *bool  `json:"success"`
+	}
+	if json.Unmarshal(i.Output, &content) != nil {
+		if json.Unmarshal(i.Output, &obj) != nil {
+			return string(i.Output), false
+		}
+		content = obj.Content
+		failed = obj.Success != nil && !*obj.Success
+	}
+
+	var shell struct {
+		Output   *string `json:"output"`
+		Metadata struct {
+			ExitCode int `json:"exit_code"`
+		} `json:"metadata"`
+	}
+	if json.Unmarshal([]byte(content), &shell) == nil && shell.Output != nil {
+		content = *shell.Output
+		failed = failed || shell.Metadata.ExitCode != 0
+	}
+
+	return content, failed
+}
+
+// Ensure CodexParser implements SessionParser
+var _ SessionParser = (*CodexParser)(nil)
@@ -0,0 +1,184 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const codexRollout = `{"timestamp":"2026-01-20T10:00:00.000Z","type":"session_meta","payload":{"id":"0199a1b2-c3d4-7e5f-8a9b-0c1d2e3f4a5b","timestamp":"2026-01-20T10:00:00.000Z","cwd":"/home/test/api","originator":"codex_cli_rs","cli_version":"0.40.0","git":{"commit_hash":"abc123","branch":"feature/retry"}}}
+{"timestamp":"2026-01-20T10:00:00.100Z","type":"response_item","payload":{"type":"message","role":"user","content":[{"type":"input_text","text":"<environment_context>\n  <cwd>/home/test/api</cwd>\n</environment_context>"}]}}
+{"timestamp":"2026-01-20T10:00:01.000Z","type":"turn_context","payload":{"cwd":"/home/test/api","approval_policy":"on-request","model":"gpt-5-codex"}}
+{"timestamp":"2026-01-20T10:00:01.000Z","type":"response_item","payload":{"type":"message","role":"user","content":[{"type":"input_text","text":"Why do the tests fail?"}]}}
+{"timestamp":"2026-01-20T10:00:01.000Z","type":"event_msg","payload":{"type":"user_message","message":"Why do the tests fail?"}}
+{"timestamp":"2026-01-20T10:00:05.000Z","type":"response_item","payload":{"type":"reasoning","summary":[{"type":"summary_text","text":"**Running the tests** to see the failure."}],"encrypted_content":"gAAAA"}}
+{"timestamp":"2026-01-20T10:00:06.000Z","type":"response_item","payload":{"type":"function_call","name":"shell","arguments":"{\"command\":[\"bash\",\"-lc\",\"go test ./...\"]}","call_id":"call_1"}}
+{"timestamp":"2026-01-20T10:00:09.000Z","type":"response_item","payload":{"type":"function_call_output","call_id":"call_1","output":"{\"output\":\"--- FAIL: TestRetry\\n\",\"metadata\":{\"exit_code\":1,\"duration_seconds\":2.5}}"}}
+{"timestamp":"2026-01-20T10:00:09.500Z","type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"input_tokens":1200,"cached_input_tokens":800,"output_tokens":150,"reasoning_output_tokens":60,"total_tokens":1350},"last_token_usage":{"input_tokens":1200,"output_tokens":150}}}}
+{"timestamp":"2026-01-20T10:00:12.000Z","type":"response_item","payload":{"type":"custom_tool_call","status":"completed","call_id":"call_2","name":"apply_patch","input":"*** Begin Patch\n*** Update File: retry.go\n*** End Patch"}}
+{"timestamp":"2026-01-20T10:00:12.500Z","type":"response_item","payload":{"type":"custom_tool_call_output","call_id":"call_2","output":"{\"output\":\"Success. Updated the following files:\\nM retry.go\\n\",\"metadata\":{\"exit_code\":0}}"}}
+{"timestamp":"2026-01-20T10:00:15.000Z","type":"response_item","payload":{"type":"message","role":"assistant","content":[{"type":"output_text","text":"The retry loop ignored the deadline; fixed in retry.go."}]}}
+{"timestamp":"2026-01-20T10:00:15.500Z","type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"input_tokens":2600,"output_tokens":320,"total_tokens":2920},"last_token_usage":{"input_tokens":1400,"output_tokens":170}}}}
+`
+
+const codexLegacyRollout = `{"id":"5c1d8b2e-0000-4000-8000-000000000001","timestamp":"2025-05-07T17:24:21.123Z","instructions":null,"git":{"branch":"main"}}
+{"record_type":"state"}
+{"type":"message","role":"user","content":[{"type":"input_text","text":"List the files"}]}
+{"type":"function_call","name":"shell","arguments":"{\"command\":[\"ls\"]}","call_id":"call_a"}
+{"type":"function_call_output","call_id":"call_a","output":{"content":"main.go","success":true}}
+{"type":"message","role":"assistant","content":[{"type":"output_text","text":"There is one file."}]}
+`
+
+func writeRollout(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, "rollout-2026-01-20T10-00-00-0199a1b2.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCodexParser_CanParse(t *testing.T) {
+	parser := NewCodexParser()
+	dir := t.TempDir()
+
+	if !parser.CanParse(writeRollout(t, dir, codexRollout)) {
+		t.Error("should parse a rollout")
+	}
+
+	legacy := t.TempDir()
+	if !parser.CanParse(writeRollout(t, legacy, codexLegacyRollout)) {
+		t.Error("should parse a legacy rollout")
+	}
+
+	other := filepath.Join(dir, "session.jsonl")
+	if err := os.WriteFile(other, []byte(codexRollout), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if parser.CanParse(other) {
+		t.Error("should only parse rollout-*.jsonl files")
+	}
+
+	bad := t.TempDir()
+	if parser.CanParse(writeRollout(t, bad, `{"foo": "bar"}`)) {
+		t.Error("should not parse a rollout without session metadata")
+	}
+}
+
+func TestCodexParser_ParseFile(t *testing.T) {
+	path := writeRollout(t, t.TempDir(), codexRollout)
+
+	sessions, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(sessions))
+	}
+
+	s := sessions[0]
+	if s.ID != "0199a1b2-c3d4-7e5f-8a9b-0c1d2e3f4a5b" || s.Tool != "codex" {
+		t.Errorf("unexpected session: id=%s tool=%s", s.ID, s.Tool)
+	}
+	if s.Project != "api" || s.GitBranch != "feature/retry" || s.Model != "gpt-5-codex" {
+		t.Errorf("unexpected context: project=%s branch=%s model=%s", s.Project, s.GitBranch, s.Model)
+	}
+	if s.TotalTokensIn != 2600 || s.TotalTokensOut != 320 || s.TotalTokens != 2920 {
+		t.Errorf("unexpected tokens: in=%d out=%d total=%d", s.TotalTokensIn, s.TotalTokensOut, s.TotalTokens)
+	}
+	if s.Duration.Seconds() != 15 {
+		t.Errorf("expected duration 15s, got %v", s.Duration)
+	}
+	if !s.HasErrors {
+		t.Error("expected the failed test run to be recorded")
+	}
+
+	// The environment context is skipped
+	if s.TurnCount != 1 || s.FirstUserMsg != "Why do the tests fail?" {
+		t.Errorf("unexpected turns: %d %q", s.TurnCount, s.FirstUserMsg)
+	}
+	if len(s.Messages) != 4 {
+		t.Fatalf("expected 4 messages, got %d", len(s.Messages))
+	}
+
+	work := s.Messages[1]
+	if !strings.Contains(work.Thinking, "Running the tests") || work.Text != "" {
+		t.Errorf("unexpected thinking: %q text %q", work.Thinking, work.Text)
+	}
+	if len(work.ToolUses) != 1 || work.ToolUses[0].Name != "shell" ||
+		!strings.Contains(work.ToolUses[0].Input, "go test") {
+		t.Errorf("unexpected tool uses: %+v", work.ToolUses)
+	}
+	if len(work.ToolResults) != 1 || !work.ToolResults[0].IsError ||
+		work.ToolResults[0].Content != "--- FAIL: TestRetry\n" {
+		t.Errorf("unexpected tool results: %+v", work.ToolResults)
+	}
+	if work.TokensIn != 1200 || work.TokensOut != 150 {
+		t.Errorf("unexpected message tokens: %d/%d", work.TokensIn, work.TokensOut)
+	}
+
+	// A call after a result, and text after a call, start new messages
+	patch := s.Messages[2]
+	if len(patch.ToolUses) != 1 || patch.ToolUses[0].Name != "apply_patch" ||
+		!strings.HasPrefix(patch.ToolUses[0].Input, "*** Begin Patch") {
+		t.Errorf("unexpected patch: %+v", patch.ToolUses)
+	}
+	if len(patch.ToolResults) != 1 || patch.ToolResults[0].IsError {
+		t.Errorf("unexpected patch result: %+v", patch.ToolResults)
+	}
+	if reply := s.Messages[3]; reply.Text != "The retry loop ignored the deadline; fixed in retry.go." {
+		t.Errorf("unexpected reply: %q", reply.Text)
+	}
+}
+
+func TestCodexParser_ParseFile_Legacy(t *testing.T) {
+	path := writeRollout(t, t.TempDir(), codexLegacyRollout)
+
+	sessions, err := NewCodexParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(sessions))
+	}
+
+	s := sessions[0]
+	if s.GitBranch != "main" || len(s.Messages) != 3 {
+		t.Fatalf("unexpected session: branch=%s messages=%d", s.GitBranch, len(s.Messages))
+	}
+	call := s.Messages[1]
+	if len(call.ToolResults) != 1 || call.ToolResults[0].Content != "main.go" {
+		t.Errorf("unexpected tool results: %+v", call.ToolResults)
+	}
+	if s.Messages[2].Text != "There is one file." {
+		t.Errorf("unexpected reply: %q", s.Messages[2].Text)
+	}
+}
+
+func TestFindSessions_Codex(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("CODEX_HOME", "")
+	t.Chdir(t.TempDir())
+
+	dir := filepath.Join(home, ".codex", "sessions", "2026", "01", "20")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeRollout(t, dir, codexRollout)
+
+	sessions, err := FindSessions()
+	if err != nil {
+		t.Fatalf("FindSessions failed: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].Tool != "codex" {
+		t.Fatalf("expected the codex session, got %d sessions", len(sessions))
+	}
+}
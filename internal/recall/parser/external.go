@@ -0,0 +1,193 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// externalParserTimeout bounds the run time of an external parser on
+// one file.
+const externalParserTimeout = 30 * time.Second
+
+// ExternalParser runs a command declared in .contextrc to parse session
+// files of tools ctx has no built-in parser for. Commands are only run
+// with CTX_TRUST_PARSERS=1; see config.ParsersTrusted.
+//
+// The command is run through "sh -c" with the path of the session file
+// as its last argument. It prints either one session or an array of
+// sessions as JSON, in the shape of Session; a non-zero exit status
+// fails the file, with the command's standard error as the reason.
+type ExternalParser struct {
+	tool    string
+	command string
+	glob    string
+}
+
+// NewExternalParser creates a parser from its .contextrc declaration.
+func NewExternalParser(cfg config.ParserConfig) *ExternalParser {
+	return &ExternalParser{tool: cfg.Tool, command: cfg.Command, glob: cfg.Glob}
+}
+
+// Tool returns the tool identifier declared for the parser.
+func (p *ExternalParser) Tool() string {
+	return p.tool
+}
+
+// CanParse returns true if the file name matches the declared glob.
+func (p *ExternalParser) CanParse(path string) bool {
+	ok, err := filepath.Match(p.glob, filepath.Base(path))
+	return err == nil && ok
+}
+
+// ParseFile runs the command on a session file and decodes its output.
+//
+// Sessions are completed the way the built-in parsers fill them: the
+// tool, source file and project are set when missing, and turn counts
+// and previews are derived from the messages.
+func (p *ExternalParser) ParseFile(path string) ([]*Session, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), externalParserTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", p.command+` "$@"`, "sh", path)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s parser: %w: %s", p.tool, err, msg)
+		}
+		return nil, fmt.Errorf("%s parser: %w", p.tool, err)
+	}
+
+	sessions, err := decodeSessions(stdout.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s parser: decode output: %w", p.tool, err)
+	}
+	for i, s := range sessions {
+		if s.ID == "" {
+			return nil, fmt.Errorf("%s parser: session %d has no id", p.tool, i+1)
+		}
+		p.complete(s, path)
+	}
+	return sessions, nil
+}
+
+// ParseLine is not supported by external parsers, which work on whole
+// files; every line is skipped.
+func (p *ExternalParser) ParseLine(line []byte) (*Message, string, error) {
+	return nil, "", nil
+}
+
+// complete fills in the fields of a session the command left out.
+func (p *ExternalParser) complete(s *Session, path string) {
+	if s.Tool == "" {
+		s.Tool = p.tool
+	}
+	if s.SourceFile == "" {
+		s.SourceFile = path
+	}
+	if s.Project == "" && s.CWD != "" {
+		s.Project = filepath.Base(s.CWD)
+	}
+
+	if n := len(s.Messages); n > 0 {
+		if s.StartTime.IsZero() {
+			s.StartTime = s.Messages[0].Timestamp
+		}
+		if s.EndTime.IsZero() {
+			s.EndTime = s.Messages[n-1].Timestamp
+		}
+	}
+	if s.Duration == 0 && !s.EndTime.IsZero() {
+		s.Duration = s.EndTime.Sub(s.StartTime)
+	}
+
+	turns := 0
+	for _, m := range s.Messages {
+		if !m.IsUser() {
+			continue
+		}
+		turns++
+		if s.FirstUserMsg == "" && m.Text != "" {
+			s.FirstUserMsg = m.Preview(100)
+		}
+	}
+	if s.TurnCount == 0 {
+		s.TurnCount = turns
+	}
+	if s.TotalTokens == 0 {
+		s.TotalTokens = s.SumTokens()
+	}
+}
+
+// decodeSessions decodes a JSON session or array of sessions.
+func decodeSessions(data []byte) ([]*Session, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if data[0] == '[' {
+		var sessions []*Session
+		if err := json.Unmarshal(data, &sessions); err != nil {
+			return nil, err
+		}
+		return sessions, nil
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return []*Session{&s}, nil
+}
+
+// externalParsers returns the parsers declared in .contextrc. Entries
+// without a tool, command or glob are ignored.
+func externalParsers() []SessionParser {
+	var parsers []SessionParser
+	for _, cfg := range config.GetParsers() {
+		if cfg.Tool == "" || cfg.Command == "" || cfg.Glob == "" {
+			continue
+		}
+		parsers = append(parsers, NewExternalParser(cfg))
+	}
+	return parsers
+}
+
+// externalDirs returns the session directories declared for external
+// parsers, with a leading "~" expanded to the home directory.
+func externalDirs() []string {
+	var dirs []string
+	for _, cfg := range config.GetParsers() {
+		dir := cfg.Dir
+		if dir == "" {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(dir, "~"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				continue
+			}
+			dir = filepath.Join(home, rest)
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// Ensure ExternalParser implements SessionParser
+var _ SessionParser = (*ExternalParser)(nil)
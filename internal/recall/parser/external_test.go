@@ -0,0 +1,192 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// setupExternalParser declares an "acme" parser that prints the file it
+// is given, and fails on files containing "broken".
+func setupExternalParser(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("CTX_TRUST_PARSERS", "1")
+
+	script := filepath.Join(dir, "acme-parse")
+	content := "#!/bin/sh\n" +
+		"if grep -q broken \"$1\"; then echo 'cannot read log' >&2; exit 3; fi\n" +
+		"cat \"$1\"\n"
+	if err := os.WriteFile(script, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions := filepath.Join(dir, "sessions")
+	if err := os.Mkdir(sessions, 0755); err != nil {
+		t.Fatal(err)
+	}
+	rc := "parsers:\n" +
+		"  - tool: acme\n" +
+		"    command: " + script + "\n" +
+		"    glob: \"*.acme.json\"\n" +
+		"    dir: " + sessions + "\n"
+	if err := os.WriteFile(filepath.Join(dir, ".contextrc"), []byte(rc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config.ResetRC()
+	t.Cleanup(config.ResetRC)
+
+	return sessions
+}
+
+const acmeSession = `{
+  "id": "acme-1",
+  "cwd": "/home/test/billing",
+  "messages": [
+    {"role": "user", "text": "Fix the invoice rounding", "timestamp": "2026-01-20T10:00:00Z"},
+    {"role": "assistant", "text": "Rounded half to even.", "timestamp": "2026-01-20T10:03:00Z"}
+  ]
+}`
+
+func TestExternalParser(t *testing.T) {
+	dir := setupExternalParser(t)
+
+	if !slices.Contains(RegisteredTools(), "acme") {
+		t.Errorf("expected 'acme' in registered tools, got %v", RegisteredTools())
+	}
+	if p := GetParser("acme"); p == nil || p.Tool() != "acme" {
+		t.Error("expected a parser for 'acme'")
+	}
+
+	path := filepath.Join(dir, "one.acme.json")
+	if err := os.WriteFile(path, []byte(acmeSession), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sessions, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(sessions))
+	}
+
+	// Missing fields are derived
+	s := sessions[0]
+	if s.Tool != "acme" || s.SourceFile != path || s.Project != "billing" {
+		t.Errorf("unexpected session: tool=%s source=%s project=%s", s.Tool, s.SourceFile, s.Project)
+	}
+	if s.TurnCount != 1 || s.FirstUserMsg != "Fix the invoice rounding" || s.Duration.Minutes() != 3 {
+		t.Errorf("unexpected derived fields: turns=%d first=%q duration=%v",
+			s.TurnCount, s.FirstUserMsg, s.Duration)
+	}
+
+	// Sessions in the declared directory are found
+	found, err := FindSessions()
+	if err != nil {
+		t.Fatalf("FindSessions failed: %v", err)
+	}
+	if len(found) != 1 || found[0].ID != "acme-1" {
+		t.Errorf("expected the acme session to be found, got %d sessions", len(found))
+	}
+}
+
+func TestExternalParser_Untrusted(t *testing.T) {
+	dir := setupExternalParser(t)
+	t.Setenv("CTX_TRUST_PARSERS", "")
+
+	path := filepath.Join(dir, "one.acme.json")
+	if err := os.WriteFile(path, []byte(acmeSession), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if slices.Contains(RegisteredTools(), "acme") {
+		t.Error("parser from .contextrc registered without CTX_TRUST_PARSERS")
+	}
+	if _, err := ParseFile(path); err == nil {
+		t.Error("ParseFile ran the parser from .contextrc without CTX_TRUST_PARSERS")
+	}
+}
+
+func TestExternalParser_Errors(t *testing.T) {
+	dir := setupExternalParser(t)
+
+	files := map[string]string{
+		"good.acme.json":    "[" + acmeSession + "]",
+		"bad.acme.json":     "broken",
+		"noid.acme.json":    `{"messages": []}`,
+		"other.json":        "broken",
+		"garbled.acme.json": "not json",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sessions, errs, err := ScanDirectoryWithErrors(dir)
+	if err != nil {
+		t.Fatalf("ScanDirectoryWithErrors failed: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Errorf("expected 1 session, got %d", len(sessions))
+	}
+	if len(errs) != 3 {
+		t.Fatalf("expected 3 errors, got %v", errs)
+	}
+
+	var messages []string
+	for _, e := range errs {
+		messages = append(messages, e.Error())
+	}
+	all := strings.Join(messages, "\n")
+	for _, want := range []string{"cannot read log", "has no id", "decode output"} {
+		if !strings.Contains(all, want) {
+			t.Errorf("errors missing %q:\n%s", want, all)
+		}
+	}
+}
+
+func TestFindSessionsWithErrors(t *testing.T) {
+	dir := setupExternalParser(t)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	path := filepath.Join(dir, "one.acme.json")
+	if err := os.WriteFile(path, []byte("broken"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Failed parses are reported on every scan, not cached as empty
+	for range 2 {
+		sessions, errs := FindSessionsWithErrors()
+		if len(sessions) != 0 || len(errs) != 1 || !strings.Contains(errs[0].Error(), path) {
+			t.Fatalf("expected one error naming %s, got %d sessions and %v", path, len(sessions), errs)
+		}
+	}
+
+	// The fixed file is parsed even with its old modification time
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(acmeSession), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	sessions, errs := FindSessionsWithErrors()
+	if len(sessions) != 1 || len(errs) != 0 {
+		t.Errorf("after the fix: %d sessions, errors %v", len(sessions), errs)
+	}
+}
@@ -0,0 +1,167 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// fileTools are the tools whose input names a file in file_path or
+// notebook_path.
+var fileTools = map[string]bool{
+	"Read": true, "Write": true, "Edit": true, "MultiEdit": true,
+	"NotebookEdit": true,
+}
+
+// ShellTools are the tools that run shell commands: Claude Code's Bash
+// and Codex's shell.
+var ShellTools = map[string]bool{"Bash": true, "shell": true}
+
+// patchFilePattern matches the file headers of apply_patch input.
+var patchFilePattern = regexp.MustCompile(`(?m)^\*\*\* (?:Add|Update|Delete) File: (.+)$`)
+
+// shellPathPattern matches shell words that look like file paths: with
+// a directory or an extension, and without globs, variables or URLs.
+var shellPathPattern = regexp.MustCompile(`^(?:[\w.~-]*/)*[\w.-]*\w\.[A-Za-z0-9]+$|^(?:[\w.~-]+/)+[\w.-]+$`)
+
+// FilePaths returns the paths of the files a tool call reads, changes or
+// names in a shell command, as given in its input. Paths in shell
+// commands are found by their shape, relative to the directory of a
+// leading "cd".
+func (t ToolUse) FilePaths() []string {
+	switch {
+	case fileTools[t.Name]:
+		var input struct {
+			FilePath     string `json:"file_path"`
+			NotebookPath string `json:"notebook_path"`
+		}
+		if json.Unmarshal([]byte(t.Input), &input) != nil {
+			return nil
+		}
+		if input.FilePath != "" {
+			return []string{input.FilePath}
+		}
+		if input.NotebookPath != "" {
+			return []string{input.NotebookPath}
+		}
+	case t.Name == "apply_patch":
+		var paths []string
+		for _, m := range patchFilePattern.FindAllStringSubmatch(t.Patch(), -1) {
+			paths = append(paths, strings.TrimSpace(m[1]))
+		}
+		return paths
+	case ShellTools[t.Name]:
+		return shellPaths(t.Command())
+	}
+	return nil
+}
+
+// Command returns the command line of a shell tool call: the "command"
+// string of Claude Code's Bash tool, or the script of Codex's
+// ["bash", "-lc", script] argument vector.
+func (t ToolUse) Command() string {
+	var str struct {
+		Command string `json:"command"`
+	}
+	if json.Unmarshal([]byte(t.Input), &str) == nil && str.Command != "" {
+		return str.Command
+	}
+	var argv struct {
+		Command []string `json:"command"`
+	}
+	if json.Unmarshal([]byte(t.Input), &argv) != nil || len(argv.Command) == 0 {
+		return ""
+	}
+	if n := len(argv.Command); n >= 3 && (argv.Command[n-2] == "-lc" || argv.Command[n-2] == "-c") {
+		return argv.Command[n-1]
+	}
+	return strings.Join(argv.Command, " ")
+}
+
+// Patch returns the patch of an apply_patch call, sent either as the
+// patch itself or as a function call's "input" argument.
+func (t ToolUse) Patch() string {
+	var args struct {
+		Input string `json:"input"`
+	}
+	if json.Unmarshal([]byte(t.Input), &args) == nil && args.Input != "" {
+		return args.Input
+	}
+	return t.Input
+}
+
+// shellPaths returns the words of a command line that look like file
+// paths. Paths after "cd dir" are joined to dir; an absolute dir
+// replaces the directory of earlier "cd"s.
+func shellPaths(line string) []string {
+	var paths []string
+	dir := ""
+	for _, part := range strings.FieldsFunc(line, func(r rune) bool {
+		return r == ';' || r == '&' || r == '|' || r == '\n'
+	}) {
+		words := strings.Fields(part)
+		if len(words) == 2 && words[0] == "cd" {
+			target := strings.Trim(words[1], `"'`)
+			if filepath.IsAbs(target) || strings.HasPrefix(target, "~") {
+				dir = target
+			} else {
+				dir = filepath.Join(dir, target)
+			}
+			continue
+		}
+		for i, w := range words {
+			w = strings.Trim(w, `"'`)
+			if i == 0 || strings.HasPrefix(w, "-") || strings.Contains(w, "...") ||
+				!shellPathPattern.MatchString(w) {
+				continue
+			}
+			if _, err := strconv.ParseFloat(w, 64); err == nil {
+				continue
+			}
+			if dir != "" && !filepath.IsAbs(w) && !strings.HasPrefix(w, "~") {
+				w = filepath.Join(dir, w)
+			}
+			paths = append(paths, w)
+		}
+	}
+	return paths
+}
+
+// ResolvePath returns the absolute, clean form of a path from a tool
+// call, resolving relative paths against the session's working
+// directory. Relative paths stay relative if the directory is unknown.
+func ResolvePath(cwd, path string) string {
+	if !filepath.IsAbs(path) && cwd != "" {
+		path = filepath.Join(cwd, path)
+	}
+	return filepath.Clean(path)
+}
+
+// touchedFiles returns the absolute paths of the files named by a
+// session's tool calls, sorted.
+func touchedFiles(s *Session) []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, m := range s.AllMessages() {
+		for _, t := range m.ToolUses {
+			for _, p := range t.FilePaths() {
+				p = ResolvePath(s.CWD, p)
+				if !seen[p] {
+					seen[p] = true
+					files = append(files, p)
+				}
+			}
+		}
+	}
+	slices.Sort(files)
+	return files
+}
@@ -0,0 +1,70 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestToolUseFilePaths(t *testing.T) {
+	tests := []struct {
+		name string
+		tool ToolUse
+		want []string
+	}{
+		{"read", ToolUse{Name: "Read", Input: `{"file_path":"/src/a.go"}`}, []string{"/src/a.go"}},
+		{"notebook", ToolUse{Name: "NotebookEdit", Input: `{"notebook_path":"n.ipynb"}`}, []string{"n.ipynb"}},
+		{"patch", ToolUse{Name: "apply_patch", Input: `{"input":"*** Begin Patch\n*** Update File: a.go\n*** Add File: b/c.go\n*** End Patch"}`},
+			[]string{"a.go", "b/c.go"}},
+		{"bash", ToolUse{Name: "Bash", Input: `{"command":"cd api && go test ./... -run X 2>&1 | tail -20; cat retry.go README"}`},
+			[]string{"api/retry.go"}},
+		{"absolute cd", ToolUse{Name: "Bash", Input: `{"command":"cd /src/api && go test; cd /src/web && cat main.go"}`},
+			[]string{"/src/web/main.go"}},
+		{"shell", ToolUse{Name: "shell", Input: `{"command":["bash","-lc","sed -n 1,20p internal/x/y.go"]}`},
+			[]string{"internal/x/y.go"}},
+		{"numbers", ToolUse{Name: "Bash", Input: `{"command":"sleep 1.5 && tail /tmp/x.log"}`}, []string{"/tmp/x.log"}},
+		{"other", ToolUse{Name: "Task", Input: `{"file_path":"a.go"}`}, nil},
+	}
+	for _, tt := range tests {
+		if got := tt.tool.FilePaths(); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: FilePaths() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestResolvePath(t *testing.T) {
+	tests := []struct {
+		cwd, path, want string
+	}{
+		{"/src/ctx", "internal/a.go", "/src/ctx/internal/a.go"},
+		{"/src/ctx", "/etc/../tmp/a.go", "/tmp/a.go"},
+		{"", "./a.go", "a.go"},
+	}
+	for _, tt := range tests {
+		if got := ResolvePath(tt.cwd, tt.path); got != tt.want {
+			t.Errorf("ResolvePath(%q, %q) = %q, want %q", tt.cwd, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestTouchedFiles(t *testing.T) {
+	s := &Session{
+		CWD: "/src/ctx",
+		Messages: []Message{{Role: "assistant", ToolUses: []ToolUse{
+			{Name: "Edit", Input: `{"file_path":"b.go"}`},
+			{Name: "Task", Sidechain: []Message{{Role: "assistant", ToolUses: []ToolUse{
+				{Name: "Read", Input: `{"file_path":"/src/ctx/a.go"}`},
+			}}}},
+			{Name: "Read", Input: `{"file_path":"./b.go"}`},
+		}}},
+	}
+	want := []string{"/src/ctx/a.go", "/src/ctx/b.go"}
+	if got := touchedFiles(s); !slices.Equal(got, want) {
+		t.Errorf("touchedFiles() = %q, want %q", got, want)
+	}
+}
@@ -7,74 +7,87 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strings"
 )
 
-// registeredParsers holds all available session parsers.
+// registeredParsers holds all built-in session parsers.
 // Add new parsers here when supporting additional tools.
 var registeredParsers = []SessionParser{
 	NewClaudeCodeParser(),
+	NewAiderParser(),
+	NewCodexParser(),
 }
 
+// parsers returns the built-in parsers followed by the external parsers
+// declared in .contextrc, in the order they are tried.
+func parsers() []SessionParser {
+	return slices.Concat(registeredParsers, externalParsers())
+}
+
+// ErrNoParser is returned by ParseFile for files no parser accepts.
+var ErrNoParser = errors.New("no parser found for file")
+
 // ParseFile parses a session file using the appropriate parser.
 //
 // It auto-detects the file format by trying each registered parser.
-// Returns an error if no parser can handle the file.
+// Returns an error wrapping ErrNoParser if no parser can handle the file.
 func ParseFile(path string) ([]*Session, error) {
-	for _, parser := range registeredParsers {
+	for _, parser := range parsers() {
 		if parser.CanParse(path) {
-			return parser.ParseFile(path)
+			return parseWith(parser, path)
 		}
 	}
-	return nil, fmt.Errorf("no parser found for file: %s", path)
+	return nil, fmt.Errorf("%w: %s", ErrNoParser, path)
 }
 
-// ScanDirectory recursively scans a directory for session files.
-//
-// It finds all parseable files, parses them, and aggregates sessions.
-// Sessions are sorted by start time (newest first).
-func ScanDirectory(dir string) ([]*Session, error) {
-	var allSessions []*Session
-	var parseErrors []error
+// contextPacketHeader opens the output of "ctx agent".
+const contextPacketHeader = "# Context Packet\n"
 
-	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
+// parseWith parses a file with a parser and derives the session fields
+// that do not depend on the tool.
+func parseWith(p SessionParser, path string) ([]*Session, error) {
+	sessions, err := p.ParseFile(path)
+	for _, s := range sessions {
+		s.ContextPacket = readsContextPacket(s)
+		s.Files = touchedFiles(s)
+	}
+	return sessions, err
+}
 
-		if info.IsDir() {
-			return nil
+// readsContextPacket reports whether a session ran "ctx agent" or was
+// given its output.
+func readsContextPacket(s *Session) bool {
+	for _, msg := range s.Messages {
+		if strings.Contains(msg.Text, contextPacketHeader) {
+			return true
 		}
-
-		// Try to parse with any registered parser
-		for _, parser := range registeredParsers {
-			if parser.CanParse(path) {
-				sessions, err := parser.ParseFile(path)
-				if err != nil {
-					parseErrors = append(parseErrors, fmt.Errorf("%s: %w", path, err))
-					break
-				}
-				allSessions = append(allSessions, sessions...)
-				break
+		for _, t := range msg.ToolUses {
+			if strings.Contains(t.Input, "ctx agent") {
+				return true
+			}
+		}
+		for _, tr := range msg.ToolResults {
+			if strings.Contains(tr.Content, contextPacketHeader) {
+				return true
 			}
 		}
-
-		return nil
-	})
-
-	if err != nil {
-		return nil, fmt.Errorf("walk directory: %w", err)
 	}
+	return false
+}
 
-	// Sort by start time (newest first)
-	sort.Slice(allSessions, func(i, j int) bool {
-		return allSessions[i].StartTime.After(allSessions[j].StartTime)
-	})
-
-	return allSessions, nil
+// ScanDirectory recursively scans a directory for session files.
+//
+// It finds all parseable files, parses them, and aggregates sessions.
+// Sessions are sorted by start time (newest first).
+func ScanDirectory(dir string) ([]*Session, error) {
+	sessions, _, err := ScanDirectoryWithErrors(dir)
+	return sessions, err
 }
 
 // ScanDirectoryWithErrors is like ScanDirectory but also returns parse errors.
@@ -84,6 +97,7 @@ func ScanDirectory(dir string) ([]*Session, error) {
 func ScanDirectoryWithErrors(dir string) ([]*Session, []error, error) {
 	var allSessions []*Session
 	var parseErrors []error
+	available := parsers()
 
 	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -95,9 +109,9 @@ func ScanDirectoryWithErrors(dir string) ([]*Session, []error, error) {
 		}
 
 		// Try to parse with any registered parser
-		for _, parser := range registeredParsers {
+		for _, parser := range available {
 			if parser.CanParse(path) {
-				sessions, err := parser.ParseFile(path)
+				sessions, err := parseWith(parser, path)
 				if err != nil {
 					parseErrors = append(parseErrors, fmt.Errorf("%s: %w", path, err))
 					break
@@ -125,30 +139,56 @@ func ScanDirectoryWithErrors(dir string) ([]*Session, []error, error) {
 // FindSessions searches for session files in common locations.
 //
 // It checks:
-//  1. ~/.claude/projects/ (Claude Code default)
+//  1. The default session directories (see DefaultDirs)
 //  2. The specified directory (if provided)
+//  3. Aider histories in project roots: the repository of the current
+//     directory and the working directories of the sessions found
+//
+// Files are read through the parse cache in the user cache directory, so
+// only new and changed files are parsed; sessions read from the cache
+// load their messages on demand (see Session.Load).
 //
-// Returns all found sessions sorted by start time.
+// Returns all found sessions sorted by start time. Files that fail to
+// parse are skipped; use FindSessionsWithErrors to report them. Missing
+// directories are skipped too, so the error is always nil.
 func FindSessions(additionalDirs ...string) ([]*Session, error) {
-	var allSessions []*Session
+	sessions, _ := FindSessionsWithErrors(additionalDirs...)
+	return sessions, nil
+}
 
-	// Check Claude Code default location
-	home, err := os.UserHomeDir()
-	if err == nil {
-		claudeDir := filepath.Join(home, ".claude", "projects")
-		if info, err := os.Stat(claudeDir); err == nil && info.IsDir() {
-			sessions, _ := ScanDirectory(claudeDir)
-			allSessions = append(allSessions, sessions...)
-		}
-	}
+// FindSessionsWithErrors is like FindSessions but also returns the parse
+// errors of the files that failed to parse, each naming its file.
+//
+// Use this when you want to report files that failed to parse while still
+// returning successfully parsed sessions.
+func FindSessionsWithErrors(additionalDirs ...string) ([]*Session, []error) {
+	cache := openDefaultCache()
+	sessions, stats := cache.FindSessions(additionalDirs...)
+	cache.Prune()
+	// A cache that cannot be written only costs parsing again next time
+	_ = cache.Save()
+	return sessions, stats.Errors
+}
 
-	// Check additional directories
-	for _, dir := range additionalDirs {
-		if info, err := os.Stat(dir); err == nil && info.IsDir() {
-			sessions, _ := ScanDirectory(dir)
-			allSessions = append(allSessions, sessions...)
+// FindSessions is like the package-level FindSessions, reading files
+// through the cache. It does not save the cache.
+func (c *Cache) FindSessions(additionalDirs ...string) ([]*Session, CacheStats) {
+	// Check default and additional directories
+	allSessions, stats := c.ScanDirectories(append(DefaultDirs(), additionalDirs...)...)
+
+	// Check Aider histories, which live in the project itself
+	var histories []string
+	for _, root := range projectRoots(allSessions) {
+		for _, name := range []string{aiderChatHistory, aiderInputHistory} {
+			path := filepath.Join(root, name)
+			if _, err := os.Stat(path); err == nil && NewAiderParser().CanParse(path) {
+				histories = append(histories, path)
+			}
 		}
 	}
+	sessions, more := c.ParseFiles(histories)
+	allSessions = append(allSessions, sessions...)
+	stats.add(more)
 
 	// Deduplicate by session ID
 	seen := make(map[string]bool)
@@ -165,14 +205,66 @@ func FindSessions(additionalDirs ...string) ([]*Session, error) {
 		return unique[i].StartTime.After(unique[j].StartTime)
 	})
 
-	return unique, nil
+	stats.Sessions = len(unique)
+	return unique, stats
+}
+
+// DefaultDirs returns the directories where supported tools keep their
+// sessions: ~/.claude/projects for Claude Code, $CODEX_HOME/sessions,
+// by default ~/.codex/sessions, for Codex CLI, and the directories
+// declared for external parsers in .contextrc.
+func DefaultDirs() []string {
+	var dirs []string
+	home, err := os.UserHomeDir()
+	if err == nil {
+		dirs = append(dirs, filepath.Join(home, ".claude", "projects"))
+	}
+	if codexHome := os.Getenv("CODEX_HOME"); codexHome != "" {
+		dirs = append(dirs, filepath.Join(codexHome, "sessions"))
+	} else if err == nil {
+		dirs = append(dirs, filepath.Join(home, ".codex", "sessions"))
+	}
+	return append(dirs, externalDirs()...)
+}
+
+// projectRoots returns the directories that may hold project-local
+// session histories: the repository root of the current directory, or
+// the directory itself outside a repository, followed by the working
+// directories of the given sessions.
+func projectRoots(sessions []*Session) []string {
+	var roots []string
+	seen := make(map[string]bool)
+	add := func(dir string) {
+		if dir != "" && !seen[dir] {
+			seen[dir] = true
+			roots = append(roots, dir)
+		}
+	}
+
+	if cwd, err := os.Getwd(); err == nil {
+		root := cwd
+		for dir := cwd; ; dir = filepath.Dir(dir) {
+			if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+				root = dir
+				break
+			}
+			if filepath.Dir(dir) == dir {
+				break
+			}
+		}
+		add(root)
+	}
+	for _, s := range sessions {
+		add(s.CWD)
+	}
+	return roots
 }
 
 // GetParser returns a parser for the specified tool.
 //
 // Returns nil if no parser is registered for the tool.
 func GetParser(tool string) SessionParser {
-	for _, parser := range registeredParsers {
+	for _, parser := range parsers() {
 		if parser.Tool() == tool {
 			return parser
 		}
@@ -180,11 +272,12 @@ func GetParser(tool string) SessionParser {
 	return nil
 }
 
-// RegisteredTools returns the list of supported tools.
+// RegisteredTools returns the list of supported tools, external parsers
+// included.
 func RegisteredTools() []string {
-	tools := make([]string, len(registeredParsers))
-	for i, parser := range registeredParsers {
-		tools[i] = parser.Tool()
+	var tools []string
+	for _, parser := range parsers() {
+		tools = append(tools, parser.Tool())
 	}
 	return tools
 }
@@ -7,6 +7,7 @@
 package parser
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -219,6 +220,60 @@ func TestClaudeCodeParser_ParseFile_MultipleSessions(t *testing.T) {
 	}
 }
 
+func TestClaudeCodeParser_ParseFile_WithoutSessionID(t *testing.T) {
+	parser := NewClaudeCodeParser()
+	dir := t.TempDir()
+
+	// Hand-written transcript: no session IDs or UUIDs
+	jsonlFile := filepath.Join(dir, "plain.jsonl")
+	content := `{"type":"user","message":{"role":"user","content":"Hello"},"timestamp":"2025-01-21T10:00:00Z"}
+{"type":"assistant","message":{"role":"assistant","content":"Hi there!"},"timestamp":"2025-01-21T10:00:05Z"}`
+	if err := os.WriteFile(jsonlFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := parser.ParseFile(jsonlFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	if len(sessions) != 1 || len(sessions[0].Messages) != 2 {
+		t.Fatalf("expected 1 session with 2 messages, got %+v", sessions)
+	}
+	if sessions[0].Messages[1].Text != "Hi there!" {
+		t.Errorf("second message = %q, want the reply", sessions[0].Messages[1].Text)
+	}
+	if sessions[0].ID == "" || sessions[0].Project != "" {
+		t.Errorf("ID, Project = %q, %q, want an ID and no project", sessions[0].ID, sessions[0].Project)
+	}
+
+	// The ID is stable for a file and differs between files
+	other := filepath.Join(dir, "other.jsonl")
+	if err := os.WriteFile(other, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	again, _ := parser.ParseFile(jsonlFile)
+	others, _ := parser.ParseFile(other)
+	if len(again) != 1 || again[0].ID != sessions[0].ID {
+		t.Errorf("ID changed between parses: %+v", again)
+	}
+	if len(others) != 1 || others[0].ID == sessions[0].ID {
+		t.Errorf("two files share the ID %q", sessions[0].ID)
+	}
+
+	// Lines without ID are dropped next to sessions with one
+	content += "\n" + `{"uuid":"a1","sessionId":"sess-A","slug":"a","type":"user","timestamp":"2026-01-20T09:00:00Z","message":{"role":"user","content":"Hello A"}}`
+	if err := os.WriteFile(jsonlFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sessions, err = parser.ParseFile(jsonlFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != "sess-A" || len(sessions[0].Messages) != 1 {
+		t.Errorf("expected only session sess-A, got %+v", sessions)
+	}
+}
+
 func TestClaudeCodeParser_ParseFile_SkipsMalformed(t *testing.T) {
 	parser := NewClaudeCodeParser()
 	dir := t.TempDir()
@@ -393,3 +448,113 @@ func TestDebugSession(t *testing.T) {
 		}
 	}
 }
+
+func TestClaudeCodeParser_ParseFile_CacheTokensAndContextPacket(t *testing.T) {
+	dir := t.TempDir()
+	jsonlFile := filepath.Join(dir, "session.jsonl")
+	content := `{"uuid":"u1","sessionId":"sess-1","slug":"cached-session","type":"user","timestamp":"2026-01-20T10:00:00Z","cwd":"/home/test/project","version":"2.1.0","message":{"role":"user","content":"Load the context"}}
+{"uuid":"a1","parentUuid":"u1","sessionId":"sess-1","type":"assistant","timestamp":"2026-01-20T10:00:05Z","cwd":"/home/test/project","message":{"model":"claude-sonnet-4-5","role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Bash","input":{"command":"ctx agent --budget 4000"}}],"usage":{"input_tokens":10,"output_tokens":20,"cache_creation_input_tokens":3000,"cache_read_input_tokens":12000}}}`
+	if err := os.WriteFile(jsonlFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := ParseFile(jsonlFile)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("got %d sessions, want 1", len(sessions))
+	}
+	s := sessions[0]
+	if s.TotalTokensCacheWrite != 3000 || s.TotalTokensCacheRead != 12000 {
+		t.Errorf("cache tokens = %d/%d, want 3000/12000", s.TotalTokensCacheWrite, s.TotalTokensCacheRead)
+	}
+	if s.TotalTokens != 15030 {
+		t.Errorf("TotalTokens = %d, want 15030", s.TotalTokens)
+	}
+	if !s.ContextPacket {
+		t.Error("session running ctx agent should have ContextPacket set")
+	}
+}
+
+func TestClaudeCodeParser_ParseFile_UsagePerResponse(t *testing.T) {
+	dir := t.TempDir()
+	jsonlFile := filepath.Join(dir, "session.jsonl")
+	// One response split into three lines, one per content block, each
+	// repeating the usage; the last has the final output count
+	line := `{"uuid":"%s","sessionId":"sess-1","slug":"usage","type":"assistant","requestId":"req-%s","timestamp":"2026-01-20T10:00:0%dZ","cwd":"/src","message":{"id":"msg-%s","model":"claude-sonnet-4-5","role":"assistant","content":[{"type":"text","text":"part"}],"usage":{"input_tokens":10,"output_tokens":%d,"cache_creation_input_tokens":100,"cache_read_input_tokens":1000}}}` + "\n"
+	var b strings.Builder
+	b.WriteString(`{"uuid":"u1","sessionId":"sess-1","slug":"usage","type":"user","timestamp":"2026-01-20T10:00:00Z","cwd":"/src","message":{"role":"user","content":"Go"}}` + "\n")
+	b.WriteString(fmt.Sprintf(line, "a1", "1", 1, "1", 5))
+	b.WriteString(fmt.Sprintf(line, "a2", "1", 2, "1", 5))
+	b.WriteString(fmt.Sprintf(line, "a3", "1", 3, "1", 20))
+	b.WriteString(fmt.Sprintf(line, "a4", "2", 4, "2", 7))
+	if err := os.WriteFile(jsonlFile, []byte(b.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := ParseFile(jsonlFile)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("got %d sessions, want 1", len(sessions))
+	}
+	s := sessions[0]
+	if s.TotalTokensIn != 20 || s.TotalTokensOut != 27 ||
+		s.TotalTokensCacheWrite != 200 || s.TotalTokensCacheRead != 2000 {
+		t.Errorf("tokens in/out/cache write/cache read = %d/%d/%d/%d, want 20/27/200/2000",
+			s.TotalTokensIn, s.TotalTokensOut, s.TotalTokensCacheWrite, s.TotalTokensCacheRead)
+	}
+}
+
+func TestClaudeCodeParser_ParseFile_ToolResultFiles(t *testing.T) {
+	dir := t.TempDir()
+	jsonlFile := filepath.Join(dir, "session.jsonl")
+	line := `{"uuid":"%s","sessionId":"sess-1","slug":"files","type":"%s","timestamp":"2026-01-20T10:00:0%dZ","cwd":"/src","version":"2.1.0","message":{"role":"%s","content":%s}%s}` + "\n"
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(line, "a1", "assistant", 1, "assistant", `[{"type":"tool_use","id":"t1","name":"Read","input":{"file_path":"/src/a.go"}},{"type":"tool_use","id":"t2","name":"Read","input":{"file_path":"/src/b.go","offset":10}}]`, ""))
+	b.WriteString(fmt.Sprintf(line, "u1", "user", 2, "user", `[{"type":"tool_result","tool_use_id":"t1","content":"     1→package a"}]`,
+		`,"toolUseResult":{"type":"text","file":{"filePath":"/src/a.go","content":"package a\n","numLines":1,"startLine":1,"totalLines":1}}`))
+	b.WriteString(fmt.Sprintf(line, "u2", "user", 3, "user", `[{"type":"tool_result","tool_use_id":"t2","content":"    10→}"}]`,
+		`,"toolUseResult":{"type":"text","file":{"filePath":"/src/b.go","content":"}\n","numLines":1,"startLine":10,"totalLines":10}}`))
+	b.WriteString(fmt.Sprintf(line, "a2", "assistant", 4, "assistant", `[{"type":"tool_use","id":"t3","name":"Edit","input":{"file_path":"/src/a.go","old_string":"a","new_string":"b"}}]`, ""))
+	b.WriteString(fmt.Sprintf(line, "u3", "user", 5, "user", `[{"type":"tool_result","tool_use_id":"t3","content":"updated"}]`,
+		`,"toolUseResult":{"filePath":"/src/a.go","oldString":"a","newString":"b","originalFile":"package a\n"}`))
+	b.WriteString(fmt.Sprintf(line, "u4", "user", 6, "user", `[{"type":"tool_result","tool_use_id":"t4","content":"Error","is_error":true}]`,
+		`,"toolUseResult":"Error: file not found"`))
+	b.WriteString(fmt.Sprintf(line, "u5", "user", 7, "user", `[{"type":"tool_result","tool_use_id":"t5","content":"created"}]`,
+		`,"toolUseResult":{"type":"create","filePath":"/src/c.go","content":"package c\n"}`))
+	if err := os.WriteFile(jsonlFile, []byte(b.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := ParseFile(jsonlFile)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("got %d sessions, want 1", len(sessions))
+	}
+	files := make(map[string]*string)
+	for _, m := range sessions[0].Messages {
+		for _, tr := range m.ToolResults {
+			files[tr.ToolUseID] = tr.File
+		}
+	}
+	if f := files["t1"]; f == nil || *f != "package a\n" {
+		t.Errorf("file of whole read = %v, want the content", f)
+	}
+	if f := files["t2"]; f != nil {
+		t.Errorf("file of partial read = %q, want none", *f)
+	}
+	if f := files["t3"]; f == nil || *f != "package a\n" {
+		t.Errorf("file of edit = %v, want the original", f)
+	}
+	if f := files["t4"]; f != nil {
+		t.Errorf("file of error = %q, want none", *f)
+	}
+	if f := files["t5"]; f == nil || *f != "" {
+		t.Errorf("file of create = %v, want empty", f)
+	}
+}
@@ -42,15 +42,35 @@ type Session struct {
 	Messages  []Message `json:"messages"`
 	TurnCount int       `json:"turn_count"` // Count of user messages
 
-	// Token Statistics (if available)
-	TotalTokensIn  int `json:"total_tokens_in,omitempty"`
-	TotalTokensOut int `json:"total_tokens_out,omitempty"`
-	TotalTokens    int `json:"total_tokens,omitempty"`
+	// Conversation tree (if the tool records one). Messages hold the main
+	// branch; Branches hold the parts left behind by rewound or edited
+	// prompts, and Sidechains the subagent conversations that could not
+	// be attached to the tool call that spawned them.
+	Branches   []Branch `json:"branches,omitempty"`
+	Sidechains []Branch `json:"sidechains,omitempty"`
+
+	// Token Statistics (if available). Input tokens exclude the tokens
+	// written to and read from the prompt cache, which are counted apart;
+	// TotalTokens is the sum of all four.
+	TotalTokensIn         int `json:"total_tokens_in,omitempty"`
+	TotalTokensOut        int `json:"total_tokens_out,omitempty"`
+	TotalTokensCacheWrite int `json:"total_tokens_cache_write,omitempty"`
+	TotalTokensCacheRead  int `json:"total_tokens_cache_read,omitempty"`
+	TotalTokens           int `json:"total_tokens,omitempty"`
 
 	// Derived
 	HasErrors    bool   `json:"has_errors,omitempty"`
 	FirstUserMsg string `json:"first_user_msg,omitempty"` // Preview text (truncated)
 	Model        string `json:"model,omitempty"`          // Primary model used
+	// ContextPacket is set if the session read the output of "ctx agent"
+	ContextPacket bool `json:"context_packet,omitempty"`
+	// Files are the absolute paths of the files the session's tool calls
+	// read, changed or named in shell commands, sorted
+	Files []string `json:"files,omitempty"`
+
+	// stub is set on sessions listed from the parse cache, whose
+	// messages are read by Load
+	stub bool
 }
 
 // Message represents a single message in a session.
@@ -58,6 +78,7 @@ type Session struct {
 // This is tool-agnostic - all parsers normalize to this format.
 type Message struct {
 	ID        string    `json:"id"`
+	ParentID  string    `json:"parent_id,omitempty"`
 	Timestamp time.Time `json:"timestamp"`
 	Role      string    `json:"role"` // "user" or "assistant"
 
@@ -68,8 +89,10 @@ type Message struct {
 	ToolResults []ToolResult `json:"tool_results,omitempty"`
 
 	// Token usage (if available)
-	TokensIn  int `json:"tokens_in,omitempty"`
-	TokensOut int `json:"tokens_out,omitempty"`
+	TokensIn         int `json:"tokens_in,omitempty"`
+	TokensOut        int `json:"tokens_out,omitempty"`
+	TokensCacheWrite int `json:"tokens_cache_write,omitempty"`
+	TokensCacheRead  int `json:"tokens_cache_read,omitempty"`
 }
 
 // ToolUse represents a tool invocation by the assistant.
@@ -77,6 +100,17 @@ type ToolUse struct {
 	ID    string `json:"id"`
 	Name  string `json:"name"`  // "bash", "read", "write", etc.
 	Input string `json:"input"` // JSON string of input parameters
+
+	// Sidechain is the subagent conversation the call spawned, if any
+	Sidechain []Message `json:"sidechain,omitempty"`
+}
+
+// Branch is a part of a conversation tree off the main branch.
+type Branch struct {
+	// ParentID is the ID of the message the branch continues from, or ""
+	// for a branch of its own.
+	ParentID string    `json:"parent_id,omitempty"`
+	Messages []Message `json:"messages"`
 }
 
 // ToolResult represents the result of a tool invocation.
@@ -84,6 +118,11 @@ type ToolResult struct {
 	ToolUseID string `json:"tool_use_id"`
 	Content   string `json:"content"`
 	IsError   bool   `json:"is_error,omitempty"`
+
+	// File is the full content of the file the call read, or of the file
+	// before the call changed it ("" if the call created it), if the tool
+	// recorded it
+	File *string `json:"file,omitempty"`
 }
 
 // SessionParser defines the interface for tool-specific session parsers.
@@ -130,6 +169,13 @@ func (m *Message) Preview(maxLen int) string {
 	return m.Text[:maxLen] + "..."
 }
 
+// SumTokens returns the sum of the session's input, output and cache
+// token counts.
+func (s *Session) SumTokens() int {
+	return s.TotalTokensIn + s.TotalTokensOut +
+		s.TotalTokensCacheWrite + s.TotalTokensCacheRead
+}
+
 // UserMessages returns only user messages from the session.
 func (s *Session) UserMessages() []Message {
 	var msgs []Message
@@ -152,6 +198,30 @@ func (s *Session) AssistantMessages() []Message {
 	return msgs
 }
 
+// AllMessages returns the messages of the main branch, the abandoned
+// branches and the subagent conversations, each conversation in order
+// and subagent conversations after the message that spawned them.
+func (s *Session) AllMessages() []Message {
+	var msgs []Message
+	var add func([]Message)
+	add = func(ms []Message) {
+		for _, m := range ms {
+			msgs = append(msgs, m)
+			for _, t := range m.ToolUses {
+				add(t.Sidechain)
+			}
+		}
+	}
+	add(s.Messages)
+	for _, b := range s.Branches {
+		add(b.Messages)
+	}
+	for _, b := range s.Sidechains {
+		add(b.Messages)
+	}
+	return msgs
+}
+
 // AllToolUses returns all tool uses across all messages.
 func (s *Session) AllToolUses() []ToolUse {
 	var tools []ToolUse
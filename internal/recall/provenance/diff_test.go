@@ -0,0 +1,44 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package provenance
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	numbered := func(from, to int) string {
+		var b strings.Builder
+		for i := from; i <= to; i++ {
+			b.WriteString("line " + string(rune('a'+i-1)) + "\n")
+		}
+		return b.String()
+	}
+
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{"equal", "a\n", "a\n", ""},
+		{"create", "", "a\nb\n", "@@ -0,0 +1,2 @@\n+a\n+b\n"},
+		{"delete all", "a\n", "", "@@ -1 +0,0 @@\n-a\n"},
+		{"change", numbered(1, 10), strings.Replace(numbered(1, 10), "line e\n", "line E\n", 1),
+			"@@ -2,7 +2,7 @@\n line b\n line c\n line d\n-line e\n+line E\n line f\n line g\n line h\n"},
+		{"two hunks", numbered(1, 20),
+			strings.Replace(strings.Replace(numbered(1, 20), "line b\n", "", 1), "line s\n", "line S\n", 1),
+			"@@ -1,5 +1,4 @@\n line a\n-line b\n line c\n line d\n line e\n" +
+				"@@ -16,5 +15,5 @@\n line p\n line q\n line r\n-line s\n+line S\n line t\n"},
+		{"no newline", "a\nb", "a\nc\n", "@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n"},
+	}
+	for _, tt := range tests {
+		if got := unified(tt.old, tt.new); got != tt.want {
+			t.Errorf("%s: unified() =\n%s\nwant\n%s", tt.name, got, tt.want)
+		}
+	}
+}
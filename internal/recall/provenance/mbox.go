@@ -0,0 +1,59 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package provenance
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// mboxSeparator starts each message of a git format-patch mbox.
+const mboxSeparator = "From 0000000000000000000000000000000000000000 Mon Sep 17 00:00:00 2001\n"
+
+// WriteMbox writes patches of a session as a mailbox that git am
+// applies as one commit per patch. The commit message is the prompt that
+// led to the change. Patches must be exact and not failed to apply.
+func WriteMbox(w io.Writer, s *parser.Session, patches []Patch) error {
+	name := s.Slug
+	if name == "" {
+		name = s.ID
+	}
+	for i, p := range patches {
+		var b strings.Builder
+		b.WriteString(mboxSeparator)
+		fmt.Fprintf(&b, "From: %s <%s@localhost>\n", s.Tool, s.Tool)
+		fmt.Fprintf(&b, "Date: %s\n", p.Time.Format(time.RFC1123Z))
+		fmt.Fprintf(&b, "Subject: [PATCH %d/%d] %s %s\n\n", i+1, len(patches), p.Tool, p.Name)
+		if p.Prompt != "" {
+			for _, line := range strings.Split(p.Prompt, "\n") {
+				// Lines starting with "From " would start a new message,
+				// and lines like a diff header the patch
+				switch {
+				case strings.HasPrefix(line, "From "):
+					line = ">" + line
+				case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "diff -"),
+					strings.HasPrefix(line, "Index: "):
+					line = " " + line
+				}
+				b.WriteString(line + "\n")
+			}
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "Session: %s (%s)\n", name, s.ID)
+		b.WriteString("---\n")
+		b.WriteString(p.Diff())
+		b.WriteString("-- \nctx\n\n")
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
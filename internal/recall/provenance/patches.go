@@ -0,0 +1,265 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package provenance
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// Patch is the change a tool call made to a file.
+type Patch struct {
+	Time time.Time `json:"time"`
+	Tool string    `json:"tool"`
+	// Path is the absolute path of the file, and Name the path shown in
+	// the diff: relative to the session's working directory if inside it
+	Path   string `json:"path"`
+	Name   string `json:"name"`
+	Prompt string `json:"prompt,omitempty"`
+	// Hunks are the hunks of the unified diff of the change. They have
+	// line numbers and context only if Exact is set: if the content of
+	// the file before the call is unknown, they show the replaced text
+	Hunks string `json:"hunks"`
+	Exact bool   `json:"exact"`
+	// Created is set if the call created the file
+	Created bool `json:"created,omitempty"`
+	// Failed is set if the tool reported an error, with its first line
+	// in Error; the change was not made
+	Failed   bool   `json:"failed,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Subagent bool   `json:"subagent,omitempty"`
+}
+
+// editInput is the input of the edit tools: Edit, MultiEdit, Write and
+// the Edit calls recorded for aider, which may hold a unified diff.
+type editInput struct {
+	FilePath   string        `json:"file_path"`
+	OldString  string        `json:"old_string"`
+	NewString  string        `json:"new_string"`
+	ReplaceAll bool          `json:"replace_all"`
+	Edits      []replacement `json:"edits"`
+	Content    string        `json:"content"`
+	Diff       string        `json:"diff"`
+}
+
+// replacement is one edit of a MultiEdit call.
+type replacement struct {
+	OldString  string `json:"old_string"`
+	NewString  string `json:"new_string"`
+	ReplaceAll bool   `json:"replace_all"`
+}
+
+// Patches replays the file edits of a loaded session in time order and
+// returns the change of each Edit, MultiEdit and Write call, failed ones
+// included. Edits on abandoned branches are left out.
+//
+// The content of a file is known from the original content the tool
+// recorded with an edit, from a whole-file read, or from the previous
+// edits; it is forgotten when a shell command names the file.
+func Patches(s *parser.Session) []Patch {
+	results := make(map[string]parser.ToolResult)
+	for _, m := range s.AllMessages() {
+		for _, r := range m.ToolResults {
+			results[r.ToolUseID] = r
+		}
+	}
+
+	var cs []call
+	for _, c := range calls(s) {
+		if !c.abandoned {
+			cs = append(cs, c)
+		}
+	}
+	sort.SliceStable(cs, func(i, j int) bool { return cs[i].time.Before(cs[j].time) })
+
+	files := make(map[string]string)
+	var patches []Patch
+	for _, c := range cs {
+		t := c.tool
+		result, hasResult := results[t.ID]
+		paths := t.FilePaths()
+		switch {
+		case len(paths) == 0:
+			continue
+		case t.Name == "Read":
+			if hasResult && !result.IsError && result.File != nil {
+				files[parser.ResolvePath(s.CWD, paths[0])] = *result.File
+			}
+			continue
+		case parser.ShellTools[t.Name]:
+			for _, p := range paths {
+				delete(files, parser.ResolvePath(s.CWD, p))
+			}
+			continue
+		case t.Name != "Edit" && t.Name != "MultiEdit" && t.Name != "Write":
+			continue
+		}
+
+		var input editInput
+		if json.Unmarshal([]byte(t.Input), &input) != nil {
+			continue
+		}
+		path := parser.ResolvePath(s.CWD, paths[0])
+		before, known := files[path]
+		if hasResult && result.File != nil {
+			before, known = *result.File, true
+		}
+
+		p := Patch{
+			Time:     c.time,
+			Tool:     t.Name,
+			Path:     path,
+			Name:     diffName(s.CWD, path),
+			Prompt:   c.prompt,
+			Subagent: c.subagent,
+		}
+		if hasResult && result.IsError {
+			p.Failed = true
+			p.Error, _, _ = strings.Cut(strings.TrimSpace(result.Content), "\n")
+		}
+
+		after, applied := apply(t.Name, input, before, known)
+		switch {
+		case applied:
+			p.Hunks = unified(before, after)
+			p.Exact = true
+			p.Created = before == "" && (t.Name == "Write" || input.OldString == "")
+		case input.Diff != "":
+			p.Hunks = diffHunks(input.Diff)
+		default:
+			p.Hunks = fragments(t.Name, input)
+		}
+		if p.Hunks == "" {
+			continue
+		}
+		patches = append(patches, p)
+
+		if p.Failed {
+			continue
+		}
+		switch {
+		case applied:
+			files[path] = after
+		case t.Name == "Write":
+			files[path] = input.Content
+		default:
+			delete(files, path)
+		}
+	}
+	return patches
+}
+
+// apply returns the content of a file after an edit call, and whether
+// it could be worked out from the content before.
+func apply(tool string, input editInput, before string, known bool) (string, bool) {
+	if tool == "Write" {
+		return input.Content, known
+	}
+	if !known || input.Diff != "" {
+		return "", false
+	}
+	edits := input.Edits
+	if tool == "Edit" {
+		edits = []replacement{{input.OldString, input.NewString, input.ReplaceAll}}
+	}
+
+	content := before
+	for _, e := range edits {
+		switch {
+		case e.OldString == "" && content == "":
+			// An edit with nothing to replace creates the file
+			content = e.NewString
+		case e.OldString == "" || !strings.Contains(content, e.OldString):
+			return "", false
+		case e.ReplaceAll:
+			content = strings.ReplaceAll(content, e.OldString, e.NewString)
+		default:
+			content = strings.Replace(content, e.OldString, e.NewString, 1)
+		}
+	}
+	return content, known
+}
+
+// fragments returns the hunks of an edit whose file content is unknown:
+// the replaced text, without line numbers and with the lines the old
+// and new text share as context.
+func fragments(tool string, input editInput) string {
+	edits := input.Edits
+	switch tool {
+	case "Edit":
+		edits = []replacement{{OldString: input.OldString, NewString: input.NewString}}
+	case "Write":
+		edits = []replacement{{NewString: input.Content}}
+	}
+
+	// The text of an edit is not the end of the file
+	withNewline := func(text string) string {
+		if text != "" && !strings.HasSuffix(text, "\n") {
+			return text + "\n"
+		}
+		return text
+	}
+	var out strings.Builder
+	for _, e := range edits {
+		if hunks := unified(withNewline(e.OldString), withNewline(e.NewString)); hunks != "" {
+			for _, line := range splitLines(hunks) {
+				if strings.HasPrefix(line, "@@ ") {
+					line = "@@ @@\n"
+				}
+				out.WriteString(line)
+			}
+		}
+	}
+	return out.String()
+}
+
+// diffHunks returns the hunks of a unified diff, without its file
+// header lines.
+func diffHunks(diff string) string {
+	var out strings.Builder
+	for _, line := range splitLines(diff) {
+		if strings.HasPrefix(line, "--- ") || strings.HasPrefix(line, "+++ ") {
+			continue
+		}
+		out.WriteString(line)
+	}
+	if s := out.String(); s != "" && !strings.HasSuffix(s, "\n") {
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+// diffName returns the path of a file as shown in a diff: relative to
+// the working directory if inside it, otherwise without its leading
+// slash.
+func diffName(cwd, path string) string {
+	if cwd != "" {
+		if rel, err := filepath.Rel(cwd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return strings.TrimPrefix(filepath.ToSlash(path), "/")
+}
+
+// Diff returns the patch as a git diff of one file.
+func (p Patch) Diff() string {
+	var b strings.Builder
+	b.WriteString("diff --git a/" + p.Name + " b/" + p.Name + "\n")
+	if p.Created {
+		b.WriteString("new file mode 100644\n--- /dev/null\n")
+	} else {
+		b.WriteString("--- a/" + p.Name + "\n")
+	}
+	b.WriteString("+++ b/" + p.Name + "\n")
+	b.WriteString(p.Hunks)
+	return b.String()
+}
@@ -0,0 +1,132 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package provenance
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// patchSession returns a session that reads a file, edits it three
+// times (once failing), creates another and edits a file it never read.
+func patchSession() *parser.Session {
+	at := func(sec int) time.Time { return time.Date(2026, 1, 20, 10, 0, sec, 0, time.UTC) }
+	content := "package drift\n\nfunc stale() bool {\n\treturn true\n}\n"
+	tool := func(id, name, input string) parser.ToolUse {
+		return parser.ToolUse{ID: id, Name: name, Input: input}
+	}
+	return &parser.Session{
+		ID:   "abcdef12-3456",
+		Slug: "drift-fix",
+		Tool: "claude-code",
+		CWD:  "/src/ctx",
+		Messages: []parser.Message{
+			{Role: "user", Timestamp: at(0), Text: "Fix the drift false positive"},
+			{Role: "assistant", Timestamp: at(1), ToolUses: []parser.ToolUse{
+				tool("t1", "Read", `{"file_path":"/src/ctx/drift.go"}`),
+			}},
+			{Role: "user", Timestamp: at(2), ToolResults: []parser.ToolResult{{ToolUseID: "t1", File: &content}}},
+			{Role: "assistant", Timestamp: at(3), ToolUses: []parser.ToolUse{
+				tool("t2", "Edit", `{"file_path":"drift.go","old_string":"return true","new_string":"return false"}`),
+			}},
+			{Role: "assistant", Timestamp: at(4), ToolUses: []parser.ToolUse{
+				tool("t3", "Edit", `{"file_path":"drift.go","old_string":"missing","new_string":"x"}`),
+			}},
+			{Role: "user", Timestamp: at(5), ToolResults: []parser.ToolResult{
+				{ToolUseID: "t3", IsError: true, Content: "String to replace not found in file.\nString: missing"},
+			}},
+			{Role: "user", Timestamp: at(6), Text: "Add a test"},
+			{Role: "assistant", Timestamp: at(7), ToolUses: []parser.ToolUse{
+				tool("t4", "Write", `{"file_path":"/src/ctx/drift_test.go","content":"package drift\n"}`),
+				tool("t5", "MultiEdit", `{"file_path":"drift.go","edits":[{"old_string":"stale","new_string":"isStale"},{"old_string":"false","new_string":"!true"}]}`),
+				tool("t6", "Edit", `{"file_path":"README.md","old_string":"Old\ntext","new_string":"New\ntext"}`),
+			}},
+			{Role: "user", Timestamp: at(8), ToolResults: []parser.ToolResult{{ToolUseID: "t4", File: new(string)}}},
+		},
+	}
+}
+
+func TestPatches(t *testing.T) {
+	patches := Patches(patchSession())
+
+	want := []struct {
+		tool, name string
+		exact      bool
+		created    bool
+		failed     bool
+		prompt     string
+		hunks      string
+	}{
+		{"Edit", "drift.go", true, false, false, "Fix the drift false positive",
+			"@@ -1,5 +1,5 @@\n package drift\n \n func stale() bool {\n-\treturn true\n+\treturn false\n }\n"},
+		{"Edit", "drift.go", false, false, true, "Fix the drift false positive", "@@ @@\n-missing\n+x\n"},
+		{"Write", "drift_test.go", true, true, false, "Add a test", "@@ -0,0 +1 @@\n+package drift\n"},
+		{"MultiEdit", "drift.go", true, false, false, "Add a test",
+			"@@ -1,5 +1,5 @@\n package drift\n \n-func stale() bool {\n-\treturn false\n+func isStale() bool {\n+\treturn !true\n }\n"},
+		{"Edit", "README.md", false, false, false, "Add a test", "@@ @@\n-Old\n+New\n text\n"},
+	}
+	if len(patches) != len(want) {
+		t.Fatalf("Patches() = %d patches, want %d: %+v", len(patches), len(want), patches)
+	}
+	for i, w := range want {
+		p := patches[i]
+		if p.Tool != w.tool || p.Name != w.name || p.Exact != w.exact || p.Created != w.created ||
+			p.Failed != w.failed || p.Prompt != w.prompt || p.Hunks != w.hunks {
+			t.Errorf("patch %d = %+v\nwant %+v", i+1, p, w)
+		}
+	}
+	if patches[1].Error != "String to replace not found in file." {
+		t.Errorf("Error = %q, want the first line of the result", patches[1].Error)
+	}
+}
+
+func TestPatches_ShellCommandForgetsContent(t *testing.T) {
+	s := patchSession()
+	s.Messages = append(s.Messages[:3], append([]parser.Message{{
+		Role: "assistant", Timestamp: s.Messages[2].Timestamp,
+		ToolUses: []parser.ToolUse{{ID: "b1", Name: "Bash", Input: `{"command":"gofmt -w drift.go"}`}},
+	}}, s.Messages[3:]...)...)
+
+	if p := Patches(s)[0]; p.Exact {
+		t.Errorf("edit after a shell command on the file is exact: %+v", p)
+	}
+}
+
+func TestWriteMbox(t *testing.T) {
+	s := patchSession()
+	var exact []Patch
+	for _, p := range Patches(s) {
+		if p.Exact && !p.Failed {
+			exact = append(exact, p)
+		}
+	}
+	exact[0].Prompt = "Fix it\nFrom now on\n---"
+
+	var b strings.Builder
+	if err := WriteMbox(&b, s, exact); err != nil {
+		t.Fatalf("WriteMbox: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		mboxSeparator + "From: claude-code <claude-code@localhost>\nDate: Tue, 20 Jan 2026 10:00:03 +0000\n" +
+			"Subject: [PATCH 1/3] Edit drift.go\n\nFix it\n>From now on\n ---\n\nSession: drift-fix (abcdef12-3456)\n---\n" +
+			"diff --git a/drift.go b/drift.go\n--- a/drift.go\n+++ b/drift.go\n@@ -1,5 +1,5 @@\n",
+		"Subject: [PATCH 2/3] Write drift_test.go\n",
+		"diff --git a/drift_test.go b/drift_test.go\nnew file mode 100644\n--- /dev/null\n+++ b/drift_test.go\n",
+		"Subject: [PATCH 3/3] MultiEdit drift.go\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("mbox missing %q:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, mboxSeparator); n != 3 {
+		t.Errorf("mbox has %d messages, want 3", n)
+	}
+}
@@ -0,0 +1,244 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+// Package provenance finds the tool calls of sessions that read, edited
+// or ran commands on a file: the agent equivalent of git blame.
+//
+// Each touch carries the user prompt that led to it: the last user text
+// before the call in its conversation. Subagent conversations inherit
+// the prompt of the call that spawned them, and abandoned branches the
+// prompt of the message they continue from.
+//
+// The edits of a session can be replayed as a patch series: a unified
+// diff per edit, written as a mailbox for git am.
+package provenance
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// Kind is what a tool call did with a file.
+type Kind string
+
+// Touch kinds.
+const (
+	KindRead    Kind = "read"
+	KindEdit    Kind = "edit"
+	KindCommand Kind = "command"
+)
+
+// MaxSnippetLines is the number of lines a snippet is cut to.
+const MaxSnippetLines = 12
+
+// Touch is a tool call that read, edited or named a file.
+type Touch struct {
+	Time   time.Time `json:"time"`
+	Tool   string    `json:"tool"`
+	Kind   Kind      `json:"kind"`
+	Prompt string    `json:"prompt,omitempty"`
+	// Snippet is the change as "-" and "+" lines for edits, or the command
+	// line for commands
+	Snippet string `json:"snippet,omitempty"`
+	// Subagent is set for calls of a subagent conversation
+	Subagent bool `json:"subagent,omitempty"`
+	// Abandoned is set for calls on a branch left by a rewound prompt
+	Abandoned bool `json:"abandoned,omitempty"`
+}
+
+// Touches returns the tool calls of a loaded session that touched the
+// file at an absolute path, in conversation order.
+func Touches(s *parser.Session, path string) []Touch {
+	var touches []Touch
+	for _, c := range calls(s) {
+		if !touched(s, c.tool, path) {
+			continue
+		}
+		touches = append(touches, Touch{
+			Time:      c.time,
+			Tool:      c.tool.Name,
+			Kind:      kind(c.tool),
+			Prompt:    c.prompt,
+			Snippet:   truncate(snippet(s, c.tool, path)),
+			Subagent:  c.subagent,
+			Abandoned: c.abandoned,
+		})
+	}
+	return touches
+}
+
+// call is a tool call with the prompt that led to it.
+type call struct {
+	time      time.Time
+	tool      parser.ToolUse
+	prompt    string
+	subagent  bool
+	abandoned bool
+}
+
+// calls returns the tool calls of a session in conversation order: the
+// main branch with subagent conversations in place, then the abandoned
+// branches, then the subagent conversations without a spawning call.
+func calls(s *parser.Session) []call {
+	w := walker{prompts: make(map[string]string)}
+	w.walk(s.Messages, "", false, false)
+	for _, b := range s.Branches {
+		w.walk(b.Messages, w.prompts[b.ParentID], false, true)
+	}
+	for _, b := range s.Sidechains {
+		w.walk(b.Messages, "", true, false)
+	}
+	return w.calls
+}
+
+// walker collects the tool calls of a session's conversations.
+type walker struct {
+	// prompts maps the main branch's message IDs to their prompt
+	prompts map[string]string
+	calls   []call
+}
+
+// walk collects the tool calls of a conversation, starting from a
+// prompt. Subagent conversations keep the prompt they start from.
+func (w *walker) walk(msgs []parser.Message, prompt string, subagent, abandoned bool) {
+	for _, m := range msgs {
+		if m.IsUser() && !subagent && strings.TrimSpace(m.Text) != "" {
+			prompt = strings.TrimSpace(m.Text)
+		}
+		if !subagent && !abandoned && m.ID != "" {
+			w.prompts[m.ID] = prompt
+		}
+		for _, t := range m.ToolUses {
+			w.calls = append(w.calls, call{
+				time:      m.Timestamp,
+				tool:      t,
+				prompt:    prompt,
+				subagent:  subagent,
+				abandoned: abandoned,
+			})
+			w.walk(t.Sidechain, prompt, true, abandoned)
+		}
+	}
+}
+
+// touched reports whether a tool call of a session names the file at
+// an absolute path.
+func touched(s *parser.Session, t parser.ToolUse, path string) bool {
+	for _, p := range t.FilePaths() {
+		if parser.ResolvePath(s.CWD, p) == path {
+			return true
+		}
+	}
+	return false
+}
+
+// kind returns what a tool call did with its files.
+func kind(t parser.ToolUse) Kind {
+	switch {
+	case t.Name == "Read":
+		return KindRead
+	case parser.ShellTools[t.Name]:
+		return KindCommand
+	}
+	return KindEdit
+}
+
+// snippet returns the change a tool call of a session made to the file
+// at an absolute path, or its command line.
+func snippet(s *parser.Session, t parser.ToolUse, path string) string {
+	if parser.ShellTools[t.Name] {
+		return t.Command()
+	}
+	if t.Name == "apply_patch" {
+		return patchSection(s, t.Patch(), path)
+	}
+
+	var input struct {
+		OldString string `json:"old_string"`
+		NewString string `json:"new_string"`
+		Content   string `json:"content"`
+		NewSource string `json:"new_source"`
+		Edits     []struct {
+			OldString string `json:"old_string"`
+			NewString string `json:"new_string"`
+		} `json:"edits"`
+	}
+	if json.Unmarshal([]byte(t.Input), &input) != nil {
+		return ""
+	}
+	var b strings.Builder
+	switch t.Name {
+	case "Edit":
+		diff(&b, input.OldString, input.NewString)
+	case "MultiEdit":
+		for _, e := range input.Edits {
+			diff(&b, e.OldString, e.NewString)
+		}
+	case "Write":
+		diff(&b, "", input.Content)
+	case "NotebookEdit":
+		diff(&b, "", input.NewSource)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// patchSection returns the hunks of an apply_patch patch of a session
+// for the file at an absolute path.
+func patchSection(s *parser.Session, patch, path string) string {
+	var lines []string
+	in := false
+	for _, line := range strings.Split(patch, "\n") {
+		if strings.HasPrefix(line, "*** ") {
+			in = false
+			for _, op := range []string{"Add", "Update", "Delete"} {
+				if name, ok := strings.CutPrefix(line, "*** "+op+" File: "); ok {
+					in = parser.ResolvePath(s.CWD, strings.TrimSpace(name)) == path
+				}
+			}
+			continue
+		}
+		if in {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// diff writes the lines of old as "- " lines and those of new as "+ "
+// lines.
+func diff(b *strings.Builder, old, new string) {
+	for _, part := range []struct{ prefix, text string }{{"- ", old}, {"+ ", new}} {
+		if part.text == "" {
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(part.text, "\n"), "\n") {
+			b.WriteString(part.prefix + line + "\n")
+		}
+	}
+}
+
+// truncate cuts a snippet to MaxSnippetLines lines.
+func truncate(snippet string) string {
+	lines := strings.Split(snippet, "\n")
+	if len(lines) <= MaxSnippetLines {
+		return snippet
+	}
+	more := len(lines) - MaxSnippetLines
+	return strings.Join(lines[:MaxSnippetLines], "\n") +
+		"\n... (" + plural(more, "more line") + ")"
+}
+
+// plural formats a count with a noun, adding "s" unless the count is 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(n) + " " + noun + "s"
+}
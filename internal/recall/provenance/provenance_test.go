@@ -0,0 +1,76 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package provenance
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+func TestTouches(t *testing.T) {
+	s := &parser.Session{
+		CWD: "/src/ctx",
+		Messages: []parser.Message{
+			{ID: "u1", Role: "user", Text: "Fix the drift false positive"},
+			{ID: "a1", Role: "assistant", ToolUses: []parser.ToolUse{
+				{Name: "Read", Input: `{"file_path":"/src/ctx/internal/drift/detector.go"}`},
+				{Name: "Edit", Input: `{"file_path":"internal/drift/detector.go","old_string":"a := 1","new_string":"a := 2\nb := 3"}`},
+				{Name: "Edit", Input: `{"file_path":"/src/ctx/README.md","old_string":"x","new_string":"y"}`},
+			}},
+			{ID: "u2", Role: "user", ToolResults: []parser.ToolResult{{ToolUseID: "t"}}},
+			{ID: "u3", Role: "user", Text: "Now run the tests"},
+			{ID: "a2", Role: "assistant", ToolUses: []parser.ToolUse{
+				{Name: "Bash", Input: `{"command":"cd internal/drift && go test -run TestDetector detector_test.go detector.go"}`},
+				{Name: "Task", Input: `{}`, Sidechain: []parser.Message{
+					{Role: "user", Text: "Review detector.go"},
+					{Role: "assistant", ToolUses: []parser.ToolUse{
+						{Name: "apply_patch", Input: "*** Begin Patch\n*** Update File: internal/drift/detector.go\n@@\n-a := 2\n+a := 4\n*** Update File: other.go\n@@\n+x\n*** End Patch"},
+					}},
+				}},
+			}},
+		},
+		Branches: []parser.Branch{{ParentID: "a1", Messages: []parser.Message{
+			{Role: "assistant", ToolUses: []parser.ToolUse{
+				{Name: "Write", Input: `{"file_path":"/src/ctx/internal/drift/detector.go","content":"package drift\n"}`},
+			}},
+		}}},
+	}
+
+	got := Touches(s, "/src/ctx/internal/drift/detector.go")
+	want := []Touch{
+		{Tool: "Read", Kind: KindRead, Prompt: "Fix the drift false positive"},
+		{Tool: "Edit", Kind: KindEdit, Prompt: "Fix the drift false positive", Snippet: "- a := 1\n+ a := 2\n+ b := 3"},
+		{Tool: "Bash", Kind: KindCommand, Prompt: "Now run the tests",
+			Snippet: "cd internal/drift && go test -run TestDetector detector_test.go detector.go"},
+		{Tool: "apply_patch", Kind: KindEdit, Prompt: "Now run the tests", Snippet: "@@\n-a := 2\n+a := 4", Subagent: true},
+		{Tool: "Write", Kind: KindEdit, Prompt: "Fix the drift false positive", Snippet: "+ package drift", Abandoned: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Touches() = %+v, want %d touches", got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("touch %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	long := strings.Repeat("+ line\n", MaxSnippetLines+3)
+	got := truncate(strings.TrimSuffix(long, "\n"))
+	if n := strings.Count(got, "\n"); n != MaxSnippetLines {
+		t.Errorf("truncate() has %d lines, want %d", n+1, MaxSnippetLines+1)
+	}
+	if !strings.HasSuffix(got, "... (3 more lines)") {
+		t.Errorf("truncate() = %q, want a count of the lines cut", got)
+	}
+	if got := truncate("+ a\n+ b"); got != "+ a\n+ b" {
+		t.Errorf("truncate() = %q, want it unchanged", got)
+	}
+}
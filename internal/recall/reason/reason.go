@@ -0,0 +1,197 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+// Package reason classifies the thinking blocks of sessions.
+//
+// Each thinking block gets a reasoning category (decomposition,
+// hypothesis, pivot, error analysis) from phrase heuristics, and an
+// outcome (success, failure, abandoned) from the messages that follow
+// it. Classification is deterministic and needs no model.
+package reason
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// Category is the kind of reasoning in a thinking block.
+type Category string
+
+// Reasoning categories.
+const (
+	CategoryDecomposition Category = "decomposition"
+	CategoryHypothesis    Category = "hypothesis"
+	CategoryPivot         Category = "pivot"
+	CategoryErrorAnalysis Category = "error_analysis"
+	CategoryOther         Category = "other"
+)
+
+// Categories lists the reasoning categories, in tie-break order.
+var Categories = []Category{
+	CategoryPivot,
+	CategoryErrorAnalysis,
+	CategoryHypothesis,
+	CategoryDecomposition,
+	CategoryOther,
+}
+
+// Outcome is what came of a thinking block.
+type Outcome string
+
+// Reasoning outcomes.
+const (
+	OutcomeSuccess   Outcome = "success"
+	OutcomeFailure   Outcome = "failure"
+	OutcomeAbandoned Outcome = "abandoned"
+	OutcomeUnknown   Outcome = "unknown"
+)
+
+// Outcomes lists the reasoning outcomes.
+var Outcomes = []Outcome{
+	OutcomeSuccess, OutcomeFailure, OutcomeAbandoned, OutcomeUnknown,
+}
+
+// outcomeWindow is the number of messages after a thinking block that
+// are examined for its outcome.
+const outcomeWindow = 5
+
+// minProgress is the number of successful tool results, without errors,
+// that count as continued progress.
+const minProgress = 2
+
+// maxEvidenceLength is the length beyond which outcome evidence is
+// truncated.
+const maxEvidenceLength = 120
+
+// categoryPatterns are the phrases indicating each category. A block
+// scores one point per matching pattern.
+var categoryPatterns = map[Category][]*regexp.Regexp{
+	CategoryDecomposition: {
+		regexp.MustCompile(`(?i)\bbreak (this|it) down\b`),
+		regexp.MustCompile(`(?i)\bstep by step\b`),
+		regexp.MustCompile(`(?i)\bfirst\b[^.]*\bthen\b`),
+		regexp.MustCompile(`(?i)\b(steps|plan|approach):`),
+		regexp.MustCompile(`(?m)^\s*1[.)]\s[\s\S]*^\s*2[.)]\s`),
+	},
+	CategoryHypothesis: {
+		regexp.MustCompile(`(?i)\b(could|might|may) be\b`),
+		regexp.MustCompile(`(?i)\b(maybe|perhaps|possibly)\b`),
+		regexp.MustCompile(`(?i)\bi (suspect|think the|guess)\b`),
+		regexp.MustCompile(`(?i)\bhypothes[ie]s\b`),
+		regexp.MustCompile(`(?i)\blet me (check|verify|test) (if|whether)\b`),
+		regexp.MustCompile(`(?i)\b(probably|likely) (because|due to|caused)\b`),
+	},
+	CategoryPivot: {
+		regexp.MustCompile(`(?i)\bactually,`),
+		regexp.MustCompile(`(?i)\b(won't|will not|doesn't|does not|didn't|did not) work\b`),
+		regexp.MustCompile(`(?i)\b(a different|another|alternative) approach\b`),
+		regexp.MustCompile(`(?i)\blet me try (something else|another|a different)\b`),
+		regexp.MustCompile(`(?i)\b(instead|scratch that|on second thought)\b`),
+		regexp.MustCompile(`(?i)^\s*(wait|hmm),`),
+	},
+	CategoryErrorAnalysis: {
+		regexp.MustCompile(`(?i)\b(stack ?trace|traceback|panic|exception)\b`),
+		regexp.MustCompile(`(?i)\berror (message|says|shows|is)\b`),
+		regexp.MustCompile(`(?i)\b(nil pointer|null pointer|undefined|segfault)\b`),
+		regexp.MustCompile(`(?i)\b(failed|fails|failing) (with|because)\b`),
+		regexp.MustCompile(`(?i)\bexit (code|status) \d+`),
+		regexp.MustCompile(`(?i)\bline \d+\b`),
+	},
+}
+
+// successPattern matches language reporting that something worked.
+var successPattern = regexp.MustCompile(
+	`(?i)\b(that worked|it works|works now|now works|now pass(es)?|tests? (now )?pass(es|ed)?|all tests pass|is fixed|fixed it|that fixed|succeeded|looks good)\b`,
+)
+
+// failurePattern matches language reporting that something did not work.
+var failurePattern = regexp.MustCompile(
+	`(?i)\b(didn't work|did not work|doesn't work|does not work|still (fails|failing|broken|the same)|same error|no luck|that's not it|wrong (hypothesis|assumption))\b`,
+)
+
+// Classify returns the reasoning category of a thinking block: the one
+// with the most matching phrases, ties broken in the order of
+// Categories. Blocks matching no phrase are CategoryOther.
+func Classify(text string) Category {
+	best, bestScore := CategoryOther, 0
+	for _, c := range Categories {
+		score := 0
+		for _, p := range categoryPatterns[c] {
+			if p.MatchString(text) {
+				score++
+			}
+		}
+		if score > bestScore {
+			best, bestScore = c, score
+		}
+	}
+	return best
+}
+
+// DetectOutcome returns the outcome of the thinking block of message idx,
+// with the text that indicated it, by examining the messages that follow.
+//
+// A tool error, failure language or a pivot in later thinking is a
+// failure; success language, or continued progress through successful
+// tool calls, is a success. A block followed by the end of the session
+// without either is abandoned.
+func DetectOutcome(s *parser.Session, idx int) (Outcome, string) {
+	progress := 0
+	end := min(idx+outcomeWindow, len(s.Messages)-1)
+	for i := idx + 1; i <= end; i++ {
+		m := s.Messages[i]
+		for _, r := range m.ToolResults {
+			if r.IsError {
+				return OutcomeFailure, evidence(r.Content)
+			}
+			progress++
+		}
+		if m.IsAssistant() && Classify(m.Thinking) == CategoryPivot {
+			return OutcomeFailure, evidence(m.Thinking)
+		}
+		if line := matchingLine(failurePattern, m.Text); line != "" {
+			return OutcomeFailure, evidence(line)
+		}
+		if line := matchingLine(successPattern, m.Text); line != "" {
+			return OutcomeSuccess, evidence(line)
+		}
+	}
+
+	switch {
+	case progress >= minProgress:
+		return OutcomeSuccess, ""
+	case end == len(s.Messages)-1:
+		return OutcomeAbandoned, ""
+	}
+	return OutcomeUnknown, ""
+}
+
+// matchingLine returns the first line of text matching a pattern, or "".
+func matchingLine(p *regexp.Regexp, text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if p.MatchString(line) {
+			return line
+		}
+	}
+	return ""
+}
+
+// evidence returns the first non-empty line of a text, truncated.
+func evidence(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if runes := []rune(line); len(runes) > maxEvidenceLength {
+			line = string(runes[:maxEvidenceLength-3]) + "..."
+		}
+		return line
+	}
+	return ""
+}
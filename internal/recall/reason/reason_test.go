@@ -0,0 +1,105 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package reason
+
+import (
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// TestClassify tests the reasoning category heuristics.
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		text string
+		want Category
+	}{
+		{"Let me break this down:\n1. Parse the input\n2. Apply the rules", CategoryDecomposition},
+		{"The error could be a missing dependency. Maybe the version is wrong; let me check if go.mod pins it.", CategoryHypothesis},
+		{"Actually, this won't work because the API has no batch endpoint. Let me try another approach.", CategoryPivot},
+		{"The stack trace shows a nil pointer at line 45, so the config is not loaded.", CategoryErrorAnalysis},
+		{"I'll read the file.", CategoryOther},
+		{"", CategoryOther},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.text); got != tt.want {
+			t.Errorf("Classify(%q) = %s, want %s", tt.text, got, tt.want)
+		}
+	}
+}
+
+// TestDetectOutcome tests outcome detection from the following messages.
+func TestDetectOutcome(t *testing.T) {
+	thinking := parser.Message{Role: "assistant", Thinking: "Maybe the port is taken."}
+	ok := parser.Message{Role: "user", ToolResults: []parser.ToolResult{{Content: "done"}}}
+	filler := parser.Message{Role: "assistant", Text: "Reading the file."}
+
+	tests := []struct {
+		name     string
+		after    []parser.Message
+		want     Outcome
+		evidence string
+	}{
+		{
+			name: "tool error",
+			after: []parser.Message{{Role: "user", ToolResults: []parser.ToolResult{
+				{Content: "\nbind: address already in use\nexit 1", IsError: true},
+			}}},
+			want:     OutcomeFailure,
+			evidence: "bind: address already in use",
+		},
+		{
+			name: "failure language",
+			after: []parser.Message{
+				{Role: "assistant", Text: "Restarted it.\nThat didn't work either."},
+				filler,
+			},
+			want:     OutcomeFailure,
+			evidence: "That didn't work either.",
+		},
+		{
+			name: "pivot follows",
+			after: []parser.Message{
+				{Role: "assistant", Thinking: "Actually, that won't work; let me try another approach."},
+				filler,
+			},
+			want:     OutcomeFailure,
+			evidence: "Actually, that won't work; let me try another approach.",
+		},
+		{
+			name:     "success language",
+			after:    []parser.Message{{Role: "user", Text: "Great, that worked!"}, filler},
+			want:     OutcomeSuccess,
+			evidence: "Great, that worked!",
+		},
+		{
+			name:  "continued progress",
+			after: []parser.Message{ok, filler, ok, filler, filler, filler, filler},
+			want:  OutcomeSuccess,
+		},
+		{
+			name:  "session ends",
+			after: []parser.Message{filler},
+			want:  OutcomeAbandoned,
+		},
+		{
+			name:  "no signal",
+			after: []parser.Message{filler, filler, filler, filler, filler, filler},
+			want:  OutcomeUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &parser.Session{Messages: append([]parser.Message{thinking}, tt.after...)}
+			got, evidence := DetectOutcome(s, 0)
+			if got != tt.want || evidence != tt.evidence {
+				t.Errorf("DetectOutcome() = %s %q, want %s %q", got, evidence, tt.want, tt.evidence)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package search
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ActiveMemory/ctx/internal/recall/reason"
+)
+
+const (
+	// minOccurrences is the number of similar thinking blocks needed to
+	// call their reasoning a pattern.
+	minOccurrences = 3
+	// minFailureRate is the share of failures that makes a pattern an
+	// anti-pattern.
+	minFailureRate = 0.6
+	// minSimilarity is the Jaccard similarity of key terms at which two
+	// thinking blocks are grouped together.
+	minSimilarity = 0.25
+	// keyTerms is the number of terms that characterize a thinking block.
+	keyTerms = 10
+	// patternTerms is the number of terms that name a pattern.
+	patternTerms = 3
+)
+
+// AntiPattern is reasoning that kept failing across sessions.
+type AntiPattern struct {
+	// Terms are the terms that best characterize the reasoning.
+	Terms       []string
+	Occurrences int
+	// Failures are the failed thinking blocks, best match first.
+	Failures []Result
+	// Better is the best matching successful thinking block of the same
+	// group, if any.
+	Better *Result
+}
+
+// FailureRate returns the share of occurrences that failed.
+func (a AntiPattern) FailureRate() float64 {
+	return float64(len(a.Failures)) / float64(a.Occurrences)
+}
+
+// AntiPatterns groups thinking results by their key terms and returns
+// the groups of at least minOccurrences blocks of which more than
+// minFailureRate failed, most failures first.
+//
+// Key terms are the terms of a block weighted by how rare they are among
+// the results; results of other types are ignored.
+func AntiPatterns(results []Result) []AntiPattern {
+	var thinking []Result
+	for _, r := range results {
+		if r.Chunk.Type == TypeThinking {
+			thinking = append(thinking, r)
+		}
+	}
+
+	terms := make([]map[string]int, len(thinking))
+	df := make(map[string]int)
+	for i, r := range thinking {
+		terms[i] = make(map[string]int)
+		for _, t := range Tokenize(r.Chunk.Content) {
+			if terms[i][t] == 0 {
+				df[t]++
+			}
+			terms[i][t]++
+		}
+	}
+
+	type group struct {
+		seed    map[string]bool
+		members []int
+	}
+	var groups []*group
+	weights := make([]map[string]int, len(thinking))
+	for i := range thinking {
+		weights[i] = keyTermWeights(terms[i], df, len(thinking))
+		key := make(map[string]bool)
+		for t := range weights[i] {
+			key[t] = true
+		}
+		var g *group
+		for _, cand := range groups {
+			if jaccard(key, cand.seed) >= minSimilarity {
+				g = cand
+				break
+			}
+		}
+		if g == nil {
+			g = &group{seed: key}
+			groups = append(groups, g)
+		}
+		g.members = append(g.members, i)
+	}
+
+	var patterns []AntiPattern
+	for _, g := range groups {
+		if len(g.members) < minOccurrences {
+			continue
+		}
+		p := AntiPattern{Occurrences: len(g.members)}
+		weight := make(map[string]int)
+		for _, i := range g.members {
+			r := thinking[i]
+			switch reason.Outcome(r.Chunk.Outcome) {
+			case reason.OutcomeFailure:
+				p.Failures = append(p.Failures, r)
+			case reason.OutcomeSuccess:
+				if p.Better == nil {
+					p.Better = &thinking[i]
+				}
+			}
+			for t := range g.seed {
+				weight[t] += weights[i][t]
+			}
+		}
+		if p.FailureRate() <= minFailureRate {
+			continue
+		}
+		p.Terms = topTerms(weight, patternTerms)
+		patterns = append(patterns, p)
+	}
+
+	sort.SliceStable(patterns, func(i, j int) bool {
+		return len(patterns[i].Failures) > len(patterns[j].Failures)
+	})
+	return patterns
+}
+
+// keyTermWeights returns the keyTerms terms of a block with the highest
+// tf-idf weight among n blocks, with their weights.
+func keyTermWeights(tf map[string]int, df map[string]int, n int) map[string]int {
+	weight := make(map[string]int, len(tf))
+	for t, f := range tf {
+		// Scale to integers so that topTerms can rank the weights
+		weight[t] = int(1000 * float64(f) * math.Log(1+float64(n)/float64(df[t])))
+	}
+	key := make(map[string]int)
+	for _, t := range topTerms(weight, keyTerms) {
+		key[t] = weight[t]
+	}
+	return key
+}
+
+// topTerms returns the n terms with the highest counts or weights, ties
+// broken alphabetically.
+func topTerms(count map[string]int, n int) []string {
+	terms := make([]string, 0, len(count))
+	for t := range count {
+		terms = append(terms, t)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if count[terms[i]] != count[terms[j]] {
+			return count[terms[i]] > count[terms[j]]
+		}
+		return terms[i] < terms[j]
+	})
+	return terms[:min(n, len(terms))]
+}
+
+// jaccard returns the Jaccard similarity of two term sets.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range a {
+		if b[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,98 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package search
+
+import (
+	"sort"
+	"strings"
+)
+
+// branchBoost multiplies the score of related chunks from sessions on
+// the current branch.
+const branchBoost = 1.5
+
+// AutoQuery describes the work that history is wanted for.
+type AutoQuery struct {
+	// CWD is the project directory; only sessions run in it or below it
+	// are considered.
+	CWD string
+	// Branch is the current git branch, whose sessions rank higher.
+	Branch string
+	// Text describes the current work, e.g. active tasks and changed
+	// files.
+	Text string
+	// Recent is the number of recent sessions to report.
+	Recent int
+	// Related is the number of related chunks to report.
+	Related int
+}
+
+// AutoResult is the history relevant to an AutoQuery.
+type AutoResult struct {
+	// Recent holds the opening turn of the most recent sessions of the
+	// project, newest first.
+	Recent []Result
+	// Related holds the chunks of the project that best match the query
+	// text, best first.
+	Related []Result
+}
+
+// Auto finds the history relevant to the current work: the latest
+// sessions of the project and the chunks that match what is being worked
+// on, preferring sessions on the current branch.
+func (x *Index) Auto(q AutoQuery) AutoResult {
+	var res AutoResult
+
+	// The opening turn of each session stands for the session
+	openers := make(map[string]*Chunk)
+	for _, c := range x.chunks {
+		if c.Type != TypeTurnPair || !within(c.CWD, q.CWD) {
+			continue
+		}
+		if o, ok := openers[c.SessionID]; !ok || c.Timestamp.Before(o.Timestamp) {
+			openers[c.SessionID] = c
+		}
+	}
+	for _, c := range openers {
+		res.Recent = append(res.Recent, Result{Chunk: c, Snippet: firstLine(c.Content)})
+	}
+	sort.Slice(res.Recent, func(i, j int) bool {
+		return res.Recent[i].Chunk.Timestamp.After(res.Recent[j].Chunk.Timestamp)
+	})
+	if len(res.Recent) > q.Recent {
+		res.Recent = res.Recent[:q.Recent]
+	}
+
+	shown := make(map[string]bool)
+	for _, r := range res.Recent {
+		shown[r.Chunk.ID] = true
+	}
+	for _, r := range x.Search(q.Text, Options{CWD: q.CWD}) {
+		if shown[r.Chunk.ID] {
+			continue
+		}
+		if q.Branch != "" && r.Chunk.Branch == q.Branch {
+			r.Score *= branchBoost
+		}
+		res.Related = append(res.Related, r)
+	}
+	sort.SliceStable(res.Related, func(i, j int) bool {
+		return res.Related[i].Score > res.Related[j].Score
+	})
+	if len(res.Related) > q.Related {
+		res.Related = res.Related[:q.Related]
+	}
+
+	return res
+}
+
+// firstLine returns the first line of a chunk, without the "Q: " prefix
+// of turn pairs.
+func firstLine(content string) string {
+	line, _, _ := strings.Cut(content, "\n")
+	return strings.TrimSpace(strings.TrimPrefix(line, "Q: "))
+}
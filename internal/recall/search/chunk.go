@@ -0,0 +1,163 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+// Package search provides an offline lexical index over session history.
+//
+// Sessions are split into chunks (turn pairs, thinking blocks and tool
+// calls) that are ranked against a query with BM25. The index is kept on
+// disk and updated incrementally: only session files whose modification
+// time or size changed are parsed again.
+package search
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+	"github.com/ActiveMemory/ctx/internal/recall/reason"
+)
+
+// Chunk types.
+const (
+	TypeTurnPair = "turn_pair"
+	TypeThinking = "thinking"
+	TypeTool     = "tool"
+)
+
+// maxChunkLength is the number of characters beyond which chunk content
+// is truncated, so that large tool outputs do not bloat the index.
+const maxChunkLength = 4000
+
+// Chunk is a searchable piece of a session.
+type Chunk struct {
+	// ID identifies the chunk, e.g. "<session-id>:thinking:12".
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	SessionID string    `json:"session_id"`
+	Slug      string    `json:"slug,omitempty"`
+	Project   string    `json:"project,omitempty"`
+	CWD       string    `json:"cwd,omitempty"`
+	Branch    string    `json:"branch,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	// Message is the index of the message the chunk starts at.
+	Message int    `json:"message"`
+	Content string `json:"content"`
+	// Category and Outcome classify thinking chunks; Evidence is the text
+	// the outcome was detected from, if any.
+	Category string `json:"category,omitempty"`
+	Outcome  string `json:"outcome,omitempty"`
+	Evidence string `json:"evidence,omitempty"`
+}
+
+// ChunkSession splits a session into turn pairs, thinking blocks and
+// tool calls.
+//
+// A turn pair is a user message with the assistant text that follows it
+// up to the next user message. A thinking block is classified by its
+// reasoning category and outcome. A tool call includes its result.
+func ChunkSession(s *parser.Session) []Chunk {
+	var chunks []Chunk
+	chunks = append(chunks, turnPairs(s)...)
+	chunks = append(chunks, thinkingBlocks(s)...)
+	chunks = append(chunks, toolCalls(s)...)
+	return chunks
+}
+
+// newChunk returns a chunk of a session starting at message i.
+func newChunk(s *parser.Session, typ string, i int, content string) Chunk {
+	return Chunk{
+		ID:        s.ID + ":" + typ + ":" + strconv.Itoa(i),
+		Type:      typ,
+		SessionID: s.ID,
+		Slug:      s.Slug,
+		Project:   s.Project,
+		CWD:       s.CWD,
+		Branch:    s.GitBranch,
+		Timestamp: s.Messages[i].Timestamp,
+		Message:   i,
+		Content:   truncate(strings.TrimSpace(content)),
+	}
+}
+
+// turnPairs returns the user questions of a session with their answers.
+func turnPairs(s *parser.Session) []Chunk {
+	isQuestion := func(m parser.Message) bool {
+		return m.IsUser() && strings.TrimSpace(m.Text) != ""
+	}
+
+	var chunks []Chunk
+	for i, m := range s.Messages {
+		if !isQuestion(m) {
+			continue
+		}
+		var answer []string
+		for j := i + 1; j < len(s.Messages) && !isQuestion(s.Messages[j]); j++ {
+			if a := s.Messages[j]; a.IsAssistant() && strings.TrimSpace(a.Text) != "" {
+				answer = append(answer, strings.TrimSpace(a.Text))
+			}
+		}
+		content := "Q: " + strings.TrimSpace(m.Text)
+		if len(answer) > 0 {
+			content += "\n\nA: " + strings.Join(answer, "\n\n")
+		}
+		chunks = append(chunks, newChunk(s, TypeTurnPair, i, content))
+	}
+	return chunks
+}
+
+// thinkingBlocks returns the reasoning of the assistant messages.
+func thinkingBlocks(s *parser.Session) []Chunk {
+	var chunks []Chunk
+	for i, m := range s.Messages {
+		if !m.IsAssistant() || strings.TrimSpace(m.Thinking) == "" {
+			continue
+		}
+		c := newChunk(s, TypeThinking, i, m.Thinking)
+		outcome, evidence := reason.DetectOutcome(s, i)
+		c.Category = string(reason.Classify(m.Thinking))
+		c.Outcome = string(outcome)
+		c.Evidence = evidence
+		chunks = append(chunks, c)
+	}
+	return chunks
+}
+
+// toolCalls returns the tool calls of a session with their results.
+func toolCalls(s *parser.Session) []Chunk {
+	results := make(map[string]string)
+	for _, m := range s.Messages {
+		for _, r := range m.ToolResults {
+			results[r.ToolUseID] = r.Content
+		}
+	}
+
+	var chunks []Chunk
+	for i, m := range s.Messages {
+		for k, t := range m.ToolUses {
+			content := t.Name + " " + t.Input
+			if out := strings.TrimSpace(results[t.ID]); out != "" {
+				content += "\n" + out
+			}
+			c := newChunk(s, TypeTool, i, content)
+			c.ID += "." + strconv.Itoa(k)
+			chunks = append(chunks, c)
+		}
+	}
+	return chunks
+}
+
+// truncate shortens content to maxChunkLength characters.
+func truncate(s string) string {
+	if len(s) <= maxChunkLength {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= maxChunkLength {
+		return s
+	}
+	return string(runes[:maxChunkLength-3]) + "..."
+}
@@ -0,0 +1,235 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// indexVersion is the format version of the index file. Indexes written
+// with another version are discarded and rebuilt.
+const indexVersion = 3
+
+// indexFile is the name of the index file in the cache directory.
+const indexFile = "recall-index.json"
+
+// Index is an inverted index over the chunks of session files.
+//
+// Chunks are numbered in order of file path and position in the file;
+// postings and lengths refer to chunks by that number.
+type Index struct {
+	Version int `json:"version"`
+	// Files maps session file paths to their indexed chunks.
+	Files map[string]*File `json:"files"`
+	// Postings maps terms to the chunks that contain them.
+	Postings map[string][]Posting `json:"postings"`
+	// Lengths holds the number of terms of each chunk.
+	Lengths []int `json:"lengths"`
+
+	path   string
+	chunks []*Chunk
+}
+
+// File is an indexed session file.
+type File struct {
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+	Chunks  []Chunk   `json:"chunks"`
+}
+
+// Posting records how often a term occurs in a chunk.
+type Posting struct {
+	Chunk int `json:"c"`
+	Freq  int `json:"f"`
+}
+
+// UpdateStats counts the files changed by an index update.
+type UpdateStats struct {
+	Added   int
+	Updated int
+	Removed int
+	// Errors are the parse errors of the files that failed to parse,
+	// each naming its file
+	Errors []error
+}
+
+// Changed reports whether the update changed the index.
+func (s UpdateStats) Changed() bool {
+	return s.Added+s.Updated+s.Removed > 0
+}
+
+// DefaultPath returns the path of the index file in the user cache
+// directory, e.g. ~/.cache/ctx/recall-index.json.
+func DefaultPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ctx", indexFile), nil
+}
+
+// DefaultRoots returns the directories indexed by default: the default
+// session directories of the supported tools followed by the given
+// directories.
+func DefaultRoots(dirs ...string) []string {
+	return append(parser.DefaultDirs(), dirs...)
+}
+
+// Open reads the index at path. A missing, unreadable or outdated index
+// yields an empty index that is written to path on Save.
+func Open(path string) (*Index, error) {
+	idx := &Index{path: path}
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if json.Unmarshal(content, idx) != nil || idx.Version != indexVersion {
+			idx = &Index{path: path}
+		}
+	}
+	if idx.Files == nil {
+		idx.Files = make(map[string]*File)
+	}
+
+	idx.number()
+	if len(idx.chunks) != len(idx.Lengths) {
+		idx.rebuild()
+	}
+	return idx, nil
+}
+
+// Update brings the index in line with the session files under roots.
+//
+// Files are parsed only when new or when their modification time or size
+// changed. Files that are gone, or no longer under any root, are dropped.
+// Missing roots are skipped.
+func (x *Index) Update(roots []string) (UpdateStats, error) {
+	var stats UpdateStats
+	seen := make(map[string]bool)
+
+	for _, root := range roots {
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			continue
+		}
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() || seen[path] {
+				return err
+			}
+			seen[path] = true
+
+			prev, ok := x.Files[path]
+			if ok && prev.ModTime.Equal(info.ModTime()) && prev.Size == info.Size() {
+				return nil
+			}
+
+			// Files no parser accepts are recorded without chunks, so they
+			// are not parsed again until they change. Files that fail to
+			// parse are not recorded, so they are parsed again next time.
+			sessions, err := parser.ParseFile(path)
+			if err != nil && !errors.Is(err, parser.ErrNoParser) {
+				stats.Errors = append(stats.Errors, fmt.Errorf("%s: %w", path, err))
+				return nil
+			}
+			if ok {
+				stats.Updated++
+			} else {
+				stats.Added++
+			}
+			f := &File{ModTime: info.ModTime(), Size: info.Size()}
+			for _, s := range sessions {
+				f.Chunks = append(f.Chunks, ChunkSession(s)...)
+			}
+			x.Files[path] = f
+			return nil
+		})
+		if err != nil {
+			return stats, err
+		}
+	}
+
+	for path := range x.Files {
+		if !seen[path] {
+			delete(x.Files, path)
+			stats.Removed++
+		}
+	}
+
+	if stats.Changed() {
+		x.number()
+		x.rebuild()
+	}
+	return stats, nil
+}
+
+// Save writes the index to its file, creating the directory if needed.
+func (x *Index) Save() error {
+	x.Version = indexVersion
+	content, err := json.Marshal(x)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(x.path), 0755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that an interrupted save never
+	// leaves a truncated index behind
+	tmp := x.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, x.path)
+}
+
+// Len returns the number of indexed chunks.
+func (x *Index) Len() int {
+	return len(x.chunks)
+}
+
+// number assigns chunk numbers in order of file path and position.
+func (x *Index) number() {
+	paths := make([]string, 0, len(x.Files))
+	for path := range x.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	x.chunks = x.chunks[:0]
+	for _, path := range paths {
+		f := x.Files[path]
+		for i := range f.Chunks {
+			x.chunks = append(x.chunks, &f.Chunks[i])
+		}
+	}
+}
+
+// rebuild recomputes postings and lengths from the chunk contents.
+func (x *Index) rebuild() {
+	x.Postings = make(map[string][]Posting)
+	x.Lengths = make([]int, len(x.chunks))
+	for n, c := range x.chunks {
+		terms := Tokenize(c.Content)
+		x.Lengths[n] = len(terms)
+
+		freq := make(map[string]int)
+		for _, t := range terms {
+			freq[t]++
+		}
+		for t, f := range freq {
+			x.Postings[t] = append(x.Postings[t], Posting{Chunk: n, Freq: f})
+		}
+	}
+}
@@ -0,0 +1,193 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package search
+
+import (
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BM25 parameters: term frequency saturation and length normalization.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// snippetWords is the number of words shown in a result snippet.
+const snippetWords = 30
+
+// Options restricts a search.
+type Options struct {
+	// Project keeps chunks whose project contains this text,
+	// case-insensitively.
+	Project string
+	// CWD keeps chunks of sessions run in this directory or below it.
+	CWD string
+	// Type keeps chunks of this type.
+	Type string
+	// Category and Outcome keep thinking chunks classified so.
+	Category string
+	Outcome  string
+	// Limit is the maximum number of results; 0 means no limit.
+	Limit int
+}
+
+// Result is a chunk matching a query.
+type Result struct {
+	Chunk *Chunk
+	Score float64
+	// Snippet is the part of the chunk with the most query terms, on a
+	// single line.
+	Snippet string
+}
+
+// Search ranks the chunks matching any term of the query with BM25,
+// best first. Chunks found in more than one file are returned once.
+func (x *Index) Search(query string, opts Options) []Result {
+	terms := unique(Tokenize(query))
+	if len(terms) == 0 || len(x.chunks) == 0 {
+		return nil
+	}
+
+	total := 0
+	for _, l := range x.Lengths {
+		total += l
+	}
+	n := float64(len(x.chunks))
+	avg := float64(total) / n
+	if avg == 0 {
+		avg = 1
+	}
+
+	scores := make(map[int]float64)
+	for _, t := range terms {
+		postings := x.Postings[t]
+		df := float64(len(postings))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for _, p := range postings {
+			tf := float64(p.Freq)
+			norm := 1 - bm25B + bm25B*float64(x.Lengths[p.Chunk])/avg
+			scores[p.Chunk] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+	}
+
+	project := strings.ToLower(opts.Project)
+	var results []Result
+	for i, score := range scores {
+		c := x.chunks[i]
+		if project != "" && !strings.Contains(strings.ToLower(c.Project), project) {
+			continue
+		}
+		if opts.CWD != "" && !within(c.CWD, opts.CWD) {
+			continue
+		}
+		if opts.Type != "" && c.Type != opts.Type {
+			continue
+		}
+		if opts.Category != "" && c.Category != opts.Category {
+			continue
+		}
+		if opts.Outcome != "" && c.Outcome != opts.Outcome {
+			continue
+		}
+		results = append(results, Result{Chunk: c, Score: score})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if !results[i].Chunk.Timestamp.Equal(results[j].Chunk.Timestamp) {
+			return results[i].Chunk.Timestamp.After(results[j].Chunk.Timestamp)
+		}
+		return results[i].Chunk.ID < results[j].Chunk.ID
+	})
+
+	seen := make(map[string]bool)
+	kept := results[:0]
+	for _, r := range results {
+		if seen[r.Chunk.ID] {
+			continue
+		}
+		seen[r.Chunk.ID] = true
+		r.Snippet = snippet(r.Chunk.Content, terms)
+		kept = append(kept, r)
+		if opts.Limit > 0 && len(kept) == opts.Limit {
+			break
+		}
+	}
+	return kept
+}
+
+// snippet returns the window of snippetWords words of content holding
+// the most distinct query terms, then the most matches.
+func snippet(content string, terms []string) string {
+	want := make(map[string]bool)
+	for _, t := range terms {
+		want[t] = true
+	}
+
+	words := strings.Fields(content)
+	hits := make([]map[string]bool, len(words))
+	for i, w := range words {
+		for _, t := range Tokenize(w) {
+			if want[t] {
+				if hits[i] == nil {
+					hits[i] = make(map[string]bool)
+				}
+				hits[i][t] = true
+			}
+		}
+	}
+
+	best, bestDistinct, bestTotal := 0, -1, -1
+	for start := 0; start == 0 || start+snippetWords <= len(words); start++ {
+		distinct := make(map[string]bool)
+		total := 0
+		for i := start; i < len(words) && i < start+snippetWords; i++ {
+			for t := range hits[i] {
+				distinct[t] = true
+				total++
+			}
+		}
+		if len(distinct) > bestDistinct ||
+			(len(distinct) == bestDistinct && total > bestTotal) {
+			best, bestDistinct, bestTotal = start, len(distinct), total
+		}
+	}
+
+	end := min(best+snippetWords, len(words))
+	s := strings.Join(words[best:end], " ")
+	if best > 0 {
+		s = "..." + s
+	}
+	if end < len(words) {
+		s += "..."
+	}
+	return s
+}
+
+// within reports whether path is dir or lies below it.
+func within(path, dir string) bool {
+	dir = filepath.Clean(dir)
+	path = filepath.Clean(path)
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
+// unique returns terms without duplicates, in order.
+func unique(terms []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, t := range terms {
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,268 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// sessionLine returns a Claude Code JSONL line for a message.
+func sessionLine(session, uuid, role, ts, content string) string {
+	return `{"uuid":"` + uuid + `","sessionId":"` + session + `","slug":"slug-` + session +
+		`","type":"` + role + `","timestamp":"` + ts + `","cwd":"/home/test/` + session +
+		`","version":"2.1.0","message":{"role":"` + role + `","content":` + content + `}}` + "\n"
+}
+
+// authSession discusses authentication; dbSession discusses databases.
+var (
+	authSession = sessionLine("auth", "a1", "user", "2026-01-20T10:00:00Z",
+		`[{"type":"text","text":"How should we handle authentication?"}]`) +
+		sessionLine("auth", "a2", "assistant", "2026-01-20T10:00:05Z",
+			`[{"type":"thinking","thinking":"JWT tokens are stateless, sessions need storage."},`+
+				`{"type":"text","text":"Use JWT tokens for authentication of API requests."},`+
+				`{"type":"tool_use","id":"t1","name":"Bash","input":{"command":"go get github.com/golang-jwt/jwt"}}]`) +
+		sessionLine("auth", "a3", "user", "2026-01-20T10:00:09Z",
+			`[{"type":"tool_result","tool_use_id":"t1","content":"added golang-jwt v5"}]`)
+	dbSession = sessionLine("db", "d1", "user", "2026-01-21T10:00:00Z",
+		`[{"type":"text","text":"Which database should we use?"}]`) +
+		sessionLine("db", "d2", "assistant", "2026-01-21T10:00:05Z",
+			`[{"type":"text","text":"PostgreSQL, because the data is relational."}]`)
+)
+
+// TestChunkSession tests splitting a session into chunks.
+func TestChunkSession(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "auth.jsonl")
+	if err := os.WriteFile(path, []byte(authSession), 0644); err != nil {
+		t.Fatalf("failed to write session: %v", err)
+	}
+	sessions, err := parser.ParseFile(path)
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("ParseFile = %v, %v", sessions, err)
+	}
+
+	chunks := ChunkSession(sessions[0])
+	want := []struct {
+		typ     string
+		message int
+		content string
+	}{
+		{TypeTurnPair, 0, "Q: How should we handle authentication?\n\nA: Use JWT tokens for authentication of API requests."},
+		{TypeThinking, 1, "JWT tokens are stateless, sessions need storage."},
+		{TypeTool, 1, "Bash {\"command\":\"go get github.com/golang-jwt/jwt\"}\nadded golang-jwt v5"},
+	}
+	if len(chunks) != len(want) {
+		t.Fatalf("got %d chunks, want %d: %+v", len(chunks), len(want), chunks)
+	}
+	for i, w := range want {
+		c := chunks[i]
+		if c.Type != w.typ || c.Message != w.message || c.Content != w.content {
+			t.Errorf("chunk %d = %s/%d %q, want %s/%d %q",
+				i, c.Type, c.Message, c.Content, w.typ, w.message, w.content)
+		}
+		if c.SessionID != "auth" || c.Project != "auth" || c.Timestamp.IsZero() {
+			t.Errorf("chunk %d source = %q %q %v", i, c.SessionID, c.Project, c.Timestamp)
+		}
+	}
+}
+
+// TestTokenize tests term extraction.
+func TestTokenize(t *testing.T) {
+	got := strings.Join(Tokenize("How did I handle the Dependencies of go:embed tests?"), " ")
+	want := "handle dependency go embed test"
+	if got != want {
+		t.Errorf("Tokenize() = %q, want %q", got, want)
+	}
+}
+
+// TestIndex tests incremental updates, persistence and ranking.
+func TestIndex(t *testing.T) {
+	dir := t.TempDir()
+	authPath := filepath.Join(dir, "auth.jsonl")
+	dbPath := filepath.Join(dir, "db.jsonl")
+	for path, content := range map[string]string{authPath: authSession, dbPath: dbSession} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write session: %v", err)
+		}
+	}
+	indexPath := filepath.Join(t.TempDir(), "index.json")
+
+	idx, err := Open(indexPath)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	stats, err := idx.Update([]string{dir, filepath.Join(dir, "missing")})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if !reflect.DeepEqual(stats, UpdateStats{Added: 2}) || idx.Len() != 4 {
+		t.Errorf("first update = %+v with %d chunks", stats, idx.Len())
+	}
+	if err := idx.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Reopened, the index is current and searchable
+	idx, err = Open(indexPath)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if stats, _ := idx.Update([]string{dir}); stats.Changed() {
+		t.Errorf("unchanged files were reindexed: %+v", stats)
+	}
+
+	results := idx.Search("JWT authentication", Options{})
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3: %+v", len(results), results)
+	}
+	if results[0].Chunk.Type != TypeTurnPair {
+		t.Errorf("best result is %s, want the turn pair", results[0].Chunk.Type)
+	}
+	for i := 1; i < len(results); i++ {
+		if results[i].Score > results[i-1].Score {
+			t.Errorf("results are not sorted by score")
+		}
+	}
+	if got := idx.Search("JWT", Options{Type: TypeTool}); len(got) != 1 {
+		t.Errorf("type filter returned %d results, want 1", len(got))
+	}
+	if got := idx.Search("database", Options{Project: "auth"}); len(got) != 0 {
+		t.Errorf("project filter returned %d results, want 0", len(got))
+	}
+
+	// A modified file is reindexed; a deleted one is dropped
+	later := time.Now().Add(time.Hour)
+	if err := os.WriteFile(dbPath, []byte(strings.ReplaceAll(dbSession, "PostgreSQL", "SQLite")), 0644); err != nil {
+		t.Fatalf("failed to rewrite session: %v", err)
+	}
+	if err := os.Chtimes(dbPath, later, later); err != nil {
+		t.Fatalf("Chtimes failed: %v", err)
+	}
+	if err := os.Remove(authPath); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	stats, err = idx.Update([]string{dir})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if !reflect.DeepEqual(stats, UpdateStats{Updated: 1, Removed: 1}) {
+		t.Errorf("second update = %+v", stats)
+	}
+	if got := idx.Search("postgresql", Options{}); len(got) != 0 {
+		t.Errorf("stale content is still indexed")
+	}
+	got := idx.Search("sqlite", Options{})
+	if len(got) != 1 || got[0].Chunk.SessionID != "db" {
+		t.Errorf("Search(sqlite) = %+v", got)
+	}
+}
+
+// TestSnippet tests that snippets center on the query terms.
+func TestSnippet(t *testing.T) {
+	content := strings.Repeat("filler ", 50) + "the token refresh flow" + strings.Repeat(" filler", 50)
+	got := snippet(content, Tokenize("token refresh"))
+	if !strings.HasPrefix(got, "...") || !strings.HasSuffix(got, "...") ||
+		!strings.Contains(got, "token refresh") {
+		t.Errorf("snippet = %q", got)
+	}
+	if short := snippet("token refresh", Tokenize("token")); short != "token refresh" {
+		t.Errorf("snippet of short content = %q", short)
+	}
+}
+
+// TestAuto tests finding history for the current project and branch.
+func TestAuto(t *testing.T) {
+	line := func(session, uuid, role, ts, cwd, branch, text string) string {
+		return `{"uuid":"` + uuid + `","sessionId":"` + session + `","slug":"slug-` + session +
+			`","type":"` + role + `","timestamp":"` + ts + `","cwd":"` + cwd +
+			`","gitBranch":"` + branch + `","version":"2.1.0","message":{"role":"` + role +
+			`","content":[{"type":"text","text":"` + text + `"}]}}` + "\n"
+	}
+	sessions := line("old", "o1", "user", "2026-01-10T10:00:00Z", "/src/app", "main", "Set up the cache layer") +
+		line("old", "o2", "assistant", "2026-01-10T10:00:05Z", "/src/app", "main", "Redis cache eviction uses LRU.") +
+		line("new", "n1", "user", "2026-01-20T10:00:00Z", "/src/app/web", "feature", "Style the login page") +
+		line("new", "n2", "user", "2026-01-20T11:00:00Z", "/src/app/web", "feature", "Why is the cache stale?") +
+		line("new", "n3", "assistant", "2026-01-20T11:00:05Z", "/src/app/web", "feature", "The cache TTL was never set.") +
+		line("other", "x1", "user", "2026-01-25T10:00:00Z", "/src/other", "main", "Tune the cache size")
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "s.jsonl"), []byte(sessions), 0644); err != nil {
+		t.Fatalf("failed to write sessions: %v", err)
+	}
+	idx, err := Open(filepath.Join(t.TempDir(), "index.json"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if _, err := idx.Update([]string{dir}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	res := idx.Auto(AutoQuery{
+		CWD: "/src/app", Branch: "feature", Text: "cache", Recent: 5, Related: 5,
+	})
+
+	var recent []string
+	for _, r := range res.Recent {
+		recent = append(recent, r.Chunk.SessionID+": "+r.Snippet)
+	}
+	if got := strings.Join(recent, " | "); got != "new: Style the login page | old: Set up the cache layer" {
+		t.Errorf("Recent = %q", got)
+	}
+
+	// The opening turns are already reported; of the rest, only the
+	// stale cache turn matches, and other projects are left out
+	if len(res.Related) != 1 || res.Related[0].Chunk.Message != 1 ||
+		res.Related[0].Chunk.SessionID != "new" {
+		t.Errorf("Related = %+v", res.Related)
+	}
+}
+
+// TestAntiPatterns tests grouping failed reasoning across sessions.
+func TestAntiPatterns(t *testing.T) {
+	thinking := func(id, outcome, content string) Result {
+		return Result{Chunk: &Chunk{
+			ID: id, Type: TypeThinking, Outcome: outcome, Content: content,
+		}}
+	}
+	results := []Result{
+		thinking("a", "failure", "Maybe raising the redis cache ttl fixes the stale sessions."),
+		thinking("b", "failure", "Raising the redis cache ttl again should keep sessions fresh."),
+		thinking("c", "failure", "The redis cache ttl is too short for sessions, raise it."),
+		thinking("d", "success", "Invalidate the redis cache on logout instead of raising the ttl for sessions."),
+		thinking("e", "success", "Split the parser into a lexer and a grammar."),
+		{Chunk: &Chunk{ID: "f", Type: TypeTurnPair, Content: "redis cache ttl sessions"}},
+	}
+
+	patterns := AntiPatterns(results)
+	if len(patterns) != 1 {
+		t.Fatalf("AntiPatterns() returned %d patterns, want 1", len(patterns))
+	}
+	p := patterns[0]
+	if p.Occurrences != 4 || len(p.Failures) != 3 {
+		t.Errorf("pattern has %d failures of %d, want 3 of 4",
+			len(p.Failures), p.Occurrences)
+	}
+	if p.Better == nil || p.Better.Chunk.ID != "d" {
+		t.Errorf("Better = %v, want chunk d", p.Better)
+	}
+	if len(p.Terms) == 0 {
+		t.Error("pattern has no terms")
+	}
+
+	// Below the failure rate nothing is reported
+	results[2].Chunk.Outcome = "success"
+	if patterns := AntiPatterns(results); len(patterns) != 0 {
+		t.Errorf("AntiPatterns() = %v, want none", patterns)
+	}
+}
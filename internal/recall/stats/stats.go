@@ -0,0 +1,243 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+// Package stats aggregates the token usage, estimated cost and tool use
+// of sessions.
+//
+// Sessions are grouped by day, project, model, branch or tool. Costs are
+// estimated from a pricing table of USD per million tokens, matched to
+// the session's model by name or longest name prefix; the built-in
+// table can be extended and overridden in .contextrc.
+//
+// Tool use is counted per tool, per normalized shell command, and per
+// file read or edited.
+package stats
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// Dimension is what sessions are grouped by.
+type Dimension string
+
+// Grouping dimensions.
+const (
+	ByDay     Dimension = "day"
+	ByProject Dimension = "project"
+	ByModel   Dimension = "model"
+	ByBranch  Dimension = "branch"
+	ByTool    Dimension = "tool"
+)
+
+// Dimensions lists the grouping dimensions.
+var Dimensions = []Dimension{ByDay, ByProject, ByModel, ByBranch, ByTool}
+
+// noKey groups sessions that have no value for the dimension.
+const noKey = "(none)"
+
+// Pricing maps model names or name prefixes to their prices.
+type Pricing map[string]config.ModelPrice
+
+// DefaultPricing holds list prices of common models, in USD per million
+// tokens. Entries in .contextrc take precedence.
+var DefaultPricing = Pricing{
+	"claude-opus-4":     {Input: 15, Output: 75, CacheWrite: 18.75, CacheRead: 1.5},
+	"claude-opus-4-5":   {Input: 5, Output: 25, CacheWrite: 6.25, CacheRead: 0.5},
+	"claude-sonnet-4":   {Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.3},
+	"claude-3-7-sonnet": {Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.3},
+	"claude-haiku-4-5":  {Input: 1, Output: 5, CacheWrite: 1.25, CacheRead: 0.1},
+	"claude-3-5-haiku":  {Input: 0.8, Output: 4, CacheWrite: 1, CacheRead: 0.08},
+	"gpt-5":             {Input: 1.25, Output: 10, CacheRead: 0.125},
+	"gpt-5-mini":        {Input: 0.25, Output: 2, CacheRead: 0.025},
+}
+
+// LoadPricing returns the default prices overlaid with the prices
+// declared in .contextrc.
+func LoadPricing() Pricing {
+	p := make(Pricing, len(DefaultPricing))
+	for model, price := range DefaultPricing {
+		p[model] = price
+	}
+	for model, price := range config.GetPricing() {
+		p[model] = price
+	}
+	return p
+}
+
+// Lookup returns the price of a model: the entry named after it, or else
+// the entry with the longest name that prefixes it.
+func (p Pricing) Lookup(model string) (config.ModelPrice, bool) {
+	if model == "" {
+		return config.ModelPrice{}, false
+	}
+	if price, ok := p[model]; ok {
+		return price, true
+	}
+	best := ""
+	for name := range p {
+		if strings.HasPrefix(model, name) && len(name) > len(best) {
+			best = name
+		}
+	}
+	if best == "" {
+		return config.ModelPrice{}, false
+	}
+	return p[best], true
+}
+
+// Cost returns the estimated cost of a session in USD, priced by its
+// primary model, and whether the model has a price.
+func (p Pricing) Cost(s *parser.Session) (float64, bool) {
+	price, ok := p.Lookup(s.Model)
+	if !ok {
+		return 0, false
+	}
+	return (float64(s.TotalTokensIn)*price.Input +
+		float64(s.TotalTokensOut)*price.Output +
+		float64(s.TotalTokensCacheWrite)*price.CacheWrite +
+		float64(s.TotalTokensCacheRead)*price.CacheRead) / 1e6, true
+}
+
+// Usage sums the token usage of a set of sessions.
+type Usage struct {
+	Sessions   int `json:"sessions"`
+	Turns      int `json:"turns"`
+	Errors     int `json:"sessions_with_errors"`
+	Input      int `json:"input_tokens"`
+	Output     int `json:"output_tokens"`
+	CacheWrite int `json:"cache_write_tokens"`
+	CacheRead  int `json:"cache_read_tokens"`
+	Total      int `json:"total_tokens"`
+	// Cost is the estimated cost in USD of the sessions with a price
+	Cost float64 `json:"cost_usd"`
+	// Unpriced counts the sessions whose model has no price
+	Unpriced int `json:"unpriced_sessions,omitempty"`
+}
+
+// Add adds a session to the usage.
+func (u *Usage) Add(s *parser.Session, p Pricing) {
+	u.Sessions++
+	u.Turns += s.TurnCount
+	if s.HasErrors {
+		u.Errors++
+	}
+	u.Input += s.TotalTokensIn
+	u.Output += s.TotalTokensOut
+	u.CacheWrite += s.TotalTokensCacheWrite
+	u.CacheRead += s.TotalTokensCacheRead
+	u.Total += s.SumTokens()
+	if cost, ok := p.Cost(s); ok {
+		u.Cost += cost
+	} else {
+		u.Unpriced++
+	}
+}
+
+// Group is the usage of the sessions sharing a key.
+type Group struct {
+	Key string `json:"key"`
+	Usage
+}
+
+// Report is the usage of sessions grouped by a dimension.
+type Report struct {
+	By     Dimension `json:"by"`
+	Groups []Group   `json:"groups"`
+	Total  Usage     `json:"total"`
+	// UnpricedModels lists the models without a price, sorted
+	UnpricedModels []string `json:"unpriced_models,omitempty"`
+	// ContextPacket compares the sessions that read "ctx agent" output
+	// with the others
+	ContextPacket Comparison `json:"context_packet"`
+}
+
+// Comparison splits usage between sessions with and without a property.
+type Comparison struct {
+	With    Usage `json:"with"`
+	Without Usage `json:"without"`
+}
+
+// Key returns the key of a session in a dimension. Days are local dates
+// of the session start, e.g. "2026-01-20".
+func Key(s *parser.Session, by Dimension) string {
+	var key string
+	switch by {
+	case ByDay:
+		if !s.StartTime.IsZero() {
+			key = s.StartTime.Local().Format("2006-01-02")
+		}
+	case ByProject:
+		key = s.Project
+	case ByModel:
+		key = s.Model
+	case ByBranch:
+		key = s.GitBranch
+	case ByTool:
+		key = s.Tool
+	}
+	if key == "" {
+		return noKey
+	}
+	return key
+}
+
+// Aggregate groups sessions by a dimension and sums their usage.
+//
+// Days are listed newest first; other groups by total tokens, the
+// largest first.
+func Aggregate(sessions []*parser.Session, by Dimension, p Pricing) Report {
+	r := Report{By: by}
+	index := make(map[string]int)
+	unpriced := make(map[string]bool)
+
+	for _, s := range sessions {
+		key := Key(s, by)
+		i, ok := index[key]
+		if !ok {
+			i = len(r.Groups)
+			index[key] = i
+			r.Groups = append(r.Groups, Group{Key: key})
+		}
+		r.Groups[i].Add(s, p)
+		r.Total.Add(s, p)
+
+		if s.ContextPacket {
+			r.ContextPacket.With.Add(s, p)
+		} else {
+			r.ContextPacket.Without.Add(s, p)
+		}
+
+		if _, ok := p.Lookup(s.Model); !ok {
+			model := s.Model
+			if model == "" {
+				model = noKey
+			}
+			unpriced[model] = true
+		}
+	}
+
+	sort.SliceStable(r.Groups, func(i, j int) bool {
+		a, b := r.Groups[i], r.Groups[j]
+		if by == ByDay {
+			return a.Key > b.Key
+		}
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return a.Key < b.Key
+	})
+
+	for model := range unpriced {
+		r.UnpricedModels = append(r.UnpricedModels, model)
+	}
+	slices.Sort(r.UnpricedModels)
+	return r
+}
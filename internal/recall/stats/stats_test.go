@@ -0,0 +1,103 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+func TestPricingLookup(t *testing.T) {
+	p := Pricing{
+		"claude-opus-4":   {Input: 15},
+		"claude-opus-4-5": {Input: 5},
+		"local-model":     {Input: 1},
+	}
+	tests := []struct {
+		model string
+		want  float64
+		ok    bool
+	}{
+		{"local-model", 1, true},
+		{"claude-opus-4-1-20250805", 15, true},
+		{"claude-opus-4-5-20251101", 5, true},
+		{"gpt-4o", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		price, ok := p.Lookup(tt.model)
+		if ok != tt.ok || price.Input != tt.want {
+			t.Errorf("Lookup(%q) = %v, %v; want input %v, %v", tt.model, price, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestPricingCost(t *testing.T) {
+	p := Pricing{"m": config.ModelPrice{Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.3}}
+	s := &parser.Session{
+		Model:                 "m",
+		TotalTokensIn:         1_000_000,
+		TotalTokensOut:        100_000,
+		TotalTokensCacheWrite: 200_000,
+		TotalTokensCacheRead:  10_000_000,
+	}
+	cost, ok := p.Cost(s)
+	// 3 + 1.5 + 0.75 + 3
+	if !ok || math.Abs(cost-8.25) > 1e-9 {
+		t.Errorf("Cost = %v, %v; want 8.25, true", cost, ok)
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2026, 1, d, 12, 0, 0, 0, time.Local)
+	}
+	sessions := []*parser.Session{
+		{Project: "api", Model: "m", StartTime: day(20), TurnCount: 2, TotalTokensIn: 100, TotalTokensOut: 50, ContextPacket: true},
+		{Project: "web", Model: "m", StartTime: day(21), TurnCount: 1, TotalTokensIn: 1000, TotalTokensCacheRead: 5000, HasErrors: true},
+		{Project: "api", Model: "other", StartTime: day(21), TurnCount: 3, TotalTokensIn: 10},
+		{StartTime: day(19), TurnCount: 1},
+	}
+	p := Pricing{"m": {Input: 1}}
+
+	r := Aggregate(sessions, ByProject, p)
+	var keys []string
+	for _, g := range r.Groups {
+		keys = append(keys, g.Key)
+	}
+	if want := []string{"web", "api", "(none)"}; !slices.Equal(keys, want) {
+		t.Errorf("project groups = %v, want %v", keys, want)
+	}
+	api := r.Groups[1]
+	if api.Sessions != 2 || api.Turns != 5 || api.Total != 160 || api.Unpriced != 1 {
+		t.Errorf("api usage = %+v", api.Usage)
+	}
+	if r.Total.Sessions != 4 || r.Total.CacheRead != 5000 || r.Total.Errors != 1 {
+		t.Errorf("total usage = %+v", r.Total)
+	}
+	if want := []string{"(none)", "other"}; !slices.Equal(r.UnpricedModels, want) {
+		t.Errorf("UnpricedModels = %v, want %v", r.UnpricedModels, want)
+	}
+	if r.ContextPacket.With.Sessions != 1 || r.ContextPacket.Without.Sessions != 3 {
+		t.Errorf("context packet split = %d/%d, want 1/3",
+			r.ContextPacket.With.Sessions, r.ContextPacket.Without.Sessions)
+	}
+
+	r = Aggregate(sessions, ByDay, p)
+	keys = nil
+	for _, g := range r.Groups {
+		keys = append(keys, g.Key)
+	}
+	if want := []string{"2026-01-21", "2026-01-20", "2026-01-19"}; !slices.Equal(keys, want) {
+		t.Errorf("day groups = %v, want %v", keys, want)
+	}
+}
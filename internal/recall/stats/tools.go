@@ -0,0 +1,230 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+// ToolUsage counts the calls of a tool.
+type ToolUsage struct {
+	Name   string `json:"name"`
+	Calls  int    `json:"calls"`
+	Errors int    `json:"errors"`
+}
+
+// CommandUsage counts the runs of a normalized shell command, e.g.
+// "go test" for "cd api && go test ./... -run TestRetry".
+type CommandUsage struct {
+	Command  string `json:"command"`
+	Runs     int    `json:"runs"`
+	Failures int    `json:"failures"`
+	Sessions int    `json:"sessions"`
+	// Example is the last failing invocation as it was run
+	Example string `json:"example,omitempty"`
+}
+
+// FileUsage counts the reads and edits of a file. Paths inside the
+// session's working directory are relative to it.
+type FileUsage struct {
+	Path     string `json:"path"`
+	Reads    int    `json:"reads"`
+	Edits    int    `json:"edits"`
+	Sessions int    `json:"sessions"`
+}
+
+// ToolReport is the tool usage of a set of sessions.
+type ToolReport struct {
+	Sessions int         `json:"sessions"`
+	Tools    []ToolUsage `json:"tools"`
+	// Commands are the shell commands that failed at least once, the
+	// most failures first
+	Commands []CommandUsage `json:"failing_commands"`
+	// Files are the files read or edited, the most used first
+	Files []FileUsage `json:"files"`
+}
+
+// readTools and editTools are the tools that read and change files.
+var (
+	readTools = map[string]bool{"Read": true}
+	editTools = map[string]bool{
+		"Edit": true, "MultiEdit": true, "Write": true, "NotebookEdit": true,
+		"apply_patch": true,
+	}
+)
+
+// commandWordPattern matches subcommands such as "test" in "go test".
+var commandWordPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// envAssignPattern matches leading variable assignments such as
+// "CGO_ENABLED=0".
+var envAssignPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*=`)
+
+// Tools counts tool calls, failing shell commands and file use over the
+// messages of sessions, including abandoned branches and subagent
+// conversations. Sessions must be loaded (see parser.Session.Load).
+func Tools(sessions []*parser.Session) ToolReport {
+	r := ToolReport{Sessions: len(sessions)}
+	tools := make(map[string]*ToolUsage)
+	commands := make(map[string]*CommandUsage)
+	files := make(map[string]*FileUsage)
+
+	for _, s := range sessions {
+		msgs := s.AllMessages()
+		results := make(map[string]parser.ToolResult)
+		for _, m := range msgs {
+			for _, tr := range m.ToolResults {
+				results[tr.ToolUseID] = tr
+			}
+		}
+
+		seenCommands := make(map[string]bool)
+		seenFiles := make(map[string]bool)
+		for _, m := range msgs {
+			for _, tu := range m.ToolUses {
+				failed := tu.ID != "" && results[tu.ID].IsError
+
+				t := tools[tu.Name]
+				if t == nil {
+					t = &ToolUsage{Name: tu.Name}
+					tools[tu.Name] = t
+				}
+				t.Calls++
+				if failed {
+					t.Errors++
+				}
+
+				if parser.ShellTools[tu.Name] {
+					raw := tu.Command()
+					name := NormalizeCommand(raw)
+					if name == "" {
+						continue
+					}
+					c := commands[name]
+					if c == nil {
+						c = &CommandUsage{Command: name}
+						commands[name] = c
+					}
+					c.Runs++
+					if failed {
+						c.Failures++
+						c.Example = raw
+					}
+					if !seenCommands[name] {
+						seenCommands[name] = true
+						c.Sessions++
+					}
+					continue
+				}
+
+				if !readTools[tu.Name] && !editTools[tu.Name] {
+					continue
+				}
+				for _, path := range tu.FilePaths() {
+					path = relativePath(s.CWD, parser.ResolvePath(s.CWD, path))
+					f := files[path]
+					if f == nil {
+						f = &FileUsage{Path: path}
+						files[path] = f
+					}
+					if editTools[tu.Name] {
+						f.Edits++
+					} else {
+						f.Reads++
+					}
+					if !seenFiles[path] {
+						seenFiles[path] = true
+						f.Sessions++
+					}
+				}
+			}
+		}
+	}
+
+	for _, t := range tools {
+		r.Tools = append(r.Tools, *t)
+	}
+	sort.Slice(r.Tools, func(i, j int) bool {
+		a, b := r.Tools[i], r.Tools[j]
+		if a.Calls != b.Calls {
+			return a.Calls > b.Calls
+		}
+		return a.Name < b.Name
+	})
+
+	for _, c := range commands {
+		if c.Failures > 0 {
+			r.Commands = append(r.Commands, *c)
+		}
+	}
+	sort.Slice(r.Commands, func(i, j int) bool {
+		a, b := r.Commands[i], r.Commands[j]
+		if a.Failures != b.Failures {
+			return a.Failures > b.Failures
+		}
+		return a.Command < b.Command
+	})
+
+	for _, f := range files {
+		r.Files = append(r.Files, *f)
+	}
+	sort.Slice(r.Files, func(i, j int) bool {
+		a, b := r.Files[i], r.Files[j]
+		if a.Reads+a.Edits != b.Reads+b.Edits {
+			return a.Reads+a.Edits > b.Reads+b.Edits
+		}
+		return a.Path < b.Path
+	})
+
+	return r
+}
+
+// NormalizeCommand reduces a shell command line to the program it runs
+// and its subcommand, e.g. "go test" for "cd api && go test ./... 2>&1 |
+// tail". Leading directory changes and variable assignments are skipped.
+func NormalizeCommand(line string) string {
+	for _, part := range splitCommands(line) {
+		words := strings.Fields(part)
+		for len(words) > 0 && envAssignPattern.MatchString(words[0]) {
+			words = words[1:]
+		}
+		if len(words) == 0 || words[0] == "cd" {
+			continue
+		}
+		name := filepath.Base(words[0])
+		if len(words) > 1 && commandWordPattern.MatchString(words[1]) {
+			name += " " + words[1]
+		}
+		return name
+	}
+	return ""
+}
+
+// splitCommands splits a command line at "&&", "||", ";", "|" and line
+// breaks.
+func splitCommands(line string) []string {
+	return strings.FieldsFunc(strings.NewReplacer("&&", ";", "||", ";", "|", ";", "\n", ";").Replace(line),
+		func(r rune) bool { return r == ';' })
+}
+
+// relativePath returns a path relative to a working directory when it
+// lies inside it.
+func relativePath(cwd, path string) string {
+	if cwd == "" || !filepath.IsAbs(path) {
+		return path
+	}
+	rel, err := filepath.Rel(cwd, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return path
+	}
+	return rel
+}
@@ -0,0 +1,100 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import (
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/recall/parser"
+)
+
+func TestNormalizeCommand(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"go test ./...", "go test"},
+		{"cd api && go test ./... -run TestRetry 2>&1 | tail -20", "go test"},
+		{"CGO_ENABLED=0 go build -o /tmp/x ./cmd/x", "go build"},
+		{"pytest tests/test_api.py -x", "pytest"},
+		{"/usr/local/bin/make lint", "make lint"},
+		{"npm run build; npm test", "npm run"},
+		{"git commit -m 'fix'", "git commit"},
+		{"cd /tmp", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeCommand(tt.line); got != tt.want {
+			t.Errorf("NormalizeCommand(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestTools(t *testing.T) {
+	bash := func(id, command string) parser.ToolUse {
+		return parser.ToolUse{ID: id, Name: "Bash", Input: `{"command":"` + command + `"}`}
+	}
+	result := func(id string, failed bool) parser.ToolResult {
+		return parser.ToolResult{ToolUseID: id, IsError: failed}
+	}
+
+	sessions := []*parser.Session{
+		{
+			CWD: "/src/api",
+			Messages: []parser.Message{
+				{Role: "assistant", ToolUses: []parser.ToolUse{
+					bash("t1", "go test ./..."),
+					{ID: "t2", Name: "Read", Input: `{"file_path":"/src/api/retry.go"}`},
+				}},
+				{Role: "user", ToolResults: []parser.ToolResult{result("t1", true), result("t2", false)}},
+				{Role: "assistant", ToolUses: []parser.ToolUse{
+					{ID: "t3", Name: "Edit", Input: `{"file_path":"/src/api/retry.go"}`},
+					{ID: "t4", Name: "Task", Input: `{}`, Sidechain: []parser.Message{
+						{Role: "assistant", ToolUses: []parser.ToolUse{bash("s1", "cd api && go test -run TestRetry")}},
+						{Role: "user", ToolResults: []parser.ToolResult{result("s1", true)}},
+					}},
+				}},
+			},
+		},
+		{
+			CWD: "/src/web",
+			Messages: []parser.Message{
+				{Role: "assistant", ToolUses: []parser.ToolUse{
+					bash("w1", "go test ./..."),
+					bash("w2", "ls"),
+					{ID: "w3", Name: "apply_patch", Input: "*** Begin Patch\n*** Update File: /src/api/retry.go\n*** Add File: web.go\n*** End Patch"},
+				}},
+				{Role: "user", ToolResults: []parser.ToolResult{result("w1", false), result("w2", true)}},
+			},
+		},
+	}
+
+	r := Tools(sessions)
+	if r.Sessions != 2 {
+		t.Errorf("Sessions = %d, want 2", r.Sessions)
+	}
+	if len(r.Tools) == 0 || r.Tools[0].Name != "Bash" || r.Tools[0].Calls != 4 || r.Tools[0].Errors != 3 {
+		t.Errorf("first tool = %+v, want Bash with 4 calls and 3 errors", r.Tools)
+	}
+
+	if len(r.Commands) != 2 {
+		t.Fatalf("failing commands = %+v, want go test and ls", r.Commands)
+	}
+	gt := r.Commands[0]
+	if gt.Command != "go test" || gt.Failures != 2 || gt.Runs != 3 || gt.Sessions != 2 ||
+		gt.Example != "cd api && go test -run TestRetry" {
+		t.Errorf("go test = %+v", gt)
+	}
+
+	// Paths outside the session's working directory stay absolute
+	if len(r.Files) != 3 || r.Files[1].Path != "/src/api/retry.go" || r.Files[2].Path != "web.go" {
+		t.Fatalf("files = %+v, want retry.go, /src/api/retry.go and web.go", r.Files)
+	}
+	if f := r.Files[0]; f.Path != "retry.go" || f.Reads != 1 || f.Edits != 1 || f.Sessions != 1 {
+		t.Errorf("first file = %+v, want retry.go read and edited once", f)
+	}
+}
@@ -26,8 +26,19 @@ if [ -z "$TRANSCRIPT_PATH" ] || [ ! -f "$TRANSCRIPT_PATH" ]; then
     exit 0
 fi
 
+# Resolve the context directory: CTX_DIR > .contextrc context_dir > .context
+CONTEXT_DIR="${CTX_DIR:-}"
+if [ -z "$CONTEXT_DIR" ] && [ -f "$PROJECT_DIR/.contextrc" ]; then
+    CONTEXT_DIR=$(sed -n 's/^context_dir:[[:space:]]*//p' "$PROJECT_DIR/.contextrc" | tr -d "\"'" | head -1)
+fi
+CONTEXT_DIR="${CONTEXT_DIR:-.context}"
+case "$CONTEXT_DIR" in
+    /*) ;;
+    *) CONTEXT_DIR="$PROJECT_DIR/$CONTEXT_DIR" ;;
+esac
+
 # Create sessions directory if it doesn't exist
-SESSIONS_DIR="$PROJECT_DIR/.context/sessions"
+SESSIONS_DIR="$CONTEXT_DIR/sessions"
 mkdir -p "$SESSIONS_DIR"
 
 # Generate filename with timestamp: YYYY-MM-DD-HHMMSS-session-<reason>.jsonl